@@ -6,6 +6,7 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
@@ -14,62 +15,545 @@ import (
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+// Defines values for CancellationReason.
+const (
+	CustomerRequest CancellationReason = "customer_request"
+	DuplicateOrder  CancellationReason = "duplicate_order"
+	FraudSuspected  CancellationReason = "fraud_suspected"
+	Other           CancellationReason = "other"
+	OutOfStock      CancellationReason = "out_of_stock"
+	PaymentFailed   CancellationReason = "payment_failed"
+)
+
+// Defines values for OrderHistoryEntryAction.
+const (
+	OrderHistoryEntryActionCanceled      OrderHistoryEntryAction = "canceled"
+	OrderHistoryEntryActionCreated       OrderHistoryEntryAction = "created"
+	OrderHistoryEntryActionStatusChanged OrderHistoryEntryAction = "status_changed"
+)
+
 // Defines values for OrderStatus.
 const (
-	Cancelled OrderStatus = "cancelled"
-	Delivered OrderStatus = "delivered"
-	Pending   OrderStatus = "pending"
-	Shipped   OrderStatus = "shipped"
+	OrderStatusCanceled  OrderStatus = "canceled"
+	OrderStatusDelivered OrderStatus = "delivered"
+	OrderStatusPending   OrderStatus = "pending"
+	OrderStatusShipped   OrderStatus = "shipped"
+)
+
+// Defines values for ReadinessStatus.
+const (
+	NotReady     ReadinessStatus = "not_ready"
+	Ready        ReadinessStatus = "ready"
+	ShuttingDown ReadinessStatus = "shutting_down"
+)
+
+// Defines values for ReadinessCheckStatus.
+const (
+	Down ReadinessCheckStatus = "down"
+	Up   ReadinessCheckStatus = "up"
+)
+
+// Defines values for TransitionOrderRequestStatus.
+const (
+	Delivered TransitionOrderRequestStatus = "delivered"
+	Shipped   TransitionOrderRequestStatus = "shipped"
+)
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	Failed    WebhookDeliveryStatus = "failed"
+	Pending   WebhookDeliveryStatus = "pending"
+	Succeeded WebhookDeliveryStatus = "succeeded"
+)
+
+// Defines values for WebhookEventType.
+const (
+	OrderCanceled      WebhookEventType = "order.canceled"
+	OrderCreated       WebhookEventType = "order.created"
+	OrderStatusChanged WebhookEventType = "order.status_changed"
+)
+
+// Defines values for ListOrdersParamsSort.
+const (
+	CreatedAt      ListOrdersParamsSort = "createdAt"
+	MinusCreatedAt ListOrdersParamsSort = "-createdAt"
 )
 
+// Amount Exact decimal amount, the number of fractional digits may not exceed the minor unit of the currency.
+type Amount = string
+
+// CancelOrderRequest defines model for CancelOrderRequest.
+type CancelOrderRequest struct {
+	Note   *string            `json:"note,omitempty"`
+	Reason CancellationReason `json:"reason"`
+}
+
+// CancellationReason defines model for CancellationReason.
+type CancellationReason string
+
 // CreateOrderRequest defines model for CreateOrderRequest.
 type CreateOrderRequest struct {
+	// Currency ISO 4217 currency code.
+	Currency Currency `json:"currency"`
+
+	// IdempotencyKey Use the Idempotency-Key header instead, ignored when the header is present.
+	// Deprecated: this property has been marked as deprecated upstream, but no `x-deprecated-reason` was set
 	IdempotencyKey  *openapi_types.UUID `json:"idempotencyKey,omitempty"`
-	Quantity        int                 `json:"quantity"`
-	ShippingAddress string              `json:"shippingAddress"`
-	Sku             openapi_types.UUID  `json:"sku"`
+	Items           []OrderItem         `json:"items"`
+	ShippingAddress ShippingAddress     `json:"shippingAddress"`
+}
+
+// CreateWebhookSubscriptionRequest defines model for CreateWebhookSubscriptionRequest.
+type CreateWebhookSubscriptionRequest struct {
+	// EventTypes Event types to deliver, every event type when empty.
+	EventTypes *[]WebhookEventType `json:"eventTypes,omitempty"`
+
+	// Secret Shared secret of the HMAC-SHA256 signature, generated when not given.
+	Secret *string `json:"secret,omitempty"`
+
+	// Url Https url of the endpoint, it must not point at a loopback, private or link-local address, including host names resolving to one.
+	Url string `json:"url"`
+}
+
+// Currency ISO 4217 currency code.
+type Currency = string
+
+// DeadLetterEvent defines model for DeadLetterEvent.
+type DeadLetterEvent struct {
+	AggregateId *openapi_types.UUID `json:"aggregateId,omitempty"`
+	Attempts    int                 `json:"attempts"`
+	CreatedAt   time.Time           `json:"createdAt"`
+
+	// Error Error of the last processing attempt.
+	Error string `json:"error"`
+
+	// EventId Id of the message the event was received in.
+	EventId   string `json:"eventId"`
+	EventType string `json:"eventType"`
+	Id        int64  `json:"id"`
+
+	// Payload The event in CloudEvents structured mode, a JSON string holding the received message for events that are not valid JSON.
+	Payload json.RawMessage `json:"payload"`
+
+	// Trace Propagation headers of the event keyed by their name, like traceparent, tracestate and baggage.
+	Trace TraceEnvelope `json:"trace"`
+}
+
+// FieldError defines model for FieldError.
+type FieldError struct {
+	// Field JSON pointer into the request body or name of the parameter.
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ListDeadLetterEventsResponse defines model for ListDeadLetterEventsResponse.
+type ListDeadLetterEventsResponse struct {
+	Items      []DeadLetterEvent `json:"items"`
+	NextCursor *string           `json:"nextCursor,omitempty"`
+}
+
+// ListOrdersResponse defines model for ListOrdersResponse.
+type ListOrdersResponse struct {
+	Items      []Order `json:"items"`
+	NextCursor *string `json:"nextCursor,omitempty"`
+}
+
+// ListWebhookDeliveriesResponse defines model for ListWebhookDeliveriesResponse.
+type ListWebhookDeliveriesResponse struct {
+	Items []WebhookDelivery `json:"items"`
+}
+
+// ListWebhookSubscriptionsResponse defines model for ListWebhookSubscriptionsResponse.
+type ListWebhookSubscriptionsResponse struct {
+	Items []WebhookSubscription `json:"items"`
 }
 
 // Order defines model for Order.
 type Order struct {
-	CreatedAt       time.Time          `json:"createdAt"`
-	Id              openapi_types.UUID `json:"id"`
-	Quantity        int                `json:"quantity"`
-	ShippingAddress string             `json:"shippingAddress"`
-	Sku             openapi_types.UUID `json:"sku"`
-	Status          OrderStatus        `json:"status"`
-	UpdatedAt       time.Time          `json:"updatedAt"`
+	Cancellation *OrderCancellation `json:"cancellation,omitempty"`
+	CreatedAt    time.Time          `json:"createdAt"`
+
+	// Currency ISO 4217 currency code.
+	Currency Currency           `json:"currency"`
+	Id       openapi_types.UUID `json:"id"`
+	Items    []OrderItem        `json:"items"`
+
+	// LegacyShippingAddress Free text address of orders created before structured addresses were introduced.
+	LegacyShippingAddress *string          `json:"legacyShippingAddress,omitempty"`
+	ShippingAddress       *ShippingAddress `json:"shippingAddress,omitempty"`
+	Status                OrderStatus      `json:"status"`
+
+	// Subtotal Exact decimal amount, the number of fractional digits may not exceed the minor unit of the currency.
+	Subtotal Amount `json:"subtotal"`
+
+	// Tax Exact decimal amount, the number of fractional digits may not exceed the minor unit of the currency.
+	Tax Amount `json:"tax"`
+
+	// Total Exact decimal amount, the number of fractional digits may not exceed the minor unit of the currency.
+	Total     Amount    `json:"total"`
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	// Version Incremented on every change of the order, also returned as ETag.
+	Version int `json:"version"`
+}
+
+// OrderCancellation defines model for OrderCancellation.
+type OrderCancellation struct {
+	CanceledAt time.Time          `json:"canceledAt"`
+	Note       *string            `json:"note,omitempty"`
+	Reason     CancellationReason `json:"reason"`
+}
+
+// OrderHistoryEntry defines model for OrderHistoryEntry.
+type OrderHistoryEntry struct {
+	Action    OrderHistoryEntryAction `json:"action"`
+	Actor     string                  `json:"actor"`
+	CreatedAt time.Time               `json:"createdAt"`
+	Id        int64                   `json:"id"`
+	NewValues map[string]interface{}  `json:"newValues"`
+	OldValues *map[string]interface{} `json:"oldValues,omitempty"`
+	TraceId   *string                 `json:"traceId,omitempty"`
+}
+
+// OrderHistoryEntryAction defines model for OrderHistoryEntry.Action.
+type OrderHistoryEntryAction string
+
+// OrderHistoryResponse defines model for OrderHistoryResponse.
+type OrderHistoryResponse struct {
+	Items []OrderHistoryEntry `json:"items"`
 }
 
-// OrderStatus defines model for Order.Status.
+// OrderItem defines model for OrderItem.
+type OrderItem struct {
+	Quantity int                `json:"quantity"`
+	Sku      openapi_types.UUID `json:"sku"`
+
+	// UnitPrice Exact decimal amount, the number of fractional digits may not exceed the minor unit of the currency. Unit prices stay below 10^9 so the totals of the largest order fit in the 15 integer digits amounts are stored with.
+	UnitPrice UnitPrice `json:"unitPrice"`
+}
+
+// OrderStatus defines model for OrderStatus.
 type OrderStatus string
 
-// ErrorResponse defines model for ErrorResponse.
-type ErrorResponse struct {
-	Code  int    `json:"code"`
-	Error string `json:"error"`
+// OrderStatusEvent defines model for OrderStatusEvent.
+type OrderStatusEvent struct {
+	// Action Action of the order history entry.
+	Action    string    `json:"action"`
+	Actor     string    `json:"actor"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Id Id of the order history entry.
+	Id             int64              `json:"id"`
+	OrderId        openapi_types.UUID `json:"orderId"`
+	PreviousStatus *OrderStatus       `json:"previousStatus,omitempty"`
+	Status         OrderStatus        `json:"status"`
+	TraceId        *string            `json:"traceId,omitempty"`
+}
+
+// Problem RFC 7807 problem details.
+type Problem struct {
+	// Code Machine-readable error code, unlike the detail it does not change. One of malformed_request,
+	// validation_failed, invalid_cursor, not_found, method_not_allowed, internal_error,
+	// order_not_found, illegal_status_transition, concurrent_modification, precondition_required,
+	// version_mismatch, idempotency_key_reused, webhook_not_found, dead_letter_event_not_found and
+	// dead_letter_event_not_replayable.
+	Code string `json:"code"`
+
+	// Detail Explanation of this occurrence of the problem, meant for humans.
+	Detail *string `json:"detail,omitempty"`
+
+	// Errors Invalid parts of the request.
+	Errors *[]FieldError `json:"errors,omitempty"`
+
+	// Instance Path of the request.
+	Instance string `json:"instance"`
+	Status   int    `json:"status"`
+
+	// Title Text of the status code.
+	Title string `json:"title"`
+
+	// TraceId Id of the trace of the request.
+	TraceId *string `json:"traceId,omitempty"`
+
+	// Type URI identifying the kind of the problem, derived from the code.
+	Type string `json:"type"`
+}
+
+// Readiness defines model for Readiness.
+type Readiness struct {
+	Checks    []ReadinessCheck `json:"checks"`
+	Status    ReadinessStatus  `json:"status"`
+	Timestamp time.Time        `json:"timestamp"`
+}
+
+// ReadinessStatus defines model for Readiness.Status.
+type ReadinessStatus string
+
+// ReadinessCheck defines model for ReadinessCheck.
+type ReadinessCheck struct {
+	DurationMs float64              `json:"durationMs"`
+	Error      *string              `json:"error,omitempty"`
+	Name       string               `json:"name"`
+	Status     ReadinessCheckStatus `json:"status"`
+}
+
+// ReadinessCheckStatus defines model for ReadinessCheck.Status.
+type ReadinessCheckStatus string
+
+// ShippingAddress defines model for ShippingAddress.
+type ShippingAddress struct {
+	City string `json:"city"`
+
+	// Country ISO 3166-1 alpha-2 country code.
+	Country string   `json:"country"`
+	Lines   []string `json:"lines"`
+	Name    string   `json:"name"`
+
+	// PostalCode Validated against the postal code format of the country.
+	PostalCode string  `json:"postalCode"`
+	Region     *string `json:"region,omitempty"`
+}
+
+// TraceEnvelope Propagation headers of the event keyed by their name, like traceparent, tracestate and baggage.
+type TraceEnvelope map[string]string
+
+// TransitionOrderRequest defines model for TransitionOrderRequest.
+type TransitionOrderRequest struct {
+	// Status Target status, orders are canceled via the cancel endpoint.
+	Status TransitionOrderRequestStatus `json:"status"`
+}
+
+// TransitionOrderRequestStatus Target status, orders are canceled via the cancel endpoint.
+type TransitionOrderRequestStatus string
+
+// UnitPrice Exact decimal amount, the number of fractional digits may not exceed the minor unit of the currency. Unit prices stay below 10^9 so the totals of the largest order fit in the 15 integer digits amounts are stored with.
+type UnitPrice = string
+
+// UpdateWebhookSubscriptionRequest defines model for UpdateWebhookSubscriptionRequest.
+type UpdateWebhookSubscriptionRequest struct {
+	// Active Enabling a subscription again resets its failure count.
+	Active     *bool               `json:"active,omitempty"`
+	EventTypes *[]WebhookEventType `json:"eventTypes,omitempty"`
+
+	// Url Https url of the endpoint, it must not point at a loopback, private or link-local address, including host names resolving to one.
+	Url *string `json:"url,omitempty"`
+}
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	Attempts       int                   `json:"attempts"`
+	CreatedAt      time.Time             `json:"createdAt"`
+	DeliveredAt    *time.Time            `json:"deliveredAt,omitempty"`
+	EventId        string                `json:"eventId"`
+	EventType      WebhookEventType      `json:"eventType"`
+	Id             openapi_types.UUID    `json:"id"`
+	LastError      *string               `json:"lastError,omitempty"`
+	LastStatusCode *int                  `json:"lastStatusCode,omitempty"`
+	NextAttemptAt  time.Time             `json:"nextAttemptAt"`
+	Status         WebhookDeliveryStatus `json:"status"`
+}
+
+// WebhookDeliveryStatus defines model for WebhookDelivery.Status.
+type WebhookDeliveryStatus string
+
+// WebhookEventType defines model for WebhookEventType.
+type WebhookEventType string
+
+// WebhookSubscription defines model for WebhookSubscription.
+type WebhookSubscription struct {
+	Active              bool               `json:"active"`
+	ConsecutiveFailures int                `json:"consecutiveFailures"`
+	CreatedAt           time.Time          `json:"createdAt"`
+	DisabledAt          *time.Time         `json:"disabledAt,omitempty"`
+	EventTypes          []WebhookEventType `json:"eventTypes"`
+	Id                  openapi_types.UUID `json:"id"`
+	Secret              *string            `json:"secret,omitempty"`
+	UpdatedAt           time.Time          `json:"updatedAt"`
+	Url                 string             `json:"url"`
 }
 
+// Actor defines model for Actor.
+type Actor = string
+
+// IfMatch defines model for IfMatch.
+type IfMatch = string
+
+// IfNoneMatch defines model for IfNoneMatch.
+type IfNoneMatch = string
+
+// LastEventID defines model for LastEventID.
+type LastEventID = int64
+
+// ErrorResponse RFC 7807 problem details.
+type ErrorResponse = Problem
+
 // GetHealthResponse defines model for GetHealthResponse.
 type GetHealthResponse struct {
 	Status    string    `json:"status"`
 	Timestamp time.Time `json:"timestamp"`
 }
 
+// ReadinessResponse defines model for ReadinessResponse.
+type ReadinessResponse = Readiness
+
+// ListDeadLetterEventsParams defines parameters for ListDeadLetterEvents.
+type ListDeadLetterEventsParams struct {
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque cursor returned as nextCursor by the previous page.
+	Cursor    *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+	EventType *string `form:"eventType,omitempty" json:"eventType,omitempty"`
+}
+
+// ListOrdersParams defines parameters for ListOrders.
+type ListOrdersParams struct {
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque cursor returned as nextCursor by the previous page.
+	Cursor *string             `form:"cursor,omitempty" json:"cursor,omitempty"`
+	Status *OrderStatus        `form:"status,omitempty" json:"status,omitempty"`
+	Sku    *openapi_types.UUID `form:"sku,omitempty" json:"sku,omitempty"`
+
+	// CreatedFrom Inclusive lower bound of createdAt.
+	CreatedFrom *time.Time `form:"createdFrom,omitempty" json:"createdFrom,omitempty"`
+
+	// CreatedTo Exclusive upper bound of createdAt.
+	CreatedTo *time.Time            `form:"createdTo,omitempty" json:"createdTo,omitempty"`
+	Sort      *ListOrdersParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+}
+
+// ListOrdersParamsSort defines parameters for ListOrders.
+type ListOrdersParamsSort string
+
+// CreateOrderParams defines parameters for CreateOrder.
+type CreateOrderParams struct {
+	// XActor Identifies who performs the change, recorded in the order history.
+	XActor *Actor `json:"X-Actor,omitempty"`
+
+	// IdempotencyKey Makes retries of the request safe, a repeated request with the same key and body returns the order created by the first one, a concurrent one waits for the first one to finish. Keys expire after IDEMPOTENCY_KEY_TTL_HOURS, a day by default.
+	IdempotencyKey *string `json:"Idempotency-Key,omitempty"`
+}
+
+// StreamAllOrderEventsParams defines parameters for StreamAllOrderEvents.
+type StreamAllOrderEventsParams struct {
+	// LastEventID Id of the last event received, sent by clients resuming a stream.
+	LastEventID *LastEventID `json:"Last-Event-ID,omitempty"`
+}
+
+// GetOrderByIdParams defines parameters for GetOrderById.
+type GetOrderByIdParams struct {
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// CancelOrderParams defines parameters for CancelOrder.
+type CancelOrderParams struct {
+	// XActor Identifies who performs the change, recorded in the order history.
+	XActor *Actor `json:"X-Actor,omitempty"`
+
+	// IfMatch ETag of the order version the change is based on, required by every mutation of an existing order.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// StreamOrderEventsParams defines parameters for StreamOrderEvents.
+type StreamOrderEventsParams struct {
+	// LastEventID Id of the last event received, sent by clients resuming a stream.
+	LastEventID *LastEventID `json:"Last-Event-ID,omitempty"`
+}
+
+// TransitionOrderParams defines parameters for TransitionOrder.
+type TransitionOrderParams struct {
+	// XActor Identifies who performs the change, recorded in the order history.
+	XActor *Actor `json:"X-Actor,omitempty"`
+
+	// IfMatch ETag of the order version the change is based on, required by every mutation of an existing order.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// ListWebhookDeliveriesParams defines parameters for ListWebhookDeliveries.
+type ListWebhookDeliveriesParams struct {
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // CreateOrderJSONRequestBody defines body for CreateOrder for application/json ContentType.
 type CreateOrderJSONRequestBody = CreateOrderRequest
 
+// CancelOrderJSONRequestBody defines body for CancelOrder for application/json ContentType.
+type CancelOrderJSONRequestBody = CancelOrderRequest
+
+// TransitionOrderJSONRequestBody defines body for TransitionOrder for application/json ContentType.
+type TransitionOrderJSONRequestBody = TransitionOrderRequest
+
+// CreateWebhookSubscriptionJSONRequestBody defines body for CreateWebhookSubscription for application/json ContentType.
+type CreateWebhookSubscriptionJSONRequestBody = CreateWebhookSubscriptionRequest
+
+// UpdateWebhookSubscriptionJSONRequestBody defines body for UpdateWebhookSubscription for application/json ContentType.
+type UpdateWebhookSubscriptionJSONRequestBody = UpdateWebhookSubscriptionRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 
+	// (GET /admin/dead-letter-events)
+	ListDeadLetterEvents(w http.ResponseWriter, r *http.Request, params ListDeadLetterEventsParams)
+
+	// (DELETE /admin/dead-letter-events/{deadLetterEventId})
+	DiscardDeadLetterEvent(w http.ResponseWriter, r *http.Request, deadLetterEventId int64)
+
+	// (GET /admin/dead-letter-events/{deadLetterEventId})
+	GetDeadLetterEvent(w http.ResponseWriter, r *http.Request, deadLetterEventId int64)
+
+	// (POST /admin/dead-letter-events/{deadLetterEventId}/replay)
+	ReplayDeadLetterEvent(w http.ResponseWriter, r *http.Request, deadLetterEventId int64)
+
 	// (GET /healthz)
 	GetHealth(w http.ResponseWriter, r *http.Request)
 
+	// (GET /livez)
+	GetLiveness(w http.ResponseWriter, r *http.Request)
+
+	// (GET /orders)
+	ListOrders(w http.ResponseWriter, r *http.Request, params ListOrdersParams)
+
 	// (POST /orders)
-	CreateOrder(w http.ResponseWriter, r *http.Request)
+	CreateOrder(w http.ResponseWriter, r *http.Request, params CreateOrderParams)
+
+	// (GET /orders/events)
+	StreamAllOrderEvents(w http.ResponseWriter, r *http.Request, params StreamAllOrderEventsParams)
 
 	// (GET /orders/{orderId})
-	GetOrderById(w http.ResponseWriter, r *http.Request, orderId openapi_types.UUID)
+	GetOrderById(w http.ResponseWriter, r *http.Request, orderId openapi_types.UUID, params GetOrderByIdParams)
+
+	// (POST /orders/{orderId}/cancel)
+	CancelOrder(w http.ResponseWriter, r *http.Request, orderId openapi_types.UUID, params CancelOrderParams)
+
+	// (GET /orders/{orderId}/events)
+	StreamOrderEvents(w http.ResponseWriter, r *http.Request, orderId openapi_types.UUID, params StreamOrderEventsParams)
+
+	// (GET /orders/{orderId}/history)
+	GetOrderHistory(w http.ResponseWriter, r *http.Request, orderId openapi_types.UUID)
+
+	// (POST /orders/{orderId}/transitions)
+	TransitionOrder(w http.ResponseWriter, r *http.Request, orderId openapi_types.UUID, params TransitionOrderParams)
+
+	// (GET /readyz)
+	GetReadiness(w http.ResponseWriter, r *http.Request)
+
+	// (GET /webhooks)
+	ListWebhookSubscriptions(w http.ResponseWriter, r *http.Request)
+
+	// (POST /webhooks)
+	CreateWebhookSubscription(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /webhooks/{webhookId})
+	DeleteWebhookSubscription(w http.ResponseWriter, r *http.Request, webhookId openapi_types.UUID)
+
+	// (GET /webhooks/{webhookId})
+	GetWebhookSubscription(w http.ResponseWriter, r *http.Request, webhookId openapi_types.UUID)
+
+	// (PATCH /webhooks/{webhookId})
+	UpdateWebhookSubscription(w http.ResponseWriter, r *http.Request, webhookId openapi_types.UUID)
+
+	// (GET /webhooks/{webhookId}/deliveries)
+	ListWebhookDeliveries(w http.ResponseWriter, r *http.Request, webhookId openapi_types.UUID, params ListWebhookDeliveriesParams)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -81,6 +565,124 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
+// ListDeadLetterEvents operation middleware
+func (siw *ServerInterfaceWrapper) ListDeadLetterEvents(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListDeadLetterEventsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "eventType" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "eventType", r.URL.Query(), &params.EventType)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "eventType", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListDeadLetterEvents(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DiscardDeadLetterEvent operation middleware
+func (siw *ServerInterfaceWrapper) DiscardDeadLetterEvent(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "deadLetterEventId" -------------
+	var deadLetterEventId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "deadLetterEventId", r.PathValue("deadLetterEventId"), &deadLetterEventId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "deadLetterEventId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DiscardDeadLetterEvent(w, r, deadLetterEventId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDeadLetterEvent operation middleware
+func (siw *ServerInterfaceWrapper) GetDeadLetterEvent(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "deadLetterEventId" -------------
+	var deadLetterEventId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "deadLetterEventId", r.PathValue("deadLetterEventId"), &deadLetterEventId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "deadLetterEventId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDeadLetterEvent(w, r, deadLetterEventId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReplayDeadLetterEvent operation middleware
+func (siw *ServerInterfaceWrapper) ReplayDeadLetterEvent(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "deadLetterEventId" -------------
+	var deadLetterEventId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "deadLetterEventId", r.PathValue("deadLetterEventId"), &deadLetterEventId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "deadLetterEventId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReplayDeadLetterEvent(w, r, deadLetterEventId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetHealth operation middleware
 func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Request) {
 
@@ -95,11 +697,11 @@ func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
-// CreateOrder operation middleware
-func (siw *ServerInterfaceWrapper) CreateOrder(w http.ResponseWriter, r *http.Request) {
+// GetLiveness operation middleware
+func (siw *ServerInterfaceWrapper) GetLiveness(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateOrder(w, r)
+		siw.Handler.GetLiveness(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -109,22 +711,583 @@ func (siw *ServerInterfaceWrapper) CreateOrder(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// GetOrderById operation middleware
-func (siw *ServerInterfaceWrapper) GetOrderById(w http.ResponseWriter, r *http.Request) {
+// ListOrders operation middleware
+func (siw *ServerInterfaceWrapper) ListOrders(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "orderId" -------------
-	var orderId openapi_types.UUID
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListOrdersParams
 
-	err = runtime.BindStyledParameterWithOptions("simple", "orderId", r.PathValue("orderId"), &orderId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "orderId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sku" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sku", r.URL.Query(), &params.Sku)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sku", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "createdFrom" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "createdFrom", r.URL.Query(), &params.CreatedFrom)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "createdFrom", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "createdTo" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "createdTo", r.URL.Query(), &params.CreatedTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "createdTo", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListOrders(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateOrder operation middleware
+func (siw *ServerInterfaceWrapper) CreateOrder(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateOrderParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Actor")]; found {
+		var XActor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Actor", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Actor", valueList[0], &XActor, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Actor", Err: err})
+			return
+		}
+
+		params.XActor = &XActor
+
+	}
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateOrder(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// StreamAllOrderEvents operation middleware
+func (siw *ServerInterfaceWrapper) StreamAllOrderEvents(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params StreamAllOrderEventsParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Last-Event-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Last-Event-ID")]; found {
+		var LastEventID LastEventID
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Last-Event-ID", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Last-Event-ID", valueList[0], &LastEventID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Last-Event-ID", Err: err})
+			return
+		}
+
+		params.LastEventID = &LastEventID
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StreamAllOrderEvents(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetOrderById operation middleware
+func (siw *ServerInterfaceWrapper) GetOrderById(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "orderId" -------------
+	var orderId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "orderId", r.PathValue("orderId"), &orderId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "orderId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetOrderByIdParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetOrderById(w, r, orderId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CancelOrder operation middleware
+func (siw *ServerInterfaceWrapper) CancelOrder(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "orderId" -------------
+	var orderId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "orderId", r.PathValue("orderId"), &orderId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "orderId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CancelOrderParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Actor")]; found {
+		var XActor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Actor", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Actor", valueList[0], &XActor, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Actor", Err: err})
+			return
+		}
+
+		params.XActor = &XActor
+
+	}
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CancelOrder(w, r, orderId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// StreamOrderEvents operation middleware
+func (siw *ServerInterfaceWrapper) StreamOrderEvents(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "orderId" -------------
+	var orderId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "orderId", r.PathValue("orderId"), &orderId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "orderId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params StreamOrderEventsParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Last-Event-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Last-Event-ID")]; found {
+		var LastEventID LastEventID
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Last-Event-ID", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Last-Event-ID", valueList[0], &LastEventID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Last-Event-ID", Err: err})
+			return
+		}
+
+		params.LastEventID = &LastEventID
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StreamOrderEvents(w, r, orderId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetOrderHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetOrderHistory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "orderId" -------------
+	var orderId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "orderId", r.PathValue("orderId"), &orderId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "orderId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetOrderHistory(w, r, orderId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// TransitionOrder operation middleware
+func (siw *ServerInterfaceWrapper) TransitionOrder(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "orderId" -------------
+	var orderId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "orderId", r.PathValue("orderId"), &orderId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "orderId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params TransitionOrderParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Actor")]; found {
+		var XActor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Actor", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Actor", valueList[0], &XActor, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Actor", Err: err})
+			return
+		}
+
+		params.XActor = &XActor
+
+	}
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.TransitionOrder(w, r, orderId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetReadiness operation middleware
+func (siw *ServerInterfaceWrapper) GetReadiness(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetReadiness(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListWebhookSubscriptions operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhookSubscriptions(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListWebhookSubscriptions(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateWebhookSubscription operation middleware
+func (siw *ServerInterfaceWrapper) CreateWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateWebhookSubscription(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteWebhookSubscription operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "webhookId" -------------
+	var webhookId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhookId", r.PathValue("webhookId"), &webhookId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhookId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteWebhookSubscription(w, r, webhookId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetWebhookSubscription operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "webhookId" -------------
+	var webhookId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhookId", r.PathValue("webhookId"), &webhookId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhookId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetWebhookSubscription(w, r, webhookId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateWebhookSubscription operation middleware
+func (siw *ServerInterfaceWrapper) UpdateWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "webhookId" -------------
+	var webhookId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhookId", r.PathValue("webhookId"), &webhookId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhookId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateWebhookSubscription(w, r, webhookId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListWebhookDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "webhookId" -------------
+	var webhookId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhookId", r.PathValue("webhookId"), &webhookId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhookId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListWebhookDeliveriesParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetOrderById(w, r, orderId)
+		siw.Handler.ListWebhookDeliveries(w, r, webhookId, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -254,9 +1417,27 @@ func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.H
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	m.HandleFunc("GET "+options.BaseURL+"/admin/dead-letter-events", wrapper.ListDeadLetterEvents)
+	m.HandleFunc("DELETE "+options.BaseURL+"/admin/dead-letter-events/{deadLetterEventId}", wrapper.DiscardDeadLetterEvent)
+	m.HandleFunc("GET "+options.BaseURL+"/admin/dead-letter-events/{deadLetterEventId}", wrapper.GetDeadLetterEvent)
+	m.HandleFunc("POST "+options.BaseURL+"/admin/dead-letter-events/{deadLetterEventId}/replay", wrapper.ReplayDeadLetterEvent)
 	m.HandleFunc("GET "+options.BaseURL+"/healthz", wrapper.GetHealth)
+	m.HandleFunc("GET "+options.BaseURL+"/livez", wrapper.GetLiveness)
+	m.HandleFunc("GET "+options.BaseURL+"/orders", wrapper.ListOrders)
 	m.HandleFunc("POST "+options.BaseURL+"/orders", wrapper.CreateOrder)
+	m.HandleFunc("GET "+options.BaseURL+"/orders/events", wrapper.StreamAllOrderEvents)
 	m.HandleFunc("GET "+options.BaseURL+"/orders/{orderId}", wrapper.GetOrderById)
+	m.HandleFunc("POST "+options.BaseURL+"/orders/{orderId}/cancel", wrapper.CancelOrder)
+	m.HandleFunc("GET "+options.BaseURL+"/orders/{orderId}/events", wrapper.StreamOrderEvents)
+	m.HandleFunc("GET "+options.BaseURL+"/orders/{orderId}/history", wrapper.GetOrderHistory)
+	m.HandleFunc("POST "+options.BaseURL+"/orders/{orderId}/transitions", wrapper.TransitionOrder)
+	m.HandleFunc("GET "+options.BaseURL+"/readyz", wrapper.GetReadiness)
+	m.HandleFunc("GET "+options.BaseURL+"/webhooks", wrapper.ListWebhookSubscriptions)
+	m.HandleFunc("POST "+options.BaseURL+"/webhooks", wrapper.CreateWebhookSubscription)
+	m.HandleFunc("DELETE "+options.BaseURL+"/webhooks/{webhookId}", wrapper.DeleteWebhookSubscription)
+	m.HandleFunc("GET "+options.BaseURL+"/webhooks/{webhookId}", wrapper.GetWebhookSubscription)
+	m.HandleFunc("PATCH "+options.BaseURL+"/webhooks/{webhookId}", wrapper.UpdateWebhookSubscription)
+	m.HandleFunc("GET "+options.BaseURL+"/webhooks/{webhookId}/deliveries", wrapper.ListWebhookDeliveries)
 
 	return m
 }
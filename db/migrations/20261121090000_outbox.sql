@@ -0,0 +1,14 @@
+-- migrate:up
+create table if not exists outbox (
+    id uuid primary key default gen_random_uuid(),
+    aggregate_id uuid not null,
+    event_type text not null,
+    payload jsonb not null,
+    created_at timestamp not null default now(),
+    dispatched_at timestamp
+);
+
+create index if not exists idx_outbox_pending on outbox (created_at) where dispatched_at is null;
+
+-- migrate:down
+drop table if exists outbox;
@@ -0,0 +1,7 @@
+-- migrate:up
+create index if not exists idx_orders_created_at_id on orders (created_at, id);
+create index if not exists idx_orders_status_created_at_id on orders (status, created_at, id);
+
+-- migrate:down
+drop index if exists idx_orders_status_created_at_id;
+drop index if exists idx_orders_created_at_id;
@@ -0,0 +1,39 @@
+-- migrate:up
+create table if not exists order_items (
+    id uuid primary key default gen_random_uuid(),
+    order_id uuid not null references orders (id) on delete cascade,
+    position int not null,
+    sku uuid not null,
+    quantity int not null check (quantity > 0),
+    unit_price numeric(19, 4) not null check (unit_price >= 0),
+    created_at timestamp not null default now(),
+    unique (order_id, position)
+);
+
+-- filtering orders by sku looks up their items
+create index if not exists idx_order_items_sku_order_id on order_items (sku, order_id);
+
+-- existing single item orders become orders with one line item, their price was never recorded
+insert into order_items (order_id, position, sku, quantity, unit_price, created_at)
+select id, 0, sku, quantity, 0, created_at from orders;
+
+alter table orders drop column sku;
+alter table orders drop column quantity;
+
+-- migrate:down
+alter table orders add column quantity int;
+alter table orders add column sku uuid;
+
+-- orders keep their first line item, an order without items makes the migration fail as the columns become not null
+update orders o set sku = first_item.sku, quantity = first_item.quantity
+from (
+    select distinct on (order_id) order_id, sku, quantity
+    from order_items
+    order by order_id, position
+) first_item
+where first_item.order_id = o.id;
+
+alter table orders alter column quantity set not null;
+alter table orders alter column sku set not null;
+
+drop table if exists order_items;
@@ -0,0 +1,20 @@
+-- migrate:up
+-- existing orders never recorded prices, they are marked with the ISO 4217 "no currency" code
+alter table orders add column currency char(3) not null default 'XXX';
+alter table orders add column tax_rate numeric(7, 4) not null default 0;
+alter table orders add column subtotal numeric(19, 4) not null default 0;
+alter table orders add column tax numeric(19, 4) not null default 0;
+alter table orders add column total numeric(19, 4) not null default 0;
+
+alter table orders alter column currency drop default;
+alter table orders alter column tax_rate drop default;
+alter table orders alter column subtotal drop default;
+alter table orders alter column tax drop default;
+alter table orders alter column total drop default;
+
+-- migrate:down
+alter table orders drop column total;
+alter table orders drop column tax;
+alter table orders drop column subtotal;
+alter table orders drop column tax_rate;
+alter table orders drop column currency;
@@ -0,0 +1,42 @@
+-- migrate:up
+-- free text addresses are kept as is, new orders are stored with a structured address
+alter table orders rename column shipping_address to legacy_shipping_address;
+alter table orders alter column legacy_shipping_address drop not null;
+
+alter table orders add column shipping_name text;
+alter table orders add column shipping_lines text[];
+alter table orders add column shipping_city text;
+alter table orders add column shipping_region text;
+alter table orders add column shipping_postal_code text;
+alter table orders add column shipping_country char(2);
+
+alter table orders add constraint orders_shipping_address_check check (
+    legacy_shipping_address is not null or (
+        shipping_name is not null
+        and shipping_lines is not null
+        and shipping_city is not null
+        and shipping_postal_code is not null
+        and shipping_country is not null
+    )
+);
+
+-- migrate:down
+update orders set legacy_shipping_address = concat_ws(', ',
+    shipping_name,
+    array_to_string(shipping_lines, ', '),
+    shipping_postal_code || ' ' || shipping_city,
+    shipping_region,
+    shipping_country
+) where legacy_shipping_address is null;
+
+alter table orders drop constraint orders_shipping_address_check;
+
+alter table orders drop column shipping_country;
+alter table orders drop column shipping_postal_code;
+alter table orders drop column shipping_region;
+alter table orders drop column shipping_city;
+alter table orders drop column shipping_lines;
+alter table orders drop column shipping_name;
+
+alter table orders alter column legacy_shipping_address set not null;
+alter table orders rename column legacy_shipping_address to shipping_address;
@@ -0,0 +1,20 @@
+-- migrate:up
+create type cancellation_reason as enum (
+    'customer_request',
+    'out_of_stock',
+    'payment_failed',
+    'fraud_suspected',
+    'duplicate_order',
+    'other'
+);
+
+alter table orders add column cancellation_reason cancellation_reason;
+alter table orders add column cancellation_note text;
+alter table orders add column canceled_at timestamp;
+
+-- migrate:down
+alter table orders drop column canceled_at;
+alter table orders drop column cancellation_note;
+alter table orders drop column cancellation_reason;
+
+drop type if exists cancellation_reason;
@@ -0,0 +1,16 @@
+-- migrate:up
+create table if not exists order_history (
+    id bigserial primary key,
+    order_id uuid not null references orders (id) on delete cascade,
+    action text not null,
+    actor text not null,
+    old_values jsonb,
+    new_values jsonb not null,
+    trace_id text,
+    created_at timestamp not null default now()
+);
+
+create index if not exists idx_order_history_order_id_id on order_history (order_id, id);
+
+-- migrate:down
+drop table if exists order_history;
@@ -0,0 +1,5 @@
+-- migrate:up
+alter table orders add column version int not null default 1;
+
+-- migrate:down
+alter table orders drop column version;
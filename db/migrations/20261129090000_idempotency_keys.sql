@@ -0,0 +1,30 @@
+-- migrate:up
+create table if not exists idempotency_keys (
+    key text primary key,
+    request_hash text,
+    order_id uuid references orders (id) on delete cascade,
+    created_at timestamp not null default now(),
+    expires_at timestamp not null
+);
+
+-- keys sent in the request body before the header existed, their request was never fingerprinted
+insert into idempotency_keys (key, order_id, created_at, expires_at)
+select idempotency_key::text, id, created_at, created_at + interval '24 hours'
+from orders
+where idempotency_key is not null;
+
+drop index if exists idx_orders_idempotency_key;
+
+alter table orders drop column idempotency_key;
+
+-- migrate:down
+alter table orders add column idempotency_key uuid unique;
+
+update orders o set idempotency_key = ik.key::uuid
+from idempotency_keys ik
+where ik.order_id = o.id
+  and ik.key ~* '^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$';
+
+create index if not exists idx_orders_idempotency_key on orders (idempotency_key);
+
+drop table if exists idempotency_keys;
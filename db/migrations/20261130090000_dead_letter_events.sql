@@ -0,0 +1,17 @@
+-- migrate:up
+create table if not exists dead_letter_events (
+    id bigserial primary key,
+    event_id text not null,
+    aggregate_id uuid,
+    event_type text not null,
+    payload jsonb not null,
+    error text not null,
+    attempts int not null,
+    trace jsonb not null,
+    created_at timestamp not null default now()
+);
+
+create index if not exists idx_dead_letter_events_event_type_id on dead_letter_events (event_type, id);
+
+-- migrate:down
+drop table if exists dead_letter_events;
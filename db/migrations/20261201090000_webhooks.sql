@@ -0,0 +1,56 @@
+-- migrate:up
+create table if not exists webhook_subscriptions (
+    id uuid primary key default gen_random_uuid(),
+    url text not null,
+    -- an empty filter subscribes to every event type
+    event_types text[] not null default '{}',
+    secret text not null,
+    active boolean not null default true,
+    consecutive_failures int not null default 0,
+    disabled_at timestamp,
+    created_at timestamp not null default now(),
+    updated_at timestamp not null default now()
+);
+
+create type webhook_delivery_status as enum (
+    'pending',
+    'succeeded',
+    'failed'
+);
+
+create table if not exists webhook_deliveries (
+    id uuid primary key default gen_random_uuid(),
+    subscription_id uuid not null references webhook_subscriptions (id) on delete cascade,
+    event_id text not null,
+    event_type text not null,
+    payload jsonb not null,
+    status webhook_delivery_status not null default 'pending',
+    attempts int not null default 0,
+    next_attempt_at timestamp not null default now(),
+    last_status_code int,
+    last_error text,
+    delivered_at timestamp,
+    created_at timestamp not null default now(),
+    unique (subscription_id, event_id)
+);
+
+create index if not exists idx_webhook_deliveries_due on webhook_deliveries (next_attempt_at) where status = 'pending';
+create index if not exists idx_webhook_deliveries_subscription_id_created_at on webhook_deliveries (subscription_id, created_at);
+
+create table if not exists webhook_delivery_attempts (
+    id bigserial primary key,
+    delivery_id uuid not null references webhook_deliveries (id) on delete cascade,
+    attempt int not null,
+    status_code int,
+    error text,
+    duration_ms int not null,
+    created_at timestamp not null default now()
+);
+
+create index if not exists idx_webhook_delivery_attempts_delivery_id on webhook_delivery_attempts (delivery_id, id);
+
+-- migrate:down
+drop table if exists webhook_delivery_attempts;
+drop table if exists webhook_deliveries;
+drop type if exists webhook_delivery_status;
+drop table if exists webhook_subscriptions;
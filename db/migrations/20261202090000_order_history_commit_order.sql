@@ -0,0 +1,10 @@
+-- migrate:up
+-- history ids are taken on insert and can commit out of order, the id of the writing transaction orders the entries
+-- by commit visibility: once it is below the xmin of the current snapshot no entry with a lower one can show up
+alter table order_history add column if not exists tx_id xid8 not null default pg_current_xact_id();
+
+create index if not exists idx_order_history_tx_id_id on order_history (tx_id, id);
+
+-- migrate:down
+drop index if exists idx_order_history_tx_id_id;
+alter table order_history drop column if exists tx_id;
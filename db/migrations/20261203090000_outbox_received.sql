@@ -0,0 +1,13 @@
+-- migrate:up
+-- notifications of the postgres transport are lost while no session listens, the consumer claims an event by setting
+-- received_at and looks for unclaimed ones whenever it starts listening
+alter table outbox add column if not exists received_at timestamp;
+
+-- events dispatched so far were delivered already
+update outbox set received_at = dispatched_at where dispatched_at is not null;
+
+create index if not exists idx_outbox_unreceived on outbox (created_at) where received_at is null;
+
+-- migrate:down
+drop index if exists idx_outbox_unreceived;
+alter table outbox drop column if exists received_at;
@@ -0,0 +1,7 @@
+-- migrate:up
+-- relays lease pending events instead of holding their rows locked while they publish them, an event stays pending
+-- until it is marked dispatched and is leased again once its lease ended
+alter table outbox add column if not exists leased_until timestamp;
+
+-- migrate:down
+alter table outbox drop column if exists leased_until;
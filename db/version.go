@@ -0,0 +1,31 @@
+package db
+
+import (
+	"errors"
+	"io/fs"
+	"strings"
+)
+
+// LatestMigration returns the version of the newest embedded migration, the timestamp its file name starts with.
+func LatestMigration() (string, error) {
+	entries, err := fs.ReadDir(Migrations, "migrations")
+	if err != nil {
+		return "", err
+	}
+
+	latest := ""
+	for _, entry := range entries {
+		version, _, ok := strings.Cut(entry.Name(), "_")
+		if !ok || entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+
+		latest = max(latest, version)
+	}
+
+	if latest == "" {
+		return "", errors.New("no migrations embedded")
+	}
+
+	return latest, nil
+}
@@ -4,20 +4,33 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
+	"time"
 
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/leetm4n/orders-service/config"
-	"github.com/leetm4n/orders-service/internal/model"
-	"github.com/leetm4n/orders-service/internal/repo"
+	"github.com/leetm4n/orders-service/internal/grpcserver"
 	"github.com/leetm4n/orders-service/internal/server"
+	"github.com/leetm4n/orders-service/internal/service"
 	"github.com/leetm4n/orders-service/internal/worker"
+	"github.com/leetm4n/orders-service/pkg/metrics"
+	"github.com/leetm4n/orders-service/pkg/money"
 	"github.com/leetm4n/orders-service/pkg/tracing"
-	"github.com/quantumsheep/otelpgxpool"
+	"github.com/leetm4n/orders-service/pkg/webhooks"
 	"golang.org/x/sync/errgroup"
 )
 
 func Run(ctx context.Context, cfg config.Config) error {
-	otelShutdown, err := tracing.InitTracer(ctx, "orders-service", cfg.TracingExporterEndpoint)
+	otelShutdown, err := tracing.InitTracer(ctx, tracing.TracerOptions{
+		ServiceName:    "orders-service",
+		ServiceVersion: cfg.ServiceVersion,
+		Environment:    cfg.DeploymentEnvironment,
+		Exporter:       cfg.TracingExporter,
+		Endpoint:       cfg.TracingExporterEndpoint,
+		Insecure:       cfg.TracingExporterInsecure,
+		CAFile:         cfg.TracingExporterCAFile,
+		Headers:        cfg.TracingExporterHeaders,
+		SampleRatio:    cfg.TracingSampleRatio,
+	})
 	if err != nil {
 		return err
 	}
@@ -25,44 +38,139 @@ func Run(ctx context.Context, cfg config.Config) error {
 		err = errors.Join(err, otelShutdown(context.Background()))
 	}()
 
-	poolConfig, err := pgxpool.ParseConfig(cfg.DatabaseURL)
+	metricsHandler, meterShutdown, err := metrics.InitMeter(ctx, "orders-service")
 	if err != nil {
 		return err
 	}
+	defer func() {
+		err = errors.Join(err, meterShutdown(context.Background()))
+	}()
 
-	poolConfig.ConnConfig.Tracer = otelpgxpool.NewTracer()
+	taxRatePercent, err := parseTaxRate(cfg.TaxRatePercent)
+	if err != nil {
+		return fmt.Errorf("invalid tax rate %q: %w", cfg.TaxRatePercent, err)
+	}
 
-	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	storage, err := newStorage(ctx, cfg)
 	if err != nil {
-		return fmt.Errorf("new pool error: %w", err)
+		return fmt.Errorf("storage error: %w", err)
 	}
 
-	if err := pool.Ping(ctx); err != nil {
-		return fmt.Errorf("ping database error: %w", err)
+	producer, consumer, closeEvents, err := newEventTransport(cfg, storage)
+	if err != nil {
+		return fmt.Errorf("event transport error: %w", err)
 	}
+	defer func() {
+		err = errors.Join(err, closeEvents())
+	}()
 
-	orderCreatedCh := make(chan model.OrderCreatedEvent, 100)
-	defer close(orderCreatedCh)
+	orders := service.NewOrderService(service.OrderServiceOptions{
+		Repository:        storage.orders,
+		TaxRatePercent:    taxRatePercent,
+		IdempotencyKeyTTL: time.Duration(cfg.IdempotencyKeyTTLHours) * time.Hour,
+		EventSource:       cfg.EventSource,
+		WatchPollInterval: time.Duration(cfg.StreamPollIntervalMs) * time.Millisecond,
+	})
+
+	eventWorker := worker.New(worker.WorkerOptions{
+		Consumer:       consumer,
+		Outbox:         storage.orders,
+		Webhooks:       storage.webhooks,
+		DeadLetters:    storage.deadLetters,
+		Concurrency:    cfg.WorkerConcurrency,
+		MaxAttempts:    cfg.WorkerMaxAttempts,
+		RetryBaseDelay: time.Duration(cfg.WorkerRetryBaseDelayMs) * time.Millisecond,
+		RetryMaxDelay:  time.Duration(cfg.WorkerRetryMaxDelayMs) * time.Millisecond,
+		DrainTimeout:   time.Duration(cfg.WorkerDrainTimeoutSec) * time.Second,
+	})
+
+	readiness, err := newReadiness(cfg, storage, producer, eventWorker)
+	if err != nil {
+		return fmt.Errorf("readiness error: %w", err)
+	}
+
+	webhookURLPolicy := webhooks.URLPolicy{
+		AllowHTTP:             cfg.WebhookAllowHTTP,
+		AllowPrivateAddresses: cfg.WebhookAllowPrivateAddresses,
+	}
 
 	server := server.New(server.ServerOptions{
 		Port:                       cfg.Port,
 		Host:                       cfg.Host,
 		GracefulShutdownTimeoutSec: cfg.GracefulShutdownTimeoutSec,
-		Queries:                    repo.New(pool),
-		OrderCreatedChan:           orderCreatedCh,
+		Orders:                     orders,
+		Webhooks:                   storage.webhooks,
+		DeadLetters:                storage.deadLetters,
+		StreamHeartbeatInterval:    time.Duration(cfg.StreamHeartbeatSec) * time.Second,
+		Metrics:                    metricsHandler,
+		Readiness:                  readiness,
+		ReadinessDrainDelay:        time.Duration(cfg.ReadinessDrainDelayMs) * time.Millisecond,
+		WebhookURLPolicy:           webhookURLPolicy,
 	})
 
+	grpcServer := grpcserver.New(grpcserver.ServerOptions{
+		Port:                       cfg.GRPCPort,
+		Host:                       cfg.Host,
+		GracefulShutdownTimeoutSec: cfg.GracefulShutdownTimeoutSec,
+		Orders:                     orders,
+	})
+
+	// the worker is stopped only after the server and the relay, so nothing publishes events while it drains
+	workerCtx, stopWorker := context.WithCancel(context.WithoutCancel(ctx))
+	defer stopWorker()
+
 	eG, ctx := errgroup.WithContext(ctx)
+	intakeEG, intakeCtx := errgroup.WithContext(ctx)
 
 	// start server
+	intakeEG.Go(func() error {
+		return server.Start(intakeCtx)
+	})
+
+	// start grpc server
+	intakeEG.Go(func() error {
+		return grpcServer.Start(intakeCtx)
+	})
+
+	// start outbox relay
+	relay := worker.NewRelay(worker.RelayOptions{
+		Outbox:       storage.orders,
+		Producer:     producer,
+		PollInterval: time.Duration(cfg.OutboxPollIntervalMs) * time.Millisecond,
+		BatchSize:    cfg.OutboxBatchSize,
+		Lease:        time.Duration(cfg.OutboxLeaseMs) * time.Millisecond,
+	})
+	intakeEG.Go(func() error {
+		return relay.Start(intakeCtx)
+	})
+
+	// start webhook dispatcher, the lease lets every delivery of a batch take the whole timeout, with one more to
+	// record the outcomes
+	webhookTimeout := time.Duration(cfg.WebhookTimeoutMs) * time.Millisecond
+	webhookDispatcher := worker.NewWebhookDispatcher(worker.WebhookDispatcherOptions{
+		Webhooks:             storage.webhooks,
+		Client:               webhooks.NewClient(webhooks.ClientOptions{Timeout: webhookTimeout, Policy: webhookURLPolicy}),
+		PollInterval:         time.Duration(cfg.WebhookPollIntervalMs) * time.Millisecond,
+		BatchSize:            cfg.WebhookBatchSize,
+		Lease:                time.Duration(cfg.WebhookBatchSize+1) * webhookTimeout,
+		MaxAttempts:          cfg.WebhookMaxAttempts,
+		RetryBaseDelay:       time.Duration(cfg.WebhookRetryBaseDelayMs) * time.Millisecond,
+		RetryMaxDelay:        time.Duration(cfg.WebhookRetryMaxDelayMs) * time.Millisecond,
+		DisableAfterFailures: cfg.WebhookFailuresToDisable,
+	})
+	intakeEG.Go(func() error {
+		return webhookDispatcher.Start(intakeCtx)
+	})
+
 	eG.Go(func() error {
-		return server.Start(ctx)
+		defer stopWorker()
+
+		return intakeEG.Wait()
 	})
 
 	// start worker
-	worker := worker.New(orderCreatedCh)
 	eG.Go(func() error {
-		return worker.Start(ctx)
+		return eventWorker.Start(workerCtx)
 	})
 
 	if err := eG.Wait(); err != nil {
@@ -71,3 +179,24 @@ func Run(ctx context.Context, cfg config.Config) error {
 
 	return nil
 }
+
+// maxTaxRateScale is the scale of the tax rate column, orders store their rate as numeric(7, 4).
+const maxTaxRateScale = 4
+
+// parseTaxRate parses a percentage between 0 and 100 that orders can store without rounding it.
+func parseTaxRate(s string) (money.Amount, error) {
+	rate, err := money.ParseAmount(s)
+	if err != nil {
+		return money.Amount{}, err
+	}
+
+	if money.Sign(rate) < 0 || money.Cmp(rate, money.NewAmount(big.NewInt(100), 0)) > 0 {
+		return money.Amount{}, errors.New("must be between 0 and 100")
+	}
+
+	if money.Scale(rate) > maxTaxRateScale {
+		return money.Amount{}, fmt.Errorf("must have at most %d decimal places", maxTaxRateScale)
+	}
+
+	return rate, nil
+}
@@ -0,0 +1,29 @@
+package application
+
+import "testing"
+
+func TestParseTaxRate(t *testing.T) {
+	type args struct {
+		rate string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{name: "should accept zero", args: args{rate: "0"}},
+		{name: "should accept the largest rate", args: args{rate: "100"}},
+		{name: "should accept four decimal places", args: args{rate: "27.1234"}},
+		{name: "should reject negative rate", args: args{rate: "-1"}, wantErr: true},
+		{name: "should reject rate above 100", args: args{rate: "100.0001"}, wantErr: true},
+		{name: "should reject more than four decimal places", args: args{rate: "27.12345"}, wantErr: true},
+		{name: "should reject invalid rate", args: args{rate: "27%"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseTaxRate(tt.args.rate); (err != nil) != tt.wantErr {
+				t.Errorf("parseTaxRate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
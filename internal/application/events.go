@@ -0,0 +1,54 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/leetm4n/orders-service/config"
+	"github.com/leetm4n/orders-service/internal/worker"
+	"github.com/leetm4n/orders-service/pkg/events"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+func newEventTransport(cfg config.Config, storage storage) (events.Producer, events.Consumer, func() error, error) {
+	switch cfg.EventBroker {
+	case config.EventBrokerChannel:
+		broker := events.NewChannelBroker(cfg.EventBufferSize)
+
+		return broker, broker, broker.Close, nil
+	case config.EventBrokerPostgres:
+		// notifications only carry the outbox id of an event, the consumer claims the event in the outbox and finds the
+		// ones whose notification was lost there
+		producer := events.NewPostgresProducer(storage.pool, cfg.PostgresNotifyChannel)
+		consumer := events.NewPostgresConsumer(storage.pool, cfg.PostgresNotifyChannel, worker.OutboxMessageStore(storage.orders))
+
+		return producer, consumer, func() error {
+			return errors.Join(producer.Close(), consumer.Close())
+		}, nil
+	case config.EventBrokerAMQP:
+		conn, err := amqp.Dial(cfg.AMQPURL)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("dial amqp: %w", err)
+		}
+
+		// publishing and consuming happen on separate channels so flow control on one does not block the other
+		publishCh, err := conn.Channel()
+		if err != nil {
+			return nil, nil, nil, errors.Join(fmt.Errorf("open amqp channel: %w", err), conn.Close())
+		}
+
+		consumeCh, err := conn.Channel()
+		if err != nil {
+			return nil, nil, nil, errors.Join(fmt.Errorf("open amqp channel: %w", err), conn.Close())
+		}
+
+		if err := events.DeclareAMQPTopology(publishCh, cfg.AMQPExchange, cfg.AMQPQueue); err != nil {
+			return nil, nil, nil, errors.Join(err, conn.Close())
+		}
+
+		// closing the connection closes its channels as well
+		return events.NewAMQPProducer(publishCh, cfg.AMQPExchange), events.NewAMQPConsumer(consumeCh, cfg.AMQPQueue, cfg.AMQPPrefetch), conn.Close, nil
+	default:
+		return nil, nil, nil, fmt.Errorf("unknown event broker %q", cfg.EventBroker)
+	}
+}
@@ -0,0 +1,65 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/leetm4n/orders-service/config"
+	"github.com/leetm4n/orders-service/db"
+	"github.com/leetm4n/orders-service/internal/repo"
+	"github.com/leetm4n/orders-service/internal/worker"
+	"github.com/leetm4n/orders-service/pkg/events"
+	"github.com/leetm4n/orders-service/pkg/health"
+)
+
+// eventQueueSaturation is the share of the in-process event queue that may fill up before the service reports not
+// ready, the relay blocks once the queue is full.
+const eventQueueSaturation = 0.9
+
+// boundedQueue is implemented by producers buffering events in process, like the channel broker.
+type boundedQueue interface {
+	Len() int
+	Cap() int
+}
+
+// newReadiness checks the database and its migration version with the postgres storage, the worker heartbeat and
+// the saturation of the event queue for brokers having one.
+func newReadiness(cfg config.Config, storage storage, producer events.Producer, w *worker.Worker) (*health.Checker, error) {
+	checker := health.NewChecker(time.Duration(cfg.ReadinessTimeoutMs) * time.Millisecond)
+
+	if storage.pool != nil {
+		latest, err := db.LatestMigration()
+		if err != nil {
+			return nil, fmt.Errorf("latest migration: %w", err)
+		}
+
+		checker.Add("database", storage.pool.Ping)
+		checker.Add("migrations", func(ctx context.Context) error {
+			version, err := repo.SchemaVersion(ctx, storage.pool)
+			if err != nil {
+				return err
+			}
+
+			if version < latest {
+				return fmt.Errorf("schema at version %s, want %s", version, latest)
+			}
+
+			return nil
+		})
+	}
+
+	checker.Add("worker", w.CheckHeartbeat)
+
+	if queue, ok := producer.(boundedQueue); ok {
+		checker.Add("event_queue", func(context.Context) error {
+			if queue.Cap() > 0 && float64(queue.Len()) >= float64(queue.Cap())*eventQueueSaturation {
+				return fmt.Errorf("event queue saturated with %d of %d events", queue.Len(), queue.Cap())
+			}
+
+			return nil
+		})
+	}
+
+	return checker, nil
+}
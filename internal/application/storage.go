@@ -0,0 +1,73 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/leetm4n/orders-service/config"
+	"github.com/leetm4n/orders-service/internal/model"
+	"github.com/leetm4n/orders-service/internal/repo"
+	"github.com/leetm4n/orders-service/internal/repo/memory"
+	"github.com/leetm4n/orders-service/pkg/metrics"
+	"github.com/quantumsheep/otelpgxpool"
+)
+
+type orderStorage interface {
+	model.OrderRepository
+	model.OutboxRepository
+}
+
+// storage holds where orders, webhooks and dead letters are kept, pool is nil with the in-memory storage.
+type storage struct {
+	orders      orderStorage
+	webhooks    model.WebhookRepository
+	deadLetters model.DeadLetterRepository
+	pool        *pgxpool.Pool
+}
+
+func newStorage(ctx context.Context, cfg config.Config) (storage, error) {
+	switch cfg.Storage {
+	case config.StoragePostgres:
+		poolConfig, err := pgxpool.ParseConfig(cfg.DatabaseURL)
+		if err != nil {
+			return storage{}, err
+		}
+
+		poolConfig.ConnConfig.Tracer = otelpgxpool.NewTracer()
+
+		pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+		if err != nil {
+			return storage{}, fmt.Errorf("new pool error: %w", err)
+		}
+
+		if err := pool.Ping(ctx); err != nil {
+			return storage{}, fmt.Errorf("ping database error: %w", err)
+		}
+
+		if err := metrics.RecordPoolStats(pool); err != nil {
+			return storage{}, fmt.Errorf("pool metrics error: %w", err)
+		}
+
+		return storage{
+			orders:      repo.NewPostgresOrderRepository(pool),
+			webhooks:    repo.NewPostgresWebhookRepository(pool),
+			deadLetters: repo.NewPostgresDeadLetterRepository(pool),
+			pool:        pool,
+		}, nil
+	case config.StorageMemory:
+		if cfg.EventBroker == config.EventBrokerPostgres {
+			return storage{}, fmt.Errorf("event broker %q needs the postgres storage", cfg.EventBroker)
+		}
+
+		orders := memory.NewOrderRepository()
+
+		return storage{
+			orders:      orders,
+			webhooks:    memory.NewWebhookRepository(),
+			deadLetters: memory.NewDeadLetterRepository(orders),
+		}, nil
+	default:
+		return storage{}, fmt.Errorf("unknown storage %q", cfg.Storage)
+	}
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizePostalCode(t *testing.T) {
+	type args struct {
+		country    string
+		postalCode string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr error
+	}{
+		{
+			name: "should accept us zip code",
+			args: args{country: "US", postalCode: "94103"},
+			want: "94103",
+		},
+		{
+			name: "should accept us zip+4 code",
+			args: args{country: "US", postalCode: "94103-1234"},
+			want: "94103-1234",
+		},
+		{
+			name: "should normalize gb postcode",
+			args: args{country: "GB", postalCode: " sw1a 1aa "},
+			want: "SW1A 1AA",
+		},
+		{
+			name: "should accept hungarian postal code",
+			args: args{country: "HU", postalCode: "1051"},
+			want: "1051",
+		},
+		{
+			name:    "should reject german postal code with 4 digits",
+			args:    args{country: "DE", postalCode: "1051"},
+			wantErr: ErrInvalidPostalCode,
+		},
+		{
+			name:    "should reject us zip code with letters",
+			args:    args{country: "US", postalCode: "9410A"},
+			wantErr: ErrInvalidPostalCode,
+		},
+		{
+			name: "should accept generic postal code of unknown country format",
+			args: args{country: "KE", postalCode: "00100"},
+			want: "00100",
+		},
+		{
+			name:    "should reject empty postal code",
+			args:    args{country: "KE", postalCode: " "},
+			wantErr: ErrInvalidPostalCode,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizePostalCode(tt.args.country, tt.args.postalCode)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NormalizePostalCode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizePostalCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
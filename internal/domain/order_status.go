@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+type OrderStatus string
+
+const (
+	OrderStatusPending   OrderStatus = "pending"
+	OrderStatusShipped   OrderStatus = "shipped"
+	OrderStatusDelivered OrderStatus = "delivered"
+	OrderStatusCanceled  OrderStatus = "canceled"
+)
+
+var ErrIllegalStatusTransition = errors.New("illegal order status transition")
+
+// allowedTransitions is the order lifecycle state machine, every status not listed as a key is terminal.
+var allowedTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusPending: {OrderStatusShipped, OrderStatusCanceled},
+	OrderStatusShipped: {OrderStatusDelivered},
+}
+
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range allowedTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s OrderStatus) TransitionTo(next OrderStatus) (OrderStatus, error) {
+	if !s.CanTransitionTo(next) {
+		return s, fmt.Errorf("%w: %s -> %s", ErrIllegalStatusTransition, s, next)
+	}
+
+	return next, nil
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOrderStatusTransitionTo(t *testing.T) {
+	type args struct {
+		from OrderStatus
+		to   OrderStatus
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    OrderStatus
+		wantErr error
+	}{
+		{
+			name: "should ship pending order",
+			args: args{from: OrderStatusPending, to: OrderStatusShipped},
+			want: OrderStatusShipped,
+		},
+		{
+			name: "should cancel pending order",
+			args: args{from: OrderStatusPending, to: OrderStatusCanceled},
+			want: OrderStatusCanceled,
+		},
+		{
+			name: "should deliver shipped order",
+			args: args{from: OrderStatusShipped, to: OrderStatusDelivered},
+			want: OrderStatusDelivered,
+		},
+		{
+			name:    "should not deliver pending order",
+			args:    args{from: OrderStatusPending, to: OrderStatusDelivered},
+			want:    OrderStatusPending,
+			wantErr: ErrIllegalStatusTransition,
+		},
+		{
+			name:    "should not cancel shipped order",
+			args:    args{from: OrderStatusShipped, to: OrderStatusCanceled},
+			want:    OrderStatusShipped,
+			wantErr: ErrIllegalStatusTransition,
+		},
+		{
+			name:    "should not move delivered order",
+			args:    args{from: OrderStatusDelivered, to: OrderStatusShipped},
+			want:    OrderStatusDelivered,
+			wantErr: ErrIllegalStatusTransition,
+		},
+		{
+			name:    "should not move canceled order",
+			args:    args{from: OrderStatusCanceled, to: OrderStatusPending},
+			want:    OrderStatusCanceled,
+			wantErr: ErrIllegalStatusTransition,
+		},
+		{
+			name:    "should not transition to same status",
+			args:    args{from: OrderStatusPending, to: OrderStatusPending},
+			want:    OrderStatusPending,
+			wantErr: ErrIllegalStatusTransition,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.args.from.TransitionTo(tt.args.to)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("TransitionTo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("TransitionTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/leetm4n/orders-service/pkg/money"
+)
+
+var (
+	ErrPriceExceedsCurrencyPrecision = errors.New("price has more fractional digits than the currency allows")
+	ErrNegativePrice                 = errors.New("price must not be negative")
+	ErrAmountTooLarge                = errors.New("line and order totals must be less than 10^15")
+)
+
+// amountLimit bounds every amount of an order, amounts are stored as numeric(19, 4) which leaves 15 integer digits.
+var amountLimit = money.NewAmount(big.NewInt(1), 15)
+
+type LineItem struct {
+	Quantity  int64
+	UnitPrice money.Amount
+}
+
+type OrderTotals struct {
+	Subtotal money.Amount
+	Tax      money.Amount
+	Total    money.Amount
+}
+
+// CalculateTotals sums the line items and applies the tax rate (in percent) on the subtotal, the tax is rounded
+// to the minor unit of the currency so subtotal + tax = total holds exactly. Line totals and the order total have to
+// stay below 10^15.
+func CalculateTotals(currency string, items []LineItem, taxRatePercent money.Amount) (OrderTotals, error) {
+	scale, err := money.MinorUnits(currency)
+	if err != nil {
+		return OrderTotals{}, err
+	}
+
+	subtotal := money.Round(money.Amount{}, scale)
+	for _, item := range items {
+		if money.Sign(item.UnitPrice) < 0 {
+			return OrderTotals{}, ErrNegativePrice
+		}
+
+		if money.Scale(item.UnitPrice) > scale {
+			return OrderTotals{}, ErrPriceExceedsCurrencyPrecision
+		}
+
+		line := money.MulInt(item.UnitPrice, item.Quantity)
+		if money.Cmp(line, amountLimit) >= 0 {
+			return OrderTotals{}, ErrAmountTooLarge
+		}
+
+		subtotal = money.Add(subtotal, line)
+	}
+
+	subtotal = money.Round(subtotal, scale)
+	tax := money.Percent(subtotal, taxRatePercent, scale)
+	total := money.Add(subtotal, tax)
+
+	// neither subtotal nor tax is negative, so they are below the limit when the total is
+	if money.Cmp(total, amountLimit) >= 0 {
+		return OrderTotals{}, ErrAmountTooLarge
+	}
+
+	return OrderTotals{
+		Subtotal: subtotal,
+		Tax:      tax,
+		Total:    total,
+	}, nil
+}
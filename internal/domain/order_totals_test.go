@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/leetm4n/orders-service/pkg/money"
+)
+
+func TestCalculateTotals(t *testing.T) {
+	mustParse := func(s string) money.Amount {
+		n, err := money.ParseAmount(s)
+		if err != nil {
+			t.Fatalf("ParseAmount(%q) error = %v", s, err)
+		}
+		return n
+	}
+
+	type args struct {
+		currency       string
+		items          []LineItem
+		taxRatePercent money.Amount
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    [3]string
+		wantErr error
+	}{
+		{
+			name: "should sum line items and apply tax",
+			args: args{
+				currency: "EUR",
+				items: []LineItem{
+					{Quantity: 3, UnitPrice: mustParse("19.99")},
+					{Quantity: 1, UnitPrice: mustParse("0.5")},
+				},
+				taxRatePercent: mustParse("27"),
+			},
+			want: [3]string{"60.47", "16.33", "76.80"},
+		},
+		{
+			name: "should calculate without tax",
+			args: args{
+				currency:       "USD",
+				items:          []LineItem{{Quantity: 2, UnitPrice: mustParse("10")}},
+				taxRatePercent: money.NewAmount(big.NewInt(0), 0),
+			},
+			want: [3]string{"20.00", "0.00", "20.00"},
+		},
+		{
+			name: "should round tax to currency without minor unit",
+			args: args{
+				currency:       "JPY",
+				items:          []LineItem{{Quantity: 1, UnitPrice: mustParse("999")}},
+				taxRatePercent: mustParse("10"),
+			},
+			want: [3]string{"999", "100", "1099"},
+		},
+		{
+			name: "should reject price more precise than currency",
+			args: args{
+				currency:       "JPY",
+				items:          []LineItem{{Quantity: 1, UnitPrice: mustParse("9.5")}},
+				taxRatePercent: mustParse("10"),
+			},
+			wantErr: ErrPriceExceedsCurrencyPrecision,
+		},
+		{
+			name: "should reject negative price",
+			args: args{
+				currency:       "EUR",
+				items:          []LineItem{{Quantity: 1, UnitPrice: mustParse("-1.00")}},
+				taxRatePercent: mustParse("27"),
+			},
+			wantErr: ErrNegativePrice,
+		},
+		{
+			name: "should accept total right below the limit",
+			args: args{
+				currency:       "EUR",
+				items:          []LineItem{{Quantity: 1, UnitPrice: mustParse("999999999999999.99")}},
+				taxRatePercent: money.NewAmount(big.NewInt(0), 0),
+			},
+			want: [3]string{"999999999999999.99", "0.00", "999999999999999.99"},
+		},
+		{
+			name: "should reject line total at the limit",
+			args: args{
+				currency:       "EUR",
+				items:          []LineItem{{Quantity: 2, UnitPrice: mustParse("500000000000000")}},
+				taxRatePercent: money.NewAmount(big.NewInt(0), 0),
+			},
+			wantErr: ErrAmountTooLarge,
+		},
+		{
+			name: "should reject order total above the limit",
+			args: args{
+				currency: "EUR",
+				items: []LineItem{
+					{Quantity: 1, UnitPrice: mustParse("600000000000000")},
+					{Quantity: 1, UnitPrice: mustParse("400000000000000")},
+				},
+				taxRatePercent: money.NewAmount(big.NewInt(0), 0),
+			},
+			wantErr: ErrAmountTooLarge,
+		},
+		{
+			name: "should reject tax pushing total above the limit",
+			args: args{
+				currency:       "EUR",
+				items:          []LineItem{{Quantity: 1, UnitPrice: mustParse("900000000000000")}},
+				taxRatePercent: mustParse("27"),
+			},
+			wantErr: ErrAmountTooLarge,
+		},
+		{
+			name: "should reject unknown currency",
+			args: args{
+				currency: "ABC",
+				items:    []LineItem{{Quantity: 1, UnitPrice: mustParse("1")}},
+			},
+			wantErr: money.ErrUnsupportedCurrency,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CalculateTotals(tt.args.currency, tt.args.items, tt.args.taxRatePercent)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CalculateTotals() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			scale, _ := money.MinorUnits(tt.args.currency)
+			gotFormatted := [3]string{
+				money.FormatAmount(got.Subtotal, scale),
+				money.FormatAmount(got.Tax, scale),
+				money.FormatAmount(got.Total, scale),
+			}
+			if gotFormatted != tt.want {
+				t.Errorf("CalculateTotals() = %v, want %v", gotFormatted, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package grpcserver
+
+import (
+	"github.com/leetm4n/orders-service/api/ordersv1"
+	"github.com/leetm4n/orders-service/internal/domain"
+	"github.com/leetm4n/orders-service/internal/model"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var protoOrderStatuses = map[domain.OrderStatus]ordersv1.OrderStatus{
+	domain.OrderStatusPending:   ordersv1.OrderStatus_ORDER_STATUS_PENDING,
+	domain.OrderStatusShipped:   ordersv1.OrderStatus_ORDER_STATUS_SHIPPED,
+	domain.OrderStatusDelivered: ordersv1.OrderStatus_ORDER_STATUS_DELIVERED,
+	domain.OrderStatusCanceled:  ordersv1.OrderStatus_ORDER_STATUS_CANCELED,
+}
+
+// toProtoOrderStatus returns ORDER_STATUS_UNSPECIFIED for unknown statuses.
+func toProtoOrderStatus(s domain.OrderStatus) ordersv1.OrderStatus {
+	return protoOrderStatuses[s]
+}
+
+func fromProtoOrderStatus(s ordersv1.OrderStatus) (domain.OrderStatus, bool) {
+	for status, protoStatus := range protoOrderStatuses {
+		if protoStatus == s {
+			return status, true
+		}
+	}
+
+	return "", false
+}
+
+func toProtoOrder(order model.Order) *ordersv1.Order {
+	items := make([]*ordersv1.OrderItem, 0, len(order.Items))
+	for _, item := range order.Items {
+		items = append(items, &ordersv1.OrderItem{
+			Sku:       item.Sku.String(),
+			Quantity:  int32(item.Quantity),
+			UnitPrice: item.UnitPrice,
+		})
+	}
+
+	protoOrder := &ordersv1.Order{
+		Id:              order.ID.String(),
+		Status:          toProtoOrderStatus(order.Status),
+		Items:           items,
+		Currency:        order.Currency,
+		Subtotal:        order.Subtotal,
+		Tax:             order.Tax,
+		Total:           order.Total,
+		ShippingAddress: toProtoShippingAddress(order.ShippingAddress),
+		Cancellation:    toProtoCancellation(order.Cancellation),
+		Version:         order.Version,
+		CreatedAt:       timestamppb.New(order.CreatedAt),
+		UpdatedAt:       timestamppb.New(order.UpdatedAt),
+	}
+
+	if order.LegacyShippingAddress != "" {
+		protoOrder.LegacyShippingAddress = &order.LegacyShippingAddress
+	}
+
+	return protoOrder
+}
+
+// toProtoShippingAddress returns nil for orders created before structured addresses were introduced.
+func toProtoShippingAddress(address *model.Address) *ordersv1.ShippingAddress {
+	if address == nil {
+		return nil
+	}
+
+	protoAddress := &ordersv1.ShippingAddress{
+		Name:       address.Name,
+		Lines:      address.Lines,
+		City:       address.City,
+		PostalCode: address.PostalCode,
+		Country:    address.Country,
+	}
+
+	if address.Region != "" {
+		protoAddress.Region = &address.Region
+	}
+
+	return protoAddress
+}
+
+func toProtoCancellation(cancellation *model.Cancellation) *ordersv1.OrderCancellation {
+	if cancellation == nil {
+		return nil
+	}
+
+	protoCancellation := &ordersv1.OrderCancellation{
+		Reason:     cancellation.Reason,
+		CanceledAt: timestamppb.New(cancellation.CanceledAt),
+	}
+
+	if cancellation.Note != "" {
+		protoCancellation.Note = &cancellation.Note
+	}
+
+	return protoCancellation
+}
+
+func toProtoOrderStatusEvent(event model.OrderStatusEvent) *ordersv1.OrderStatusEvent {
+	protoEvent := &ordersv1.OrderStatusEvent{
+		Id:        event.ID,
+		OrderId:   event.OrderID.String(),
+		Action:    event.Action,
+		Status:    toProtoOrderStatus(event.Status),
+		Actor:     event.Actor,
+		TraceId:   event.TraceID,
+		CreatedAt: timestamppb.New(event.CreatedAt),
+	}
+
+	if event.PreviousStatus != nil {
+		protoEvent.PreviousStatus = toProtoOrderStatus(*event.PreviousStatus)
+	}
+
+	return protoEvent
+}
@@ -0,0 +1,200 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/leetm4n/orders-service/api/ordersv1"
+	"github.com/leetm4n/orders-service/internal/domain"
+	"github.com/leetm4n/orders-service/internal/model"
+	"github.com/leetm4n/orders-service/internal/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// IdempotentReplayedHeader is set on CreateOrder responses returning the order of an earlier request with the same
+// idempotency key.
+const IdempotentReplayedHeader = "idempotent-replayed"
+
+func (s *OrdersServer) CreateOrder(ctx context.Context, req *ordersv1.CreateOrderRequest) (*ordersv1.Order, error) {
+	if err := validateCreateOrderRequest(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	items := make([]service.OrderItemInput, 0, len(req.GetItems()))
+	for _, item := range req.GetItems() {
+		items = append(items, service.OrderItemInput{
+			Sku:       uuid.MustParse(item.GetSku()),
+			Quantity:  int(item.GetQuantity()),
+			UnitPrice: item.GetUnitPrice(),
+		})
+	}
+
+	address := req.GetShippingAddress()
+
+	in := service.CreateOrderInput{
+		Items:    items,
+		Currency: req.GetCurrency(),
+		ShippingAddress: service.AddressInput{
+			Name:       address.GetName(),
+			Lines:      address.GetLines(),
+			City:       address.GetCity(),
+			Region:     address.Region,
+			PostalCode: address.GetPostalCode(),
+			Country:    address.GetCountry(),
+		},
+		Actor:          req.Actor,
+		IdempotencyKey: req.GetIdempotencyKey(),
+	}
+
+	order, replayed, err := s.orders.CreateOrder(ctx, in)
+	if err != nil {
+		return nil, toStatusError(err, "failed to create order")
+	}
+
+	if replayed {
+		if err := grpc.SetHeader(ctx, metadata.Pairs(IdempotentReplayedHeader, "true")); err != nil {
+			slog.Error("failed to set idempotent replayed header", "error", err)
+		}
+	}
+
+	return toProtoOrder(order), nil
+}
+
+func (s *OrdersServer) GetOrder(ctx context.Context, req *ordersv1.GetOrderRequest) (*ordersv1.Order, error) {
+	id, err := uuid.Parse(req.GetOrderId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid order id")
+	}
+
+	order, err := s.orders.GetOrder(ctx, id)
+	if err != nil {
+		return nil, toStatusError(err, "failed to get order by id")
+	}
+
+	return toProtoOrder(order), nil
+}
+
+func (s *OrdersServer) ListOrders(ctx context.Context, req *ordersv1.ListOrdersRequest) (*ordersv1.ListOrdersResponse, error) {
+	in := service.ListOrdersInput{
+		Limit:  int(req.GetLimit()),
+		Cursor: req.GetCursor(),
+	}
+
+	switch req.GetSort() {
+	case ordersv1.ListOrdersSort_LIST_ORDERS_SORT_CREATED_AT_ASC:
+		in.Sort = service.SortCreatedAtAsc
+	default:
+		in.Sort = service.SortCreatedAtDesc
+	}
+
+	if req.GetStatus() != ordersv1.OrderStatus_ORDER_STATUS_UNSPECIFIED {
+		orderStatus, ok := fromProtoOrderStatus(req.GetStatus())
+		if !ok {
+			return nil, status.Error(codes.InvalidArgument, "invalid status")
+		}
+
+		in.Status = &orderStatus
+	}
+
+	if req.Sku != nil {
+		sku, err := uuid.Parse(req.GetSku())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid sku")
+		}
+
+		in.Sku = &sku
+	}
+
+	if req.CreatedFrom != nil {
+		createdFrom := req.GetCreatedFrom().AsTime()
+		in.CreatedFrom = &createdFrom
+	}
+
+	if req.CreatedTo != nil {
+		createdTo := req.GetCreatedTo().AsTime()
+		in.CreatedTo = &createdTo
+	}
+
+	orders, nextCursor, err := s.orders.ListOrders(ctx, in)
+	if err != nil {
+		return nil, toStatusError(err, "failed to list orders")
+	}
+
+	response := &ordersv1.ListOrdersResponse{
+		Items:      make([]*ordersv1.Order, 0, len(orders)),
+		NextCursor: nextCursor,
+	}
+
+	for _, order := range orders {
+		response.Items = append(response.Items, toProtoOrder(order))
+	}
+
+	return response, nil
+}
+
+func (s *OrdersServer) WatchOrder(req *ordersv1.WatchOrderRequest, stream grpc.ServerStreamingServer[ordersv1.OrderStatusEvent]) error {
+	id, err := uuid.Parse(req.GetOrderId())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid order id")
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-s.shutdown:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	err = s.orders.WatchOrderHistory(ctx, service.WatchOrderHistoryInput{
+		OrderID: &id,
+		AfterID: req.AfterEventId,
+	}, func(events []model.OrderStatusEvent) error {
+		for _, event := range events {
+			if err := stream.Send(toProtoOrderStatusEvent(event)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	switch {
+	case stream.Context().Err() != nil:
+		return status.FromContextError(stream.Context().Err()).Err()
+	case ctx.Err() != nil:
+		return status.Error(codes.Unavailable, "server is shutting down")
+	default:
+		return toStatusError(err, "failed to watch order history")
+	}
+}
+
+// toStatusError maps errors of the service layer to status errors, unexpected errors are logged with msg.
+func toStatusError(err error, msg string) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var validationErr *service.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrOrderNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrIdempotencyKeyReused), errors.Is(err, domain.ErrIllegalStatusTransition):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrConcurrentModification):
+		return status.Error(codes.Aborted, err.Error())
+	default:
+		slog.Error(msg, "error", err)
+		return status.Error(codes.Internal, "internal error")
+	}
+}
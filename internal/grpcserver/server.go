@@ -0,0 +1,110 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"time"
+
+	"github.com/leetm4n/orders-service/api/ordersv1"
+	"github.com/leetm4n/orders-service/internal/service"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+var _ ordersv1.OrderServiceServer = (*OrdersServer)(nil)
+
+// OrdersServer implements the gRPC order service on top of the same service layer as the REST API.
+type OrdersServer struct {
+	ordersv1.UnimplementedOrderServiceServer
+
+	orders *service.OrderService
+	// watches end once shutdown is closed, GracefulStop would wait for them otherwise
+	shutdown <-chan struct{}
+}
+
+type Server struct {
+	port                       int
+	host                       string
+	gracefulShutdownTimeoutSec int
+	server                     *grpc.Server
+	stopWatches                context.CancelFunc
+}
+
+type ServerOptions struct {
+	Port                       int
+	Host                       string
+	GracefulShutdownTimeoutSec int
+	Orders                     *service.OrderService
+}
+
+func New(opts ServerOptions) *Server {
+	watchesCtx, stopWatches := context.WithCancel(context.Background())
+
+	server := grpc.NewServer(grpc.StatsHandler(otelgrpc.NewServerHandler()))
+	ordersv1.RegisterOrderServiceServer(server, &OrdersServer{
+		orders:   opts.Orders,
+		shutdown: watchesCtx.Done(),
+	})
+	reflection.Register(server)
+
+	return &Server{
+		port:                       opts.Port,
+		host:                       opts.Host,
+		gracefulShutdownTimeoutSec: opts.GracefulShutdownTimeoutSec,
+		server:                     server,
+		stopWatches:                stopWatches,
+	}
+}
+
+func (s *Server) Start(ctx context.Context) error {
+	slog.Info(fmt.Sprintf("grpc server starting on %s:%d", s.host, s.port))
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("%s:%d", s.host, s.port))
+	if err != nil {
+		return fmt.Errorf("err listening for grpc: %w", err)
+	}
+
+	eg, ctx := errgroup.WithContext(ctx)
+
+	eg.Go(func() error {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			return fmt.Errorf("err running grpc server: %w", err)
+		}
+
+		return nil
+	})
+
+	eg.Go(func() error {
+		<-ctx.Done()
+
+		s.stopWatches()
+
+		stopped := make(chan struct{})
+		go func() {
+			s.server.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-time.After(time.Duration(s.gracefulShutdownTimeoutSec) * time.Second):
+			s.server.Stop()
+			return errors.New("err shutting down grpc server: graceful shutdown timed out")
+		}
+
+		return nil
+	})
+
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	slog.Info("grpc server stopped gracefully")
+
+	return nil
+}
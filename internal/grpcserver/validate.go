@@ -0,0 +1,31 @@
+package grpcserver
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/leetm4n/orders-service/api/ordersv1"
+	"github.com/leetm4n/orders-service/internal/service"
+)
+
+// validateCreateOrderRequest checks what the service input cannot express, skus that are not uuids and a missing
+// shipping address. Quantities are checked against the limit of the service before they are converted to int, the
+// service validates everything else for both APIs.
+func validateCreateOrderRequest(req *ordersv1.CreateOrderRequest) error {
+	for i, item := range req.GetItems() {
+		if _, err := uuid.Parse(item.GetSku()); err != nil {
+			return fmt.Errorf("items[%d].sku must be a uuid", i)
+		}
+
+		if item.GetQuantity() < 1 || item.GetQuantity() > service.MaxQuantity {
+			return fmt.Errorf("items[%d].quantity must be between 1 and %d", i, service.MaxQuantity)
+		}
+	}
+
+	if req.GetShippingAddress() == nil {
+		return errors.New("shipping_address is required")
+	}
+
+	return nil
+}
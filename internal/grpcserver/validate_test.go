@@ -0,0 +1,72 @@
+package grpcserver
+
+import (
+	"testing"
+
+	"github.com/leetm4n/orders-service/api/ordersv1"
+	"github.com/leetm4n/orders-service/internal/service"
+)
+
+func newCreateOrderRequest(quantity int32) *ordersv1.CreateOrderRequest {
+	return &ordersv1.CreateOrderRequest{
+		Items:    []*ordersv1.OrderItem{{Sku: "3deb76e4-cd89-4aa3-b143-89e9c0ed11ad", Quantity: quantity, UnitPrice: "19.99"}},
+		Currency: "EUR",
+		ShippingAddress: &ordersv1.ShippingAddress{
+			Name:       "John Doe",
+			Lines:      []string{"Andrassy ut 1."},
+			City:       "Budapest",
+			PostalCode: "1061",
+			Country:    "HU",
+		},
+	}
+}
+
+func TestValidateCreateOrderRequest(t *testing.T) {
+	type args struct {
+		modify func(req *ordersv1.CreateOrderRequest)
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "should accept valid request",
+			args: args{modify: func(req *ordersv1.CreateOrderRequest) {}},
+		},
+		{
+			name:    "should reject invalid sku",
+			args:    args{modify: func(req *ordersv1.CreateOrderRequest) { req.Items[0].Sku = "sku" }},
+			wantErr: true,
+		},
+		{
+			name:    "should reject zero quantity",
+			args:    args{modify: func(req *ordersv1.CreateOrderRequest) { req.Items[0].Quantity = 0 }},
+			wantErr: true,
+		},
+		{
+			name: "should accept largest quantity",
+			args: args{modify: func(req *ordersv1.CreateOrderRequest) { req.Items[0].Quantity = service.MaxQuantity }},
+		},
+		{
+			name:    "should reject quantity above limit",
+			args:    args{modify: func(req *ordersv1.CreateOrderRequest) { req.Items[0].Quantity = service.MaxQuantity + 1 }},
+			wantErr: true,
+		},
+		{
+			name:    "should reject missing shipping address",
+			args:    args{modify: func(req *ordersv1.CreateOrderRequest) { req.ShippingAddress = nil }},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newCreateOrderRequest(4)
+			tt.args.modify(req)
+
+			if err := validateCreateOrderRequest(req); (err != nil) != tt.wantErr {
+				t.Errorf("validateCreateOrderRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeadLetterEvent is an event the worker could not process. AggregateID is nil for events without one, which cannot
+// be replayed. Payload is always valid JSON, Trace is the trace envelope of the event as JSON. ID and CreatedAt are
+// assigned when the event is stored.
+type DeadLetterEvent struct {
+	ID          int64
+	EventID     string
+	AggregateID *uuid.UUID
+	EventType   string
+	Payload     []byte
+	Error       string
+	Attempts    int
+	Trace       []byte
+	CreatedAt   time.Time
+}
+
+// DeadLetterFilter selects dead letter events newest first, BeforeID is the id of the last event of the previous
+// page.
+type DeadLetterFilter struct {
+	EventType *string
+	BeforeID  *int64
+	Limit     int
+}
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leetm4n/orders-service/internal/domain"
+)
+
+const (
+	HistoryActionCreated       = "created"
+	HistoryActionStatusChanged = "status_changed"
+	HistoryActionCanceled      = "canceled"
+)
+
+// DefaultActor is recorded for changes of callers that do not identify themselves.
+const DefaultActor = "anonymous"
+
+// HistoryEntry is a change of an order, OldValues is nil for created orders. ID and CreatedAt are assigned when
+// the entry is stored.
+type HistoryEntry struct {
+	ID        int64
+	OrderID   uuid.UUID
+	Action    string
+	Actor     string
+	OldValues json.RawMessage
+	NewValues json.RawMessage
+	TraceID   *string
+	CreatedAt time.Time
+}
+
+// OrderStatusEvent is an order history entry reduced to the status change it made.
+type OrderStatusEvent struct {
+	ID             int64
+	OrderID        uuid.UUID
+	Action         string
+	Status         domain.OrderStatus
+	PreviousStatus *domain.OrderStatus
+	Actor          string
+	TraceID        *string
+	CreatedAt      time.Time
+}
@@ -0,0 +1,13 @@
+package model
+
+import (
+	"github.com/google/uuid"
+)
+
+// IdempotencyKey is a key claimed by a create order request. RequestHash is empty for keys stored before requests
+// were fingerprinted, OrderID is nil until the order is created.
+type IdempotencyKey struct {
+	Key         string
+	RequestHash string
+	OrderID     *uuid.UUID
+}
@@ -3,20 +3,100 @@ package model
 import (
 	"time"
 
-	"github.com/leetm4n/orders-service/pkg/tracing"
+	"github.com/google/uuid"
+	"github.com/leetm4n/orders-service/internal/domain"
+	"github.com/leetm4n/orders-service/pkg/money"
 )
 
+type OrderItem struct {
+	Sku       uuid.UUID `json:"sku"`
+	Quantity  int       `json:"quantity"`
+	UnitPrice string    `json:"unitPrice"`
+}
+
+type Address struct {
+	Name       string   `json:"name"`
+	Lines      []string `json:"lines"`
+	City       string   `json:"city"`
+	Region     string   `json:"region,omitempty"`
+	PostalCode string   `json:"postalCode"`
+	Country    string   `json:"country"`
+}
+
+// Order is an order with its amounts formatted to the minor unit of its currency, it is also the order sent in
+// events and recorded in the order history.
 type Order struct {
-	ID              string    `json:"id"`
-	Quantity        int       `json:"quantity"`
-	CreatedAt       time.Time `json:"createdAt"`
-	UpdatedAt       time.Time `json:"updatedAt"`
-	Status          string    `json:"status"`
-	ShippingAddress string    `json:"shippingAddress"`
-	Sku             string    `json:"sku"`
+	ID                    uuid.UUID          `json:"id"`
+	Items                 []OrderItem        `json:"items"`
+	Currency              string             `json:"currency"`
+	Subtotal              string             `json:"subtotal"`
+	Tax                   string             `json:"tax"`
+	Total                 string             `json:"total"`
+	CreatedAt             time.Time          `json:"createdAt"`
+	UpdatedAt             time.Time          `json:"updatedAt"`
+	Status                domain.OrderStatus `json:"status"`
+	ShippingAddress       *Address           `json:"shippingAddress,omitempty"`
+	LegacyShippingAddress string             `json:"legacyShippingAddress,omitempty"`
+	Cancellation          *Cancellation      `json:"cancellation,omitempty"`
+	Version               int32              `json:"version"`
+}
+
+type Cancellation struct {
+	Reason     string    `json:"reason"`
+	Note       string    `json:"note,omitempty"`
+	CanceledAt time.Time `json:"canceledAt"`
 }
 
+// NewOrder is an order to be created with its amounts already calculated.
+type NewOrder struct {
+	Currency        string
+	TaxRate         money.Amount
+	Subtotal        money.Amount
+	Tax             money.Amount
+	Total           money.Amount
+	Items           []NewOrderItem
+	ShippingAddress Address
+}
+
+type NewOrderItem struct {
+	Sku       uuid.UUID
+	Quantity  int
+	UnitPrice money.Amount
+}
+
+// OrderFilter selects a page of orders ordered by creation time and id, nil fields do not filter.
+type OrderFilter struct {
+	Status      *domain.OrderStatus
+	Sku         *uuid.UUID
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	// After is the position of the last order of the previous page.
+	After     *OrderPosition
+	Ascending bool
+	Limit     int
+}
+
+type OrderPosition struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// Order events are sent as the data of CloudEvents, the trace context travels in the traceparent extension.
+const OrderCreatedEventType = "order.created"
+
 type OrderCreatedEvent struct {
-	Order Order                 `json:"order"`
-	Trace tracing.TraceEnvelope `json:"trace"`
+	Order Order `json:"order"`
+}
+
+const OrderStatusChangedEventType = "order.status_changed"
+
+type OrderStatusChangedEvent struct {
+	Order          Order  `json:"order"`
+	PreviousStatus string `json:"previousStatus"`
+}
+
+const OrderCanceledEventType = "order.canceled"
+
+type OrderCanceledEvent struct {
+	Order Order `json:"order"`
 }
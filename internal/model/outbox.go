@@ -0,0 +1,13 @@
+package model
+
+import (
+	"github.com/google/uuid"
+)
+
+// OutboxEvent is an event waiting in the outbox to be published, its payload is a CloudEvent in structured mode.
+type OutboxEvent struct {
+	ID          uuid.UUID
+	AggregateID uuid.UUID
+	EventType   string
+	Payload     []byte
+}
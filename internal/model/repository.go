@@ -0,0 +1,101 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leetm4n/orders-service/internal/domain"
+)
+
+var (
+	ErrNotFound = errors.New("not found")
+	ErrConflict = errors.New("conflict")
+)
+
+// OrderRepository stores orders together with their history, idempotency keys and outbox events. Lookups of missing
+// records fail with ErrNotFound, conditional writes that do not apply with ErrConflict.
+type OrderRepository interface {
+	// InTx runs fn with a repository bound to a transaction, which is committed when fn succeeds and rolled back
+	// otherwise.
+	InTx(ctx context.Context, fn func(repo OrderRepository) error) error
+
+	GetOrder(ctx context.Context, id uuid.UUID) (Order, error)
+	ListOrders(ctx context.Context, filter OrderFilter) ([]Order, error)
+	CreateOrder(ctx context.Context, order NewOrder) (Order, error)
+	// UpdateOrderStatus and CancelOrder change the order only while it still has the status and version of order.
+	UpdateOrderStatus(ctx context.Context, order Order, status domain.OrderStatus) (Order, error)
+	CancelOrder(ctx context.Context, order Order, reason string, note *string) (Order, error)
+
+	// ClaimIdempotencyKey fails with ErrConflict while the key is claimed and not expired yet.
+	ClaimIdempotencyKey(ctx context.Context, key, requestHash string, ttl time.Duration) error
+	GetIdempotencyKey(ctx context.Context, key string) (IdempotencyKey, error)
+	SetIdempotencyKeyOrder(ctx context.Context, key string, orderID uuid.UUID) error
+
+	CreateHistoryEntry(ctx context.Context, entry HistoryEntry) error
+	ListOrderHistory(ctx context.Context, orderID uuid.UUID) ([]HistoryEntry, error)
+	// ListOrderHistoryAfter returns at most limit entries committed after afterID in commit order, of every order when
+	// orderID is nil. Entries are only returned once no transaction that can still commit an earlier one is running.
+	ListOrderHistoryAfter(ctx context.Context, afterID int64, orderID *uuid.UUID, limit int) ([]HistoryEntry, error)
+	// GetLastOrderHistoryID returns the id of the last entry in commit order that ListOrderHistoryAfter returns.
+	GetLastOrderHistoryID(ctx context.Context) (int64, error)
+
+	CreateOutboxEvent(ctx context.Context, event OutboxEvent) error
+}
+
+// OutboxRepository gives the relay and the worker access to the outbox the OrderRepository writes events to.
+type OutboxRepository interface {
+	// LeasePendingOutboxEvents returns up to limit pending events in the order they were written, they are not
+	// returned again before the lease ends. An event stays pending until it is marked as dispatched, so an event whose
+	// publishing failed or was interrupted is leased again once its lease ended.
+	LeasePendingOutboxEvents(ctx context.Context, limit int, lease time.Duration) ([]OutboxEvent, error)
+	MarkOutboxEventDispatched(ctx context.Context, id uuid.UUID) error
+	// ReceiveOutboxEvent marks the event as received by a consumer and returns it, it reports false when the event
+	// was received before or does not exist. Transports that only carry the id claim events with it, so every event
+	// is received once.
+	ReceiveOutboxEvent(ctx context.Context, id uuid.UUID) (OutboxEvent, bool, error)
+	// ListUnreceivedOutboxEventIDs returns the ids of up to limit events no consumer received yet, pending or
+	// dispatched, in the order they were written.
+	ListUnreceivedOutboxEventIDs(ctx context.Context, limit int) ([]uuid.UUID, error)
+	// RequeueOutboxEvent moves a dispatched or received event back to pending and reports whether there was such an
+	// event.
+	RequeueOutboxEvent(ctx context.Context, id uuid.UUID) (bool, error)
+}
+
+// WebhookRepository stores webhook subscriptions and their deliveries. Lookups of missing subscriptions fail with
+// ErrNotFound.
+type WebhookRepository interface {
+	CreateWebhookSubscription(ctx context.Context, subscription NewWebhookSubscription) (WebhookSubscription, error)
+	// ListWebhookSubscriptions returns the subscriptions in the order they were created.
+	ListWebhookSubscriptions(ctx context.Context) ([]WebhookSubscription, error)
+	GetWebhookSubscription(ctx context.Context, id uuid.UUID) (WebhookSubscription, error)
+	UpdateWebhookSubscription(ctx context.Context, id uuid.UUID, update WebhookSubscriptionUpdate) (WebhookSubscription, error)
+	// DeleteWebhookSubscription deletes the subscription together with its deliveries.
+	DeleteWebhookSubscription(ctx context.Context, id uuid.UUID) error
+	// ListWebhookDeliveries returns up to limit deliveries of the subscription, newest first.
+	ListWebhookDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]WebhookDelivery, error)
+
+	// CreateWebhookDeliveries creates a pending delivery of the event for every active subscription of its type and
+	// returns how many it created. Deliveries are unique per subscription and event, so an event is delivered once.
+	CreateWebhookDeliveries(ctx context.Context, eventID, eventType string, payload []byte) (int, error)
+	// LeaseDueWebhookDeliveries returns up to limit pending deliveries of active subscriptions that are due, they are
+	// not due again before the lease ends.
+	LeaseDueWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]LeasedWebhookDelivery, error)
+	// RecordWebhookDeliveryAttempt stores the attempt and its outcome together. A successful attempt resets the
+	// failures of the subscription, a failed one is retried as policy says. It reports whether the attempt disabled
+	// the subscription.
+	RecordWebhookDeliveryAttempt(ctx context.Context, attempt WebhookDeliveryAttempt, policy WebhookRetryPolicy) (bool, error)
+}
+
+// DeadLetterRepository stores the events the worker could not process. Lookups of missing events fail with
+// ErrNotFound.
+type DeadLetterRepository interface {
+	CreateDeadLetterEvent(ctx context.Context, event DeadLetterEvent) error
+	ListDeadLetterEvents(ctx context.Context, filter DeadLetterFilter) ([]DeadLetterEvent, error)
+	GetDeadLetterEvent(ctx context.Context, id int64) (DeadLetterEvent, error)
+	DeleteDeadLetterEvent(ctx context.Context, id int64) error
+	// ReplayDeadLetterEvent moves the event back to the outbox under a new id, so the relay publishes it again. It
+	// fails with ErrConflict for events without an aggregate id, which stay dead-lettered.
+	ReplayDeadLetterEvent(ctx context.Context, id int64) error
+}
@@ -0,0 +1,90 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookSubscription receives the events of its EventTypes, of every type when EventTypes is empty. DisabledAt is
+// set while the subscription is disabled.
+type WebhookSubscription struct {
+	ID                  uuid.UUID
+	URL                 string
+	EventTypes          []string
+	Secret              string
+	Active              bool
+	ConsecutiveFailures int
+	DisabledAt          *time.Time
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+type NewWebhookSubscription struct {
+	URL        string
+	EventTypes []string
+	Secret     string
+}
+
+// WebhookSubscriptionUpdate changes the fields that are set, a nil EventTypes keeps the event types and an empty one
+// subscribes to every event type. Enabling a subscription again resets its failures.
+type WebhookSubscriptionUpdate struct {
+	URL        *string
+	EventTypes []string
+	Active     *bool
+}
+
+// WebhookDelivery is an event to be posted to a subscription, LastStatusCode and LastError are those of the last
+// attempt.
+type WebhookDelivery struct {
+	ID             uuid.UUID
+	SubscriptionID uuid.UUID
+	EventID        string
+	EventType      string
+	Payload        []byte
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatusCode *int
+	LastError      *string
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+}
+
+// LeasedWebhookDelivery is a delivery leased to be posted together with the endpoint of its subscription.
+type LeasedWebhookDelivery struct {
+	ID             uuid.UUID
+	SubscriptionID uuid.UUID
+	EventType      string
+	Payload        []byte
+	Attempts       int
+	URL            string
+	Secret         string
+}
+
+// WebhookDeliveryAttempt is the outcome of posting a delivery, the attempt failed when Error is set. StatusCode is
+// nil when there was no response.
+type WebhookDeliveryAttempt struct {
+	DeliveryID     uuid.UUID
+	SubscriptionID uuid.UUID
+	Attempt        int
+	StatusCode     *int
+	Error          *string
+	Duration       time.Duration
+}
+
+// WebhookRetryPolicy decides what a failed attempt leads to. The delivery is retried after RetryIn until it made
+// MaxAttempts attempts and the subscription is disabled after DisableAfterFailures failures in a row.
+type WebhookRetryPolicy struct {
+	MaxAttempts          int
+	RetryIn              time.Duration
+	DisableAfterFailures int
+}
@@ -0,0 +1,136 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/leetm4n/orders-service/internal/model"
+)
+
+// PostgresDeadLetterRepository implements model.DeadLetterRepository with the generated queries.
+type PostgresDeadLetterRepository struct {
+	pool    *pgxpool.Pool
+	queries *Queries
+}
+
+func NewPostgresDeadLetterRepository(pool *pgxpool.Pool) *PostgresDeadLetterRepository {
+	return &PostgresDeadLetterRepository{
+		pool:    pool,
+		queries: New(pool),
+	}
+}
+
+func (r *PostgresDeadLetterRepository) CreateDeadLetterEvent(ctx context.Context, event model.DeadLetterEvent) error {
+	params := CreateDeadLetterEventParams{
+		EventID:   event.EventID,
+		EventType: event.EventType,
+		Payload:   event.Payload,
+		Error:     event.Error,
+		Attempts:  int32(event.Attempts),
+		Trace:     event.Trace,
+	}
+
+	if event.AggregateID != nil {
+		params.AggregateID = toUUID(*event.AggregateID)
+	}
+
+	_, err := r.queries.CreateDeadLetterEvent(ctx, params)
+
+	return err
+}
+
+func (r *PostgresDeadLetterRepository) ListDeadLetterEvents(
+	ctx context.Context,
+	filter model.DeadLetterFilter,
+) ([]model.DeadLetterEvent, error) {
+	params := ListDeadLetterEventsParams{
+		Limit: int32(filter.Limit),
+	}
+
+	if filter.EventType != nil {
+		params.EventType = pgtype.Text{String: *filter.EventType, Valid: true}
+	}
+
+	if filter.BeforeID != nil {
+		params.CursorID = pgtype.Int8{Int64: *filter.BeforeID, Valid: true}
+	}
+
+	events, err := r.queries.ListDeadLetterEvents(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.DeadLetterEvent, 0, len(events))
+	for _, event := range events {
+		result = append(result, toModelDeadLetterEvent(event))
+	}
+
+	return result, nil
+}
+
+func (r *PostgresDeadLetterRepository) GetDeadLetterEvent(ctx context.Context, id int64) (model.DeadLetterEvent, error) {
+	event, err := r.queries.GetDeadLetterEvent(ctx, id)
+	if err != nil {
+		return model.DeadLetterEvent{}, notFound(err)
+	}
+
+	return toModelDeadLetterEvent(event), nil
+}
+
+func (r *PostgresDeadLetterRepository) DeleteDeadLetterEvent(ctx context.Context, id int64) error {
+	_, err := r.queries.DeleteDeadLetterEvent(ctx, id)
+
+	return notFound(err)
+}
+
+// ReplayDeadLetterEvent deletes the event first, which locks its row, so a concurrent replay of the same event finds
+// nothing to replay.
+func (r *PostgresDeadLetterRepository) ReplayDeadLetterEvent(ctx context.Context, id int64) error {
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		qtx := r.queries.WithTx(tx)
+
+		event, err := qtx.DeleteDeadLetterEvent(ctx, id)
+		if err != nil {
+			return notFound(err)
+		}
+
+		if !event.AggregateID.Valid {
+			return model.ErrConflict
+		}
+
+		if _, err := qtx.CreateOutboxEvent(ctx, CreateOutboxEventParams{
+			ID:          toUUID(uuid.New()),
+			AggregateID: event.AggregateID,
+			EventType:   event.EventType,
+			Payload:     event.Payload,
+		}); err != nil {
+			return fmt.Errorf("write replayed event to outbox: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func toModelDeadLetterEvent(event DeadLetterEvent) model.DeadLetterEvent {
+	result := model.DeadLetterEvent{
+		ID:        event.ID,
+		EventID:   event.EventID,
+		EventType: event.EventType,
+		Payload:   event.Payload,
+		Error:     event.Error,
+		Attempts:  int(event.Attempts),
+		Trace:     event.Trace,
+		CreatedAt: event.CreatedAt.Time,
+	}
+
+	if event.AggregateID.Valid {
+		aggregateID := uuid.UUID(event.AggregateID.Bytes)
+		result.AggregateID = &aggregateID
+	}
+
+	return result
+}
@@ -0,0 +1,131 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/leetm4n/orders-service/internal/model"
+)
+
+// DeadLetterRepository implements model.DeadLetterRepository in memory, events are replayed into the outbox of
+// orders. Like a sequence ids are not reused after an event was deleted.
+type DeadLetterRepository struct {
+	mu     sync.Mutex
+	orders model.OrderRepository
+	events []model.DeadLetterEvent
+	lastID int64
+}
+
+func NewDeadLetterRepository(orders model.OrderRepository) *DeadLetterRepository {
+	return &DeadLetterRepository{orders: orders}
+}
+
+func (r *DeadLetterRepository) CreateDeadLetterEvent(_ context.Context, event model.DeadLetterEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+
+	event = cloneDeadLetterEvent(event)
+	event.ID = r.lastID
+	event.CreatedAt = now()
+	r.events = append(r.events, event)
+
+	return nil
+}
+
+func (r *DeadLetterRepository) ListDeadLetterEvents(
+	_ context.Context,
+	filter model.DeadLetterFilter,
+) ([]model.DeadLetterEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	events := []model.DeadLetterEvent{}
+	for _, event := range slices.Backward(r.events) {
+		if len(events) == filter.Limit {
+			break
+		}
+
+		if (filter.EventType == nil || event.EventType == *filter.EventType) &&
+			(filter.BeforeID == nil || event.ID < *filter.BeforeID) {
+			events = append(events, cloneDeadLetterEvent(event))
+		}
+	}
+
+	return events, nil
+}
+
+func (r *DeadLetterRepository) GetDeadLetterEvent(_ context.Context, id int64) (model.DeadLetterEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.index(id)
+	if i < 0 {
+		return model.DeadLetterEvent{}, model.ErrNotFound
+	}
+
+	return cloneDeadLetterEvent(r.events[i]), nil
+}
+
+func (r *DeadLetterRepository) DeleteDeadLetterEvent(_ context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.index(id)
+	if i < 0 {
+		return model.ErrNotFound
+	}
+
+	r.events = slices.Delete(r.events, i, i+1)
+
+	return nil
+}
+
+// ReplayDeadLetterEvent holds the lock while it writes to the outbox, so the event is replayed once.
+func (r *DeadLetterRepository) ReplayDeadLetterEvent(ctx context.Context, id int64) error {
+	return r.orders.InTx(ctx, func(orders model.OrderRepository) error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		i := r.index(id)
+		if i < 0 {
+			return model.ErrNotFound
+		}
+
+		event := r.events[i]
+		if event.AggregateID == nil {
+			return model.ErrConflict
+		}
+
+		if err := orders.CreateOutboxEvent(ctx, model.OutboxEvent{
+			ID:          uuid.New(),
+			AggregateID: *event.AggregateID,
+			EventType:   event.EventType,
+			Payload:     slices.Clone(event.Payload),
+		}); err != nil {
+			return err
+		}
+
+		r.events = slices.Delete(r.events, i, i+1)
+
+		return nil
+	})
+}
+
+func (r *DeadLetterRepository) index(id int64) int {
+	return slices.IndexFunc(r.events, func(event model.DeadLetterEvent) bool {
+		return event.ID == id
+	})
+}
+
+// cloneDeadLetterEvent copies what the event references, so callers cannot change the stored event.
+func cloneDeadLetterEvent(event model.DeadLetterEvent) model.DeadLetterEvent {
+	event.AggregateID = clonePtr(event.AggregateID)
+	event.Payload = slices.Clone(event.Payload)
+	event.Trace = slices.Clone(event.Trace)
+
+	return event
+}
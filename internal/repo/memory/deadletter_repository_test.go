@@ -0,0 +1,132 @@
+package memory
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/leetm4n/orders-service/internal/model"
+)
+
+func TestReplayDeadLetterEvent(t *testing.T) {
+	aggregateID := uuid.New()
+
+	type args struct {
+		aggregateID *uuid.UUID
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantErr    error
+		wantOutbox int
+	}{
+		{
+			name:       "should move the event back to the outbox",
+			args:       args{aggregateID: &aggregateID},
+			wantErr:    nil,
+			wantOutbox: 1,
+		},
+		{
+			name:       "should keep an event without aggregate id",
+			args:       args{aggregateID: nil},
+			wantErr:    model.ErrConflict,
+			wantOutbox: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orders := NewOrderRepository()
+			r := NewDeadLetterRepository(orders)
+			if err := r.CreateDeadLetterEvent(t.Context(), model.DeadLetterEvent{
+				EventID:     "event-1",
+				AggregateID: tt.args.aggregateID,
+				EventType:   "order.created",
+				Payload:     []byte(`{}`),
+				Error:       "failed",
+				Attempts:    3,
+				Trace:       []byte(`{}`),
+			}); err != nil {
+				t.Fatalf("CreateDeadLetterEvent() error = %v", err)
+			}
+
+			events, err := r.ListDeadLetterEvents(t.Context(), model.DeadLetterFilter{Limit: 10})
+			if err != nil || len(events) != 1 {
+				t.Fatalf("ListDeadLetterEvents() = %v, %v, want the stored event", events, err)
+			}
+
+			if err := r.ReplayDeadLetterEvent(t.Context(), events[0].ID); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ReplayDeadLetterEvent() error = %v, want %v", err, tt.wantErr)
+			}
+
+			// only a replayed event is gone, replaying it again finds nothing
+			_, err = r.GetDeadLetterEvent(t.Context(), events[0].ID)
+			if gone := errors.Is(err, model.ErrNotFound); gone != (tt.wantErr == nil) {
+				t.Errorf("GetDeadLetterEvent() error = %v after replay", err)
+			}
+
+			ids, err := orders.ListUnreceivedOutboxEventIDs(t.Context(), 10)
+			if err != nil {
+				t.Fatalf("ListUnreceivedOutboxEventIDs() error = %v", err)
+			}
+
+			if len(ids) != tt.wantOutbox {
+				t.Errorf("ListUnreceivedOutboxEventIDs() = %d events, want %d", len(ids), tt.wantOutbox)
+			}
+		})
+	}
+}
+
+func TestListDeadLetterEvents(t *testing.T) {
+	r := NewDeadLetterRepository(NewOrderRepository())
+	for _, eventType := range []string{"order.created", "order.canceled", "order.created"} {
+		if err := r.CreateDeadLetterEvent(t.Context(), model.DeadLetterEvent{EventType: eventType}); err != nil {
+			t.Fatalf("CreateDeadLetterEvent() error = %v", err)
+		}
+	}
+
+	eventType := "order.created"
+	beforeID := int64(3)
+
+	type args struct {
+		filter model.DeadLetterFilter
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantIDs []int64
+	}{
+		{
+			name:    "should list the newest events first",
+			args:    args{filter: model.DeadLetterFilter{Limit: 2}},
+			wantIDs: []int64{3, 2},
+		},
+		{
+			name:    "should list the events of a type",
+			args:    args{filter: model.DeadLetterFilter{EventType: &eventType, Limit: 10}},
+			wantIDs: []int64{3, 1},
+		},
+		{
+			name:    "should list the events before the cursor",
+			args:    args{filter: model.DeadLetterFilter{BeforeID: &beforeID, Limit: 10}},
+			wantIDs: []int64{2, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := r.ListDeadLetterEvents(t.Context(), tt.args.filter)
+			if err != nil {
+				t.Fatalf("ListDeadLetterEvents() error = %v", err)
+			}
+
+			ids := []int64{}
+			for _, event := range events {
+				ids = append(ids, event.ID)
+			}
+
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("ListDeadLetterEvents() ids = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
@@ -0,0 +1,494 @@
+// Package memory keeps orders, webhooks and dead letters in memory for tests and local development without a database.
+package memory
+
+import (
+	"bytes"
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leetm4n/orders-service/internal/domain"
+	"github.com/leetm4n/orders-service/internal/model"
+	"github.com/leetm4n/orders-service/pkg/money"
+)
+
+// OrderRepository implements model.OrderRepository and model.OutboxRepository in memory. Transactions hold a lock
+// for their whole duration, so they are serialized, and are rolled back by restoring the state they started with.
+// Like in postgres timestamps have microsecond precision, every write of a transaction uses its start time and ids
+// of history entries are not reused after a rollback.
+type OrderRepository struct {
+	mu    *sync.Mutex
+	state *state
+	// tx is set for repositories bound to a transaction, which already hold the lock
+	tx *transaction
+}
+
+type transaction struct {
+	now time.Time
+}
+
+type state struct {
+	orders        map[uuid.UUID]model.Order
+	keys          map[string]idempotencyKey
+	history       []model.HistoryEntry
+	outbox        []outboxEvent
+	lastHistoryID int64
+}
+
+type idempotencyKey struct {
+	model.IdempotencyKey
+	expiresAt time.Time
+}
+
+type outboxEvent struct {
+	model.OutboxEvent
+	dispatched  bool
+	received    bool
+	leasedUntil time.Time
+}
+
+func NewOrderRepository() *OrderRepository {
+	return &OrderRepository{
+		mu: &sync.Mutex{},
+		state: &state{
+			orders: map[uuid.UUID]model.Order{},
+			keys:   map[string]idempotencyKey{},
+		},
+	}
+}
+
+func (r *OrderRepository) InTx(ctx context.Context, fn func(repo model.OrderRepository) error) error {
+	if r.tx != nil {
+		return fn(r)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	snapshot := state{
+		orders:  maps.Clone(r.state.orders),
+		keys:    maps.Clone(r.state.keys),
+		history: slices.Clone(r.state.history),
+		outbox:  slices.Clone(r.state.outbox),
+	}
+
+	if err := fn(&OrderRepository{mu: r.mu, state: r.state, tx: &transaction{now: now()}}); err != nil {
+		// like a sequence the last history id is not rolled back
+		snapshot.lastHistoryID = r.state.lastHistoryID
+		*r.state = snapshot
+
+		return err
+	}
+
+	return nil
+}
+
+func (r *OrderRepository) GetOrder(_ context.Context, id uuid.UUID) (model.Order, error) {
+	var order model.Order
+	err := r.do(func(s *state, _ time.Time) error {
+		stored, ok := s.orders[id]
+		if !ok {
+			return model.ErrNotFound
+		}
+
+		order = cloneOrder(stored)
+
+		return nil
+	})
+
+	return order, err
+}
+
+func (r *OrderRepository) ListOrders(_ context.Context, filter model.OrderFilter) ([]model.Order, error) {
+	page := []model.Order{}
+	err := r.do(func(s *state, _ time.Time) error {
+		orders := slices.SortedFunc(maps.Values(s.orders), compareOrders)
+		if !filter.Ascending {
+			slices.Reverse(orders)
+		}
+
+		for _, order := range orders {
+			if len(page) == filter.Limit {
+				break
+			}
+
+			if matches(order, filter) {
+				page = append(page, cloneOrder(order))
+			}
+		}
+
+		return nil
+	})
+
+	return page, err
+}
+
+func (r *OrderRepository) CreateOrder(_ context.Context, newOrder model.NewOrder) (model.Order, error) {
+	var order model.Order
+	err := r.do(func(s *state, now time.Time) error {
+		scale, err := money.MinorUnits(newOrder.Currency)
+		if err != nil {
+			return err
+		}
+
+		items := make([]model.OrderItem, 0, len(newOrder.Items))
+		for _, item := range newOrder.Items {
+			items = append(items, model.OrderItem{
+				Sku:       item.Sku,
+				Quantity:  item.Quantity,
+				UnitPrice: money.FormatAmount(item.UnitPrice, scale),
+			})
+		}
+
+		address := newOrder.ShippingAddress
+		address.Lines = slices.Clone(address.Lines)
+
+		order = model.Order{
+			ID:              uuid.New(),
+			Items:           items,
+			Currency:        newOrder.Currency,
+			Subtotal:        money.FormatAmount(newOrder.Subtotal, scale),
+			Tax:             money.FormatAmount(newOrder.Tax, scale),
+			Total:           money.FormatAmount(newOrder.Total, scale),
+			CreatedAt:       now,
+			UpdatedAt:       now,
+			Status:          domain.OrderStatusPending,
+			ShippingAddress: &address,
+			Version:         1,
+		}
+		s.orders[order.ID] = order
+		order = cloneOrder(order)
+
+		return nil
+	})
+
+	return order, err
+}
+
+func (r *OrderRepository) UpdateOrderStatus(
+	_ context.Context,
+	order model.Order,
+	status domain.OrderStatus,
+) (model.Order, error) {
+	return r.update(order, func(stored *model.Order, _ time.Time) {
+		stored.Status = status
+	})
+}
+
+func (r *OrderRepository) CancelOrder(
+	_ context.Context,
+	order model.Order,
+	reason string,
+	note *string,
+) (model.Order, error) {
+	return r.update(order, func(stored *model.Order, now time.Time) {
+		stored.Status = domain.OrderStatusCanceled
+		stored.Cancellation = &model.Cancellation{Reason: reason, CanceledAt: now}
+
+		if note != nil {
+			stored.Cancellation.Note = *note
+		}
+	})
+}
+
+// update applies change to the stored order while it has the status and version of order, the version is
+// incremented with every change.
+func (r *OrderRepository) update(order model.Order, change func(stored *model.Order, now time.Time)) (model.Order, error) {
+	var updated model.Order
+	err := r.do(func(s *state, now time.Time) error {
+		stored, ok := s.orders[order.ID]
+		if !ok || stored.Status != order.Status || stored.Version != order.Version {
+			return model.ErrConflict
+		}
+
+		change(&stored, now)
+		stored.UpdatedAt = now
+		stored.Version++
+		s.orders[order.ID] = stored
+		updated = cloneOrder(stored)
+
+		return nil
+	})
+
+	return updated, err
+}
+
+// ClaimIdempotencyKey takes over expired keys like the postgres query does.
+func (r *OrderRepository) ClaimIdempotencyKey(_ context.Context, key, requestHash string, ttl time.Duration) error {
+	return r.do(func(s *state, now time.Time) error {
+		if claimed, ok := s.keys[key]; ok && claimed.expiresAt.After(now) {
+			return model.ErrConflict
+		}
+
+		s.keys[key] = idempotencyKey{
+			IdempotencyKey: model.IdempotencyKey{Key: key, RequestHash: requestHash},
+			expiresAt:      now.Add(ttl.Truncate(time.Second)),
+		}
+
+		return nil
+	})
+}
+
+func (r *OrderRepository) GetIdempotencyKey(_ context.Context, key string) (model.IdempotencyKey, error) {
+	var result model.IdempotencyKey
+	err := r.do(func(s *state, _ time.Time) error {
+		claimed, ok := s.keys[key]
+		if !ok {
+			return model.ErrNotFound
+		}
+
+		result = claimed.IdempotencyKey
+
+		return nil
+	})
+
+	return result, err
+}
+
+func (r *OrderRepository) SetIdempotencyKeyOrder(_ context.Context, key string, orderID uuid.UUID) error {
+	return r.do(func(s *state, _ time.Time) error {
+		claimed, ok := s.keys[key]
+		if !ok {
+			return nil
+		}
+
+		claimed.OrderID = &orderID
+		s.keys[key] = claimed
+
+		return nil
+	})
+}
+
+func (r *OrderRepository) CreateHistoryEntry(_ context.Context, entry model.HistoryEntry) error {
+	return r.do(func(s *state, now time.Time) error {
+		s.lastHistoryID++
+
+		entry.ID = s.lastHistoryID
+		entry.CreatedAt = now
+		s.history = append(s.history, entry)
+
+		return nil
+	})
+}
+
+func (r *OrderRepository) ListOrderHistory(_ context.Context, orderID uuid.UUID) ([]model.HistoryEntry, error) {
+	return r.listOrderHistory(0, &orderID, -1)
+}
+
+func (r *OrderRepository) ListOrderHistoryAfter(
+	_ context.Context,
+	afterID int64,
+	orderID *uuid.UUID,
+	limit int,
+) ([]model.HistoryEntry, error) {
+	return r.listOrderHistory(afterID, orderID, limit)
+}
+
+// listOrderHistory returns the entries following afterID in id order, a negative limit returns all of them.
+func (r *OrderRepository) listOrderHistory(afterID int64, orderID *uuid.UUID, limit int) ([]model.HistoryEntry, error) {
+	entries := []model.HistoryEntry{}
+	err := r.do(func(s *state, _ time.Time) error {
+		for _, entry := range s.history {
+			if len(entries) == limit {
+				break
+			}
+
+			if entry.ID > afterID && (orderID == nil || entry.OrderID == *orderID) {
+				entries = append(entries, entry)
+			}
+		}
+
+		return nil
+	})
+
+	return entries, err
+}
+
+func (r *OrderRepository) GetLastOrderHistoryID(_ context.Context) (int64, error) {
+	var lastID int64
+	err := r.do(func(s *state, _ time.Time) error {
+		if len(s.history) > 0 {
+			lastID = s.history[len(s.history)-1].ID
+		}
+
+		return nil
+	})
+
+	return lastID, err
+}
+
+func (r *OrderRepository) CreateOutboxEvent(_ context.Context, event model.OutboxEvent) error {
+	return r.do(func(s *state, _ time.Time) error {
+		s.outbox = append(s.outbox, outboxEvent{OutboxEvent: event})
+
+		return nil
+	})
+}
+
+func (r *OrderRepository) ReceiveOutboxEvent(_ context.Context, id uuid.UUID) (model.OutboxEvent, bool, error) {
+	var (
+		found    model.OutboxEvent
+		received bool
+	)
+	err := r.do(func(s *state, _ time.Time) error {
+		for i, event := range s.outbox {
+			if event.ID == id && !event.received {
+				s.outbox[i].received = true
+				found, received = event.OutboxEvent, true
+			}
+		}
+
+		return nil
+	})
+
+	return found, received, err
+}
+
+func (r *OrderRepository) ListUnreceivedOutboxEventIDs(_ context.Context, limit int) ([]uuid.UUID, error) {
+	ids := []uuid.UUID{}
+	err := r.do(func(s *state, _ time.Time) error {
+		for _, event := range s.outbox {
+			if len(ids) == limit {
+				break
+			}
+
+			if !event.received {
+				ids = append(ids, event.ID)
+			}
+		}
+
+		return nil
+	})
+
+	return ids, err
+}
+
+func (r *OrderRepository) LeasePendingOutboxEvents(
+	_ context.Context,
+	limit int,
+	lease time.Duration,
+) ([]model.OutboxEvent, error) {
+	leased := []model.OutboxEvent{}
+	err := r.do(func(s *state, now time.Time) error {
+		for i, event := range s.outbox {
+			if len(leased) == limit {
+				break
+			}
+
+			if !event.dispatched && !event.leasedUntil.After(now) {
+				s.outbox[i].leasedUntil = now.Add(lease)
+				leased = append(leased, event.OutboxEvent)
+			}
+		}
+
+		return nil
+	})
+
+	return leased, err
+}
+
+func (r *OrderRepository) MarkOutboxEventDispatched(_ context.Context, id uuid.UUID) error {
+	return r.do(func(s *state, _ time.Time) error {
+		for i, event := range s.outbox {
+			if event.ID == id {
+				s.outbox[i].dispatched = true
+				s.outbox[i].leasedUntil = time.Time{}
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *OrderRepository) RequeueOutboxEvent(_ context.Context, id uuid.UUID) (bool, error) {
+	requeued := false
+	err := r.do(func(s *state, _ time.Time) error {
+		for i, event := range s.outbox {
+			if event.ID == id && (event.dispatched || event.received) {
+				s.outbox[i].dispatched = false
+				s.outbox[i].received = false
+				s.outbox[i].leasedUntil = time.Time{}
+				requeued = true
+			}
+		}
+
+		return nil
+	})
+
+	return requeued, err
+}
+
+// do runs fn on the state, with the lock held and the current time unless the repository is bound to a transaction.
+func (r *OrderRepository) do(fn func(s *state, now time.Time) error) error {
+	if r.tx != nil {
+		return fn(r.state, r.tx.now)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return fn(r.state, now())
+}
+
+// now returns the current time with the precision of a postgres timestamp.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+func compareOrders(a, b model.Order) int {
+	if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+		return c
+	}
+
+	return bytes.Compare(a.ID[:], b.ID[:])
+}
+
+func matches(order model.Order, filter model.OrderFilter) bool {
+	if filter.Status != nil && order.Status != *filter.Status {
+		return false
+	}
+
+	if filter.Sku != nil && !slices.ContainsFunc(order.Items, func(item model.OrderItem) bool {
+		return item.Sku == *filter.Sku
+	}) {
+		return false
+	}
+
+	if filter.CreatedFrom != nil && order.CreatedAt.Before(*filter.CreatedFrom) {
+		return false
+	}
+
+	if filter.CreatedTo != nil && !order.CreatedAt.Before(*filter.CreatedTo) {
+		return false
+	}
+
+	if filter.After != nil {
+		c := compareOrders(order, model.Order{CreatedAt: filter.After.CreatedAt, ID: filter.After.ID})
+		if (filter.Ascending && c <= 0) || (!filter.Ascending && c >= 0) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// cloneOrder copies what the order references, so callers cannot change the stored order.
+func cloneOrder(order model.Order) model.Order {
+	order.Items = slices.Clone(order.Items)
+
+	if order.ShippingAddress != nil {
+		address := *order.ShippingAddress
+		address.Lines = slices.Clone(address.Lines)
+		order.ShippingAddress = &address
+	}
+
+	if order.Cancellation != nil {
+		cancellation := *order.Cancellation
+		order.Cancellation = &cancellation
+	}
+
+	return order
+}
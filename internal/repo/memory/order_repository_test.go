@@ -0,0 +1,296 @@
+package memory
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leetm4n/orders-service/internal/domain"
+	"github.com/leetm4n/orders-service/internal/model"
+	"github.com/leetm4n/orders-service/pkg/money"
+)
+
+func TestClaimIdempotencyKey(t *testing.T) {
+	type args struct {
+		ttl time.Duration
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name:    "should conflict while the key is live",
+			args:    args{ttl: time.Hour},
+			wantErr: model.ErrConflict,
+		},
+		{
+			name:    "should take over an expired key",
+			args:    args{ttl: 0},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewOrderRepository()
+			if err := r.ClaimIdempotencyKey(t.Context(), "key", "first", tt.args.ttl); err != nil {
+				t.Fatalf("ClaimIdempotencyKey() error = %v", err)
+			}
+
+			if err := r.SetIdempotencyKeyOrder(t.Context(), "key", uuid.New()); err != nil {
+				t.Fatalf("SetIdempotencyKeyOrder() error = %v", err)
+			}
+
+			err := r.ClaimIdempotencyKey(t.Context(), "key", "second", tt.args.ttl)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ClaimIdempotencyKey() error = %v, want %v", err, tt.wantErr)
+			}
+
+			key, err := r.GetIdempotencyKey(t.Context(), "key")
+			if err != nil {
+				t.Fatalf("GetIdempotencyKey() error = %v", err)
+			}
+
+			if tt.wantErr == nil && (key.RequestHash != "second" || key.OrderID != nil) {
+				t.Errorf("GetIdempotencyKey() = %+v, want the key claimed again", key)
+			}
+		})
+	}
+}
+
+func TestClaimIdempotencyKeyConcurrently(t *testing.T) {
+	r := NewOrderRepository()
+
+	var wg sync.WaitGroup
+	claimed := make(chan struct{}, 10)
+	for range 10 {
+		wg.Go(func() {
+			if err := r.ClaimIdempotencyKey(t.Context(), "key", "hash", time.Hour); err == nil {
+				claimed <- struct{}{}
+			}
+		})
+	}
+	wg.Wait()
+
+	if len(claimed) != 1 {
+		t.Errorf("ClaimIdempotencyKey() succeeded %d times, want 1", len(claimed))
+	}
+}
+
+func TestInTx(t *testing.T) {
+	errRollback := errors.New("rollback")
+	r := NewOrderRepository()
+
+	err := r.InTx(t.Context(), func(repo model.OrderRepository) error {
+		order, err := repo.CreateOrder(t.Context(), newOrder())
+		if err != nil {
+			return err
+		}
+
+		if err := repo.CreateHistoryEntry(t.Context(), model.HistoryEntry{OrderID: order.ID}); err != nil {
+			return err
+		}
+
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatalf("InTx() error = %v, want %v", err, errRollback)
+	}
+
+	orders, err := r.ListOrders(t.Context(), model.OrderFilter{Limit: 10})
+	if err != nil {
+		t.Fatalf("ListOrders() error = %v", err)
+	}
+
+	if len(orders) != 0 {
+		t.Errorf("ListOrders() = %d orders after rollback, want 0", len(orders))
+	}
+
+	if err := r.CreateHistoryEntry(t.Context(), model.HistoryEntry{OrderID: uuid.New()}); err != nil {
+		t.Fatalf("CreateHistoryEntry() error = %v", err)
+	}
+
+	lastID, err := r.GetLastOrderHistoryID(t.Context())
+	if err != nil {
+		t.Fatalf("GetLastOrderHistoryID() error = %v", err)
+	}
+
+	if lastID != 2 {
+		t.Errorf("GetLastOrderHistoryID() = %d, want 2 as ids are not reused after rollback", lastID)
+	}
+}
+
+func TestReceiveOutboxEvent(t *testing.T) {
+	r := NewOrderRepository()
+	event := model.OutboxEvent{ID: uuid.New(), AggregateID: uuid.New(), EventType: model.OrderCreatedEventType}
+
+	if err := r.CreateOutboxEvent(t.Context(), event); err != nil {
+		t.Fatalf("CreateOutboxEvent() error = %v", err)
+	}
+
+	if ids, err := r.ListUnreceivedOutboxEventIDs(t.Context(), 10); err != nil || len(ids) != 1 || ids[0] != event.ID {
+		t.Fatalf("ListUnreceivedOutboxEventIDs() = %v, %v, want [%s]", ids, err, event.ID)
+	}
+
+	got, received, err := r.ReceiveOutboxEvent(t.Context(), event.ID)
+	if err != nil {
+		t.Fatalf("ReceiveOutboxEvent() error = %v", err)
+	}
+
+	if !received || got.ID != event.ID || got.AggregateID != event.AggregateID || got.EventType != event.EventType {
+		t.Errorf("ReceiveOutboxEvent() = %+v, %v, want %+v, true", got, received, event)
+	}
+
+	if _, received, err := r.ReceiveOutboxEvent(t.Context(), event.ID); err != nil || received {
+		t.Errorf("ReceiveOutboxEvent() again = %v, %v, want false", received, err)
+	}
+
+	if ids, err := r.ListUnreceivedOutboxEventIDs(t.Context(), 10); err != nil || len(ids) != 0 {
+		t.Errorf("ListUnreceivedOutboxEventIDs() after receiving = %v, %v, want none", ids, err)
+	}
+
+	if _, received, err := r.ReceiveOutboxEvent(t.Context(), uuid.New()); err != nil || received {
+		t.Errorf("ReceiveOutboxEvent() of unknown event = %v, %v, want false", received, err)
+	}
+
+	if requeued, err := r.RequeueOutboxEvent(t.Context(), event.ID); err != nil || !requeued {
+		t.Fatalf("RequeueOutboxEvent() = %v, %v, want true", requeued, err)
+	}
+
+	if _, received, err := r.ReceiveOutboxEvent(t.Context(), event.ID); err != nil || !received {
+		t.Errorf("ReceiveOutboxEvent() after requeue = %v, %v, want true", received, err)
+	}
+}
+
+func TestUpdateOrderStatus(t *testing.T) {
+	type args struct {
+		stale bool
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantVersion int32
+		wantErr     error
+	}{
+		{
+			name:        "should increment the version",
+			args:        args{stale: false},
+			wantVersion: 2,
+			wantErr:     nil,
+		},
+		{
+			name:    "should conflict on a stale version",
+			args:    args{stale: true},
+			wantErr: model.ErrConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewOrderRepository()
+			order, err := r.CreateOrder(t.Context(), newOrder())
+			if err != nil {
+				t.Fatalf("CreateOrder() error = %v", err)
+			}
+
+			if order.CreatedAt.Nanosecond()%int(time.Microsecond) != 0 {
+				t.Errorf("CreateOrder() CreatedAt = %v, want microsecond precision", order.CreatedAt)
+			}
+
+			if tt.args.stale {
+				order.Version--
+			}
+
+			updated, err := r.UpdateOrderStatus(t.Context(), order, domain.OrderStatusShipped)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateOrderStatus() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if updated.Version != tt.wantVersion {
+				t.Errorf("UpdateOrderStatus() version = %d, want %d", updated.Version, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestListOrders(t *testing.T) {
+	r := NewOrderRepository()
+
+	var created []model.Order
+	for range 3 {
+		order, err := r.CreateOrder(t.Context(), newOrder())
+		if err != nil {
+			t.Fatalf("CreateOrder() error = %v", err)
+		}
+
+		created = append(created, order)
+	}
+	// orders created within the same microsecond are ordered by id
+	slices.SortFunc(created, compareOrders)
+
+	if _, err := r.UpdateOrderStatus(t.Context(), created[1], domain.OrderStatusShipped); err != nil {
+		t.Fatalf("UpdateOrderStatus() error = %v", err)
+	}
+
+	pending := domain.OrderStatusPending
+	type args struct {
+		filter model.OrderFilter
+	}
+	tests := []struct {
+		name string
+		args args
+		want []uuid.UUID
+	}{
+		{
+			name: "should list ascending",
+			args: args{filter: model.OrderFilter{Ascending: true, Limit: 10}},
+			want: []uuid.UUID{created[0].ID, created[1].ID, created[2].ID},
+		},
+		{
+			name: "should filter by status",
+			args: args{filter: model.OrderFilter{Status: &pending, Ascending: true, Limit: 10}},
+			want: []uuid.UUID{created[0].ID, created[2].ID},
+		},
+		{
+			name: "should continue after the cursor",
+			args: args{filter: model.OrderFilter{
+				After:     &model.OrderPosition{CreatedAt: created[0].CreatedAt, ID: created[0].ID},
+				Ascending: true,
+				Limit:     1,
+			}},
+			want: []uuid.UUID{created[1].ID},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orders, err := r.ListOrders(t.Context(), tt.args.filter)
+			if err != nil {
+				t.Fatalf("ListOrders() error = %v", err)
+			}
+
+			if len(orders) != len(tt.want) {
+				t.Fatalf("ListOrders() = %d orders, want %d", len(orders), len(tt.want))
+			}
+
+			for i, order := range orders {
+				if order.ID != tt.want[i] {
+					t.Errorf("ListOrders()[%d] = %v, want %v", i, order.ID, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func newOrder() model.NewOrder {
+	amount, _ := money.ParseAmount("10")
+
+	return model.NewOrder{
+		Currency: "EUR",
+		Subtotal: amount,
+		Tax:      amount,
+		Total:    amount,
+		Items:    []model.NewOrderItem{{Sku: uuid.New(), Quantity: 1, UnitPrice: amount}},
+	}
+}
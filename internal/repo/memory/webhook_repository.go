@@ -0,0 +1,326 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leetm4n/orders-service/internal/model"
+)
+
+// WebhookRepository implements model.WebhookRepository in memory. Subscriptions and deliveries are kept in the order
+// they were created, which is the order postgres lists them in.
+type WebhookRepository struct {
+	mu            sync.Mutex
+	subscriptions []model.WebhookSubscription
+	deliveries    []model.WebhookDelivery
+	attempts      []model.WebhookDeliveryAttempt
+}
+
+func NewWebhookRepository() *WebhookRepository {
+	return &WebhookRepository{}
+}
+
+func (r *WebhookRepository) CreateWebhookSubscription(
+	_ context.Context,
+	subscription model.NewWebhookSubscription,
+) (model.WebhookSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	at := now()
+	created := model.WebhookSubscription{
+		ID:         uuid.New(),
+		URL:        subscription.URL,
+		EventTypes: slices.Clone(subscription.EventTypes),
+		Secret:     subscription.Secret,
+		Active:     true,
+		CreatedAt:  at,
+		UpdatedAt:  at,
+	}
+	if created.EventTypes == nil {
+		created.EventTypes = []string{}
+	}
+
+	r.subscriptions = append(r.subscriptions, created)
+
+	return cloneWebhookSubscription(created), nil
+}
+
+func (r *WebhookRepository) ListWebhookSubscriptions(_ context.Context) ([]model.WebhookSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	subscriptions := make([]model.WebhookSubscription, 0, len(r.subscriptions))
+	for _, subscription := range r.subscriptions {
+		subscriptions = append(subscriptions, cloneWebhookSubscription(subscription))
+	}
+
+	return subscriptions, nil
+}
+
+func (r *WebhookRepository) GetWebhookSubscription(_ context.Context, id uuid.UUID) (model.WebhookSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.subscriptionIndex(id)
+	if i < 0 {
+		return model.WebhookSubscription{}, model.ErrNotFound
+	}
+
+	return cloneWebhookSubscription(r.subscriptions[i]), nil
+}
+
+// UpdateWebhookSubscription gives a subscription that is enabled again a clean failure count like the postgres query
+// does.
+func (r *WebhookRepository) UpdateWebhookSubscription(
+	_ context.Context,
+	id uuid.UUID,
+	update model.WebhookSubscriptionUpdate,
+) (model.WebhookSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.subscriptionIndex(id)
+	if i < 0 {
+		return model.WebhookSubscription{}, model.ErrNotFound
+	}
+
+	at := now()
+	subscription := &r.subscriptions[i]
+
+	if update.URL != nil {
+		subscription.URL = *update.URL
+	}
+
+	if update.EventTypes != nil {
+		subscription.EventTypes = slices.Clone(update.EventTypes)
+	}
+
+	if update.Active != nil {
+		subscription.Active = *update.Active
+
+		switch {
+		case *update.Active:
+			subscription.ConsecutiveFailures = 0
+			subscription.DisabledAt = nil
+		case subscription.DisabledAt == nil:
+			subscription.DisabledAt = &at
+		}
+	}
+
+	subscription.UpdatedAt = at
+
+	return cloneWebhookSubscription(*subscription), nil
+}
+
+func (r *WebhookRepository) DeleteWebhookSubscription(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.subscriptionIndex(id)
+	if i < 0 {
+		return model.ErrNotFound
+	}
+
+	r.subscriptions = slices.Delete(r.subscriptions, i, i+1)
+
+	r.deliveries = slices.DeleteFunc(r.deliveries, func(delivery model.WebhookDelivery) bool {
+		return delivery.SubscriptionID == id
+	})
+	r.attempts = slices.DeleteFunc(r.attempts, func(attempt model.WebhookDeliveryAttempt) bool {
+		return attempt.SubscriptionID == id
+	})
+
+	return nil
+}
+
+func (r *WebhookRepository) ListWebhookDeliveries(
+	_ context.Context,
+	subscriptionID uuid.UUID,
+	limit int,
+) ([]model.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deliveries := []model.WebhookDelivery{}
+	for _, delivery := range slices.Backward(r.deliveries) {
+		if len(deliveries) == limit {
+			break
+		}
+
+		if delivery.SubscriptionID == subscriptionID {
+			deliveries = append(deliveries, cloneWebhookDelivery(delivery))
+		}
+	}
+
+	return deliveries, nil
+}
+
+func (r *WebhookRepository) CreateWebhookDeliveries(
+	_ context.Context,
+	eventID, eventType string,
+	payload []byte,
+) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	at := now()
+	created := 0
+	for _, subscription := range r.subscriptions {
+		if !subscription.Active ||
+			(len(subscription.EventTypes) > 0 && !slices.Contains(subscription.EventTypes, eventType)) {
+			continue
+		}
+
+		if slices.ContainsFunc(r.deliveries, func(delivery model.WebhookDelivery) bool {
+			return delivery.SubscriptionID == subscription.ID && delivery.EventID == eventID
+		}) {
+			continue
+		}
+
+		r.deliveries = append(r.deliveries, model.WebhookDelivery{
+			ID:             uuid.New(),
+			SubscriptionID: subscription.ID,
+			EventID:        eventID,
+			EventType:      eventType,
+			Payload:        slices.Clone(payload),
+			Status:         model.WebhookDeliveryStatusPending,
+			NextAttemptAt:  at,
+			CreatedAt:      at,
+		})
+		created++
+	}
+
+	return created, nil
+}
+
+func (r *WebhookRepository) LeaseDueWebhookDeliveries(
+	_ context.Context,
+	limit int,
+	lease time.Duration,
+) ([]model.LeasedWebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	at := now()
+	due := []int{}
+	for i, delivery := range r.deliveries {
+		subscription := r.subscriptionIndex(delivery.SubscriptionID)
+		if delivery.Status == model.WebhookDeliveryStatusPending && !delivery.NextAttemptAt.After(at) &&
+			subscription >= 0 && r.subscriptions[subscription].Active {
+			due = append(due, i)
+		}
+	}
+
+	slices.SortStableFunc(due, func(a, b int) int {
+		return r.deliveries[a].NextAttemptAt.Compare(r.deliveries[b].NextAttemptAt)
+	})
+
+	leased := make([]model.LeasedWebhookDelivery, 0, min(len(due), limit))
+	for _, i := range due[:min(len(due), limit)] {
+		delivery := &r.deliveries[i]
+		delivery.NextAttemptAt = at.Add(lease)
+
+		subscription := r.subscriptions[r.subscriptionIndex(delivery.SubscriptionID)]
+		leased = append(leased, model.LeasedWebhookDelivery{
+			ID:             delivery.ID,
+			SubscriptionID: delivery.SubscriptionID,
+			EventType:      delivery.EventType,
+			Payload:        slices.Clone(delivery.Payload),
+			Attempts:       delivery.Attempts,
+			URL:            subscription.URL,
+			Secret:         subscription.Secret,
+		})
+	}
+
+	return leased, nil
+}
+
+// RecordWebhookDeliveryAttempt fails with model.ErrNotFound when the subscription was deleted meanwhile.
+func (r *WebhookRepository) RecordWebhookDeliveryAttempt(
+	_ context.Context,
+	attempt model.WebhookDeliveryAttempt,
+	policy model.WebhookRetryPolicy,
+) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deliveryIndex := slices.IndexFunc(r.deliveries, func(delivery model.WebhookDelivery) bool {
+		return delivery.ID == attempt.DeliveryID
+	})
+	subscriptionIndex := r.subscriptionIndex(attempt.SubscriptionID)
+	if deliveryIndex < 0 || subscriptionIndex < 0 {
+		return false, model.ErrNotFound
+	}
+
+	at := now()
+	delivery := &r.deliveries[deliveryIndex]
+	subscription := &r.subscriptions[subscriptionIndex]
+
+	r.attempts = append(r.attempts, attempt)
+
+	delivery.Attempts++
+	delivery.LastStatusCode = clonePtr(attempt.StatusCode)
+	delivery.LastError = clonePtr(attempt.Error)
+
+	if attempt.Error == nil {
+		delivery.Status = model.WebhookDeliveryStatusSucceeded
+		delivery.DeliveredAt = &at
+		subscription.ConsecutiveFailures = 0
+
+		return false, nil
+	}
+
+	if delivery.Attempts >= policy.MaxAttempts {
+		delivery.Status = model.WebhookDeliveryStatusFailed
+	}
+	delivery.NextAttemptAt = at.Add(policy.RetryIn)
+
+	subscription.ConsecutiveFailures++
+	subscription.UpdatedAt = at
+	if subscription.ConsecutiveFailures >= policy.DisableAfterFailures {
+		subscription.Active = false
+
+		if subscription.DisabledAt == nil {
+			subscription.DisabledAt = &at
+		}
+	}
+
+	return subscription.ConsecutiveFailures == policy.DisableAfterFailures, nil
+}
+
+func (r *WebhookRepository) subscriptionIndex(id uuid.UUID) int {
+	return slices.IndexFunc(r.subscriptions, func(subscription model.WebhookSubscription) bool {
+		return subscription.ID == id
+	})
+}
+
+// cloneWebhookSubscription copies what the subscription references, so callers cannot change the stored one.
+func cloneWebhookSubscription(subscription model.WebhookSubscription) model.WebhookSubscription {
+	subscription.EventTypes = slices.Clone(subscription.EventTypes)
+	subscription.DisabledAt = clonePtr(subscription.DisabledAt)
+
+	return subscription
+}
+
+func cloneWebhookDelivery(delivery model.WebhookDelivery) model.WebhookDelivery {
+	delivery.Payload = slices.Clone(delivery.Payload)
+	delivery.LastStatusCode = clonePtr(delivery.LastStatusCode)
+	delivery.LastError = clonePtr(delivery.LastError)
+	delivery.DeliveredAt = clonePtr(delivery.DeliveredAt)
+
+	return delivery
+}
+
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+
+	v := *p
+
+	return &v
+}
@@ -0,0 +1,220 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/leetm4n/orders-service/internal/model"
+)
+
+func TestCreateWebhookDeliveries(t *testing.T) {
+	type args struct {
+		eventTypes []string
+		active     bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want int
+	}{
+		{
+			name: "should deliver to a subscription of the event type",
+			args: args{eventTypes: []string{"order.created"}, active: true},
+			want: 1,
+		},
+		{
+			name: "should deliver to a subscription of every event type",
+			args: args{eventTypes: nil, active: true},
+			want: 1,
+		},
+		{
+			name: "should not deliver to a subscription of another event type",
+			args: args{eventTypes: []string{"order.canceled"}, active: true},
+			want: 0,
+		},
+		{
+			name: "should not deliver to a disabled subscription",
+			args: args{eventTypes: nil, active: false},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewWebhookRepository()
+			subscription, err := r.CreateWebhookSubscription(t.Context(), model.NewWebhookSubscription{
+				URL:        "https://hooks.example.com",
+				EventTypes: tt.args.eventTypes,
+				Secret:     "secret",
+			})
+			if err != nil {
+				t.Fatalf("CreateWebhookSubscription() error = %v", err)
+			}
+
+			if _, err := r.UpdateWebhookSubscription(t.Context(), subscription.ID, model.WebhookSubscriptionUpdate{
+				Active: &tt.args.active,
+			}); err != nil {
+				t.Fatalf("UpdateWebhookSubscription() error = %v", err)
+			}
+
+			// the second call finds the deliveries of the first one
+			for _, want := range []int{tt.want, 0} {
+				got, err := r.CreateWebhookDeliveries(t.Context(), "event-1", "order.created", []byte(`{}`))
+				if err != nil {
+					t.Fatalf("CreateWebhookDeliveries() error = %v", err)
+				}
+
+				if got != want {
+					t.Errorf("CreateWebhookDeliveries() = %d, want %d", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLeaseDueWebhookDeliveries(t *testing.T) {
+	type args struct {
+		lease time.Duration
+	}
+	tests := []struct {
+		name string
+		args args
+		want int
+	}{
+		{
+			name: "should not lease a delivery again before its lease ends",
+			args: args{lease: time.Hour},
+			want: 0,
+		},
+		{
+			name: "should lease a delivery again once its lease ended",
+			args: args{lease: 0},
+			want: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewWebhookRepository()
+			if _, err := r.CreateWebhookSubscription(t.Context(), model.NewWebhookSubscription{
+				URL:    "https://hooks.example.com",
+				Secret: "secret",
+			}); err != nil {
+				t.Fatalf("CreateWebhookSubscription() error = %v", err)
+			}
+
+			if _, err := r.CreateWebhookDeliveries(t.Context(), "event-1", "order.created", []byte(`{}`)); err != nil {
+				t.Fatalf("CreateWebhookDeliveries() error = %v", err)
+			}
+
+			leased, err := r.LeaseDueWebhookDeliveries(t.Context(), 10, tt.args.lease)
+			if err != nil {
+				t.Fatalf("LeaseDueWebhookDeliveries() error = %v", err)
+			}
+
+			if len(leased) != 1 || leased[0].URL != "https://hooks.example.com" || leased[0].Secret != "secret" {
+				t.Fatalf("LeaseDueWebhookDeliveries() = %+v, want the delivery with its endpoint", leased)
+			}
+
+			leased, err = r.LeaseDueWebhookDeliveries(t.Context(), 10, tt.args.lease)
+			if err != nil {
+				t.Fatalf("LeaseDueWebhookDeliveries() error = %v", err)
+			}
+
+			if len(leased) != tt.want {
+				t.Errorf("LeaseDueWebhookDeliveries() leased %d deliveries again, want %d", len(leased), tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordWebhookDeliveryAttempt(t *testing.T) {
+	statusCode := 503
+	errMessage := "unexpected status 503"
+	policy := model.WebhookRetryPolicy{MaxAttempts: 2, DisableAfterFailures: 3}
+
+	type args struct {
+		failures int
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantStatus   model.WebhookDeliveryStatus
+		wantDisabled bool
+	}{
+		{
+			name:       "should retry a failed delivery",
+			args:       args{failures: 1},
+			wantStatus: model.WebhookDeliveryStatusPending,
+		},
+		{
+			name:       "should give up once the delivery runs out of attempts",
+			args:       args{failures: 2},
+			wantStatus: model.WebhookDeliveryStatusFailed,
+		},
+		{
+			name:         "should disable the subscription after failures in a row",
+			args:         args{failures: 3},
+			wantStatus:   model.WebhookDeliveryStatusFailed,
+			wantDisabled: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewWebhookRepository()
+			subscription, err := r.CreateWebhookSubscription(t.Context(), model.NewWebhookSubscription{
+				URL:    "https://hooks.example.com",
+				Secret: "secret",
+			})
+			if err != nil {
+				t.Fatalf("CreateWebhookSubscription() error = %v", err)
+			}
+
+			if _, err := r.CreateWebhookDeliveries(t.Context(), "event-1", "order.created", []byte(`{}`)); err != nil {
+				t.Fatalf("CreateWebhookDeliveries() error = %v", err)
+			}
+
+			deliveries, err := r.ListWebhookDeliveries(t.Context(), subscription.ID, 1)
+			if err != nil {
+				t.Fatalf("ListWebhookDeliveries() error = %v", err)
+			}
+
+			disabled := false
+			for attempt := 1; attempt <= tt.args.failures; attempt++ {
+				disabled, err = r.RecordWebhookDeliveryAttempt(t.Context(), model.WebhookDeliveryAttempt{
+					DeliveryID:     deliveries[0].ID,
+					SubscriptionID: subscription.ID,
+					Attempt:        attempt,
+					StatusCode:     &statusCode,
+					Error:          &errMessage,
+				}, policy)
+				if err != nil {
+					t.Fatalf("RecordWebhookDeliveryAttempt() error = %v", err)
+				}
+			}
+
+			if disabled != tt.wantDisabled {
+				t.Errorf("RecordWebhookDeliveryAttempt() = %v, want %v", disabled, tt.wantDisabled)
+			}
+
+			deliveries, err = r.ListWebhookDeliveries(t.Context(), subscription.ID, 1)
+			if err != nil {
+				t.Fatalf("ListWebhookDeliveries() error = %v", err)
+			}
+
+			got := deliveries[0]
+			if got.Status != tt.wantStatus || got.Attempts != tt.args.failures || got.LastStatusCode == nil ||
+				*got.LastStatusCode != statusCode {
+				t.Errorf("ListWebhookDeliveries() = %+v, want status %s after %d attempts", got, tt.wantStatus,
+					tt.args.failures)
+			}
+
+			stored, err := r.GetWebhookSubscription(t.Context(), subscription.ID)
+			if err != nil {
+				t.Fatalf("GetWebhookSubscription() error = %v", err)
+			}
+
+			if stored.Active == tt.wantDisabled || (stored.DisabledAt != nil) != tt.wantDisabled {
+				t.Errorf("GetWebhookSubscription() = %+v, want disabled %v", stored, tt.wantDisabled)
+			}
+		})
+	}
+}
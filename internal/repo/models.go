@@ -11,6 +11,52 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type CancellationReason string
+
+const (
+	CancellationReasonCustomerRequest CancellationReason = "customer_request"
+	CancellationReasonOutOfStock      CancellationReason = "out_of_stock"
+	CancellationReasonPaymentFailed   CancellationReason = "payment_failed"
+	CancellationReasonFraudSuspected  CancellationReason = "fraud_suspected"
+	CancellationReasonDuplicateOrder  CancellationReason = "duplicate_order"
+	CancellationReasonOther           CancellationReason = "other"
+)
+
+func (e *CancellationReason) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = CancellationReason(s)
+	case string:
+		*e = CancellationReason(s)
+	default:
+		return fmt.Errorf("unsupported scan type for CancellationReason: %T", src)
+	}
+	return nil
+}
+
+type NullCancellationReason struct {
+	CancellationReason CancellationReason
+	Valid              bool // Valid is true if CancellationReason is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullCancellationReason) Scan(value interface{}) error {
+	if value == nil {
+		ns.CancellationReason, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.CancellationReason.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullCancellationReason) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.CancellationReason), nil
+}
+
 type OrderStatus string
 
 const (
@@ -55,13 +101,158 @@ func (ns NullOrderStatus) Value() (driver.Value, error) {
 	return string(ns.OrderStatus), nil
 }
 
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+)
+
+func (e *WebhookDeliveryStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = WebhookDeliveryStatus(s)
+	case string:
+		*e = WebhookDeliveryStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for WebhookDeliveryStatus: %T", src)
+	}
+	return nil
+}
+
+type NullWebhookDeliveryStatus struct {
+	WebhookDeliveryStatus WebhookDeliveryStatus
+	Valid                 bool // Valid is true if WebhookDeliveryStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullWebhookDeliveryStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.WebhookDeliveryStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.WebhookDeliveryStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullWebhookDeliveryStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.WebhookDeliveryStatus), nil
+}
+
+type DeadLetterEvent struct {
+	ID          int64
+	EventID     string
+	AggregateID pgtype.UUID
+	EventType   string
+	Payload     []byte
+	Error       string
+	Attempts    int32
+	Trace       []byte
+	CreatedAt   pgtype.Timestamp
+}
+
+type IdempotencyKey struct {
+	Key         string
+	RequestHash pgtype.Text
+	OrderID     pgtype.UUID
+	CreatedAt   pgtype.Timestamp
+	ExpiresAt   pgtype.Timestamp
+}
+
 type Order struct {
-	ID              pgtype.UUID
-	Quantity        int32
-	CreatedAt       pgtype.Timestamp
-	UpdatedAt       pgtype.Timestamp
-	Status          OrderStatus
-	IdempotencyKey  pgtype.UUID
-	ShippingAddress string
-	Sku             pgtype.UUID
+	ID                    pgtype.UUID
+	CreatedAt             pgtype.Timestamp
+	UpdatedAt             pgtype.Timestamp
+	Status                OrderStatus
+	LegacyShippingAddress pgtype.Text
+	Currency              string
+	TaxRate               pgtype.Numeric
+	Subtotal              pgtype.Numeric
+	Tax                   pgtype.Numeric
+	Total                 pgtype.Numeric
+	ShippingName          pgtype.Text
+	ShippingLines         []string
+	ShippingCity          pgtype.Text
+	ShippingRegion        pgtype.Text
+	ShippingPostalCode    pgtype.Text
+	ShippingCountry       pgtype.Text
+	CancellationReason    NullCancellationReason
+	CancellationNote      pgtype.Text
+	CanceledAt            pgtype.Timestamp
+	Version               int32
+}
+
+type OrderHistory struct {
+	ID        int64
+	OrderID   pgtype.UUID
+	Action    string
+	Actor     string
+	OldValues []byte
+	NewValues []byte
+	TraceID   pgtype.Text
+	CreatedAt pgtype.Timestamp
+	TxID      pgtype.Uint64
+}
+
+type OrderItem struct {
+	ID        pgtype.UUID
+	OrderID   pgtype.UUID
+	Position  int32
+	Sku       pgtype.UUID
+	Quantity  int32
+	UnitPrice pgtype.Numeric
+	CreatedAt pgtype.Timestamp
+}
+
+type Outbox struct {
+	ID           pgtype.UUID
+	AggregateID  pgtype.UUID
+	EventType    string
+	Payload      []byte
+	CreatedAt    pgtype.Timestamp
+	DispatchedAt pgtype.Timestamp
+	ReceivedAt   pgtype.Timestamp
+	LeasedUntil  pgtype.Timestamp
+}
+
+type WebhookDelivery struct {
+	ID             pgtype.UUID
+	SubscriptionID pgtype.UUID
+	EventID        string
+	EventType      string
+	Payload        []byte
+	Status         WebhookDeliveryStatus
+	Attempts       int32
+	NextAttemptAt  pgtype.Timestamp
+	LastStatusCode pgtype.Int4
+	LastError      pgtype.Text
+	DeliveredAt    pgtype.Timestamp
+	CreatedAt      pgtype.Timestamp
+}
+
+type WebhookDeliveryAttempt struct {
+	ID         int64
+	DeliveryID pgtype.UUID
+	Attempt    int32
+	StatusCode pgtype.Int4
+	Error      pgtype.Text
+	DurationMs int32
+	CreatedAt  pgtype.Timestamp
+}
+
+type WebhookSubscription struct {
+	ID                  pgtype.UUID
+	Url                 string
+	EventTypes          []string
+	Secret              string
+	Active              bool
+	ConsecutiveFailures int32
+	DisabledAt          pgtype.Timestamp
+	CreatedAt           pgtype.Timestamp
+	UpdatedAt           pgtype.Timestamp
 }
@@ -0,0 +1,524 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/leetm4n/orders-service/internal/domain"
+	"github.com/leetm4n/orders-service/internal/model"
+	"github.com/leetm4n/orders-service/pkg/money"
+)
+
+// PostgresOrderRepository implements model.OrderRepository and model.OutboxRepository with the generated queries.
+type PostgresOrderRepository struct {
+	// pool is nil for repositories bound to a transaction
+	pool    *pgxpool.Pool
+	queries *Queries
+}
+
+func NewPostgresOrderRepository(pool *pgxpool.Pool) *PostgresOrderRepository {
+	return &PostgresOrderRepository{
+		pool:    pool,
+		queries: New(pool),
+	}
+}
+
+func (r *PostgresOrderRepository) InTx(ctx context.Context, fn func(repo model.OrderRepository) error) error {
+	return r.inTx(ctx, func(txRepo *PostgresOrderRepository) error {
+		return fn(txRepo)
+	})
+}
+
+func (r *PostgresOrderRepository) inTx(ctx context.Context, fn func(txRepo *PostgresOrderRepository) error) error {
+	if r.pool == nil {
+		return fn(r)
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(context.WithoutCancel(ctx))
+	}()
+
+	if err := fn(&PostgresOrderRepository{queries: r.queries.WithTx(tx)}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresOrderRepository) GetOrder(ctx context.Context, id uuid.UUID) (model.Order, error) {
+	order, err := r.queries.GetOrderByID(ctx, toUUID(id))
+	if err != nil {
+		return model.Order{}, notFound(err)
+	}
+
+	return r.withItems(ctx, order)
+}
+
+func (r *PostgresOrderRepository) ListOrders(ctx context.Context, filter model.OrderFilter) ([]model.Order, error) {
+	params := ListOrdersAscParams{
+		Limit: int32(filter.Limit),
+	}
+
+	if filter.Status != nil {
+		params.Status = NullOrderStatus{OrderStatus: OrderStatus(*filter.Status), Valid: true}
+	}
+
+	if filter.Sku != nil {
+		params.Sku = toUUID(*filter.Sku)
+	}
+
+	if filter.CreatedFrom != nil {
+		params.CreatedFrom = pgtype.Timestamp{Time: filter.CreatedFrom.UTC(), Valid: true}
+	}
+
+	if filter.CreatedTo != nil {
+		params.CreatedTo = pgtype.Timestamp{Time: filter.CreatedTo.UTC(), Valid: true}
+	}
+
+	if filter.After != nil {
+		params.CursorCreatedAt = pgtype.Timestamp{Time: filter.After.CreatedAt.UTC(), Valid: true}
+		params.CursorID = toUUID(filter.After.ID)
+	}
+
+	var (
+		orders []Order
+		err    error
+	)
+	if filter.Ascending {
+		orders, err = r.queries.ListOrdersAsc(ctx, params)
+	} else {
+		orders, err = r.queries.ListOrdersDesc(ctx, ListOrdersDescParams(params))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	orderIDs := make([]pgtype.UUID, 0, len(orders))
+	for _, order := range orders {
+		orderIDs = append(orderIDs, order.ID)
+	}
+
+	items, err := r.getOrderItems(ctx, orderIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	page := make([]model.Order, 0, len(orders))
+	for _, order := range orders {
+		page = append(page, toModelOrder(order, items[order.ID.Bytes]))
+	}
+
+	return page, nil
+}
+
+func (r *PostgresOrderRepository) CreateOrder(ctx context.Context, newOrder model.NewOrder) (model.Order, error) {
+	address := newOrder.ShippingAddress
+
+	order, err := r.queries.CreateOrder(ctx, CreateOrderParams{
+		Currency:           newOrder.Currency,
+		TaxRate:            toNumeric(newOrder.TaxRate),
+		Subtotal:           toNumeric(newOrder.Subtotal),
+		Tax:                toNumeric(newOrder.Tax),
+		Total:              toNumeric(newOrder.Total),
+		ShippingName:       pgtype.Text{String: address.Name, Valid: true},
+		ShippingLines:      address.Lines,
+		ShippingCity:       pgtype.Text{String: address.City, Valid: true},
+		ShippingRegion:     pgtype.Text{String: address.Region, Valid: address.Region != ""},
+		ShippingPostalCode: pgtype.Text{String: address.PostalCode, Valid: true},
+		ShippingCountry:    pgtype.Text{String: address.Country, Valid: true},
+	})
+	if err != nil {
+		return model.Order{}, err
+	}
+
+	itemsParams := CreateOrderItemsParams{OrderID: order.ID}
+	for i, item := range newOrder.Items {
+		itemsParams.Positions = append(itemsParams.Positions, int32(i))
+		itemsParams.Skus = append(itemsParams.Skus, toUUID(item.Sku))
+		itemsParams.Quantities = append(itemsParams.Quantities, int32(item.Quantity))
+		itemsParams.UnitPrices = append(itemsParams.UnitPrices, toNumeric(item.UnitPrice))
+	}
+
+	items, err := r.queries.CreateOrderItems(ctx, itemsParams)
+	if err != nil {
+		return model.Order{}, fmt.Errorf("create order items: %w", err)
+	}
+
+	return toModelOrder(order, items), nil
+}
+
+func (r *PostgresOrderRepository) UpdateOrderStatus(
+	ctx context.Context,
+	order model.Order,
+	status domain.OrderStatus,
+) (model.Order, error) {
+	updatedOrder, err := r.queries.UpdateOrderStatus(ctx, UpdateOrderStatusParams{
+		ID:            toUUID(order.ID),
+		Status:        OrderStatus(status),
+		CurrentStatus: OrderStatus(order.Status),
+		Version:       order.Version,
+	})
+	if err != nil {
+		return model.Order{}, conflict(err)
+	}
+
+	return r.withItems(ctx, updatedOrder)
+}
+
+func (r *PostgresOrderRepository) CancelOrder(
+	ctx context.Context,
+	order model.Order,
+	reason string,
+	note *string,
+) (model.Order, error) {
+	params := CancelOrderParams{
+		ID:            toUUID(order.ID),
+		CurrentStatus: OrderStatus(order.Status),
+		Version:       order.Version,
+		Reason:        NullCancellationReason{CancellationReason: CancellationReason(reason), Valid: true},
+	}
+
+	if note != nil {
+		params.Note = pgtype.Text{String: *note, Valid: true}
+	}
+
+	canceledOrder, err := r.queries.CancelOrder(ctx, params)
+	if err != nil {
+		return model.Order{}, conflict(err)
+	}
+
+	return r.withItems(ctx, canceledOrder)
+}
+
+func (r *PostgresOrderRepository) ClaimIdempotencyKey(
+	ctx context.Context,
+	key string,
+	requestHash string,
+	ttl time.Duration,
+) error {
+	_, err := r.queries.ClaimIdempotencyKey(ctx, ClaimIdempotencyKeyParams{
+		Key:         key,
+		RequestHash: pgtype.Text{String: requestHash, Valid: true},
+		TtlSeconds:  int32(ttl.Seconds()),
+	})
+
+	return conflict(err)
+}
+
+func (r *PostgresOrderRepository) GetIdempotencyKey(ctx context.Context, key string) (model.IdempotencyKey, error) {
+	idempotencyKey, err := r.queries.GetIdempotencyKey(ctx, key)
+	if err != nil {
+		return model.IdempotencyKey{}, notFound(err)
+	}
+
+	result := model.IdempotencyKey{
+		Key:         idempotencyKey.Key,
+		RequestHash: idempotencyKey.RequestHash.String,
+	}
+
+	if idempotencyKey.OrderID.Valid {
+		orderID := uuid.UUID(idempotencyKey.OrderID.Bytes)
+		result.OrderID = &orderID
+	}
+
+	return result, nil
+}
+
+func (r *PostgresOrderRepository) SetIdempotencyKeyOrder(ctx context.Context, key string, orderID uuid.UUID) error {
+	return r.queries.SetIdempotencyKeyOrder(ctx, SetIdempotencyKeyOrderParams{
+		Key:     key,
+		OrderID: toUUID(orderID),
+	})
+}
+
+func (r *PostgresOrderRepository) CreateHistoryEntry(ctx context.Context, entry model.HistoryEntry) error {
+	params := CreateOrderHistoryEntryParams{
+		OrderID:   toUUID(entry.OrderID),
+		Action:    entry.Action,
+		Actor:     entry.Actor,
+		OldValues: entry.OldValues,
+		NewValues: entry.NewValues,
+	}
+
+	if entry.TraceID != nil {
+		params.TraceID = pgtype.Text{String: *entry.TraceID, Valid: true}
+	}
+
+	_, err := r.queries.CreateOrderHistoryEntry(ctx, params)
+
+	return err
+}
+
+func (r *PostgresOrderRepository) ListOrderHistory(ctx context.Context, orderID uuid.UUID) ([]model.HistoryEntry, error) {
+	entries, err := r.queries.ListOrderHistory(ctx, toUUID(orderID))
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelHistoryEntries(entries), nil
+}
+
+func (r *PostgresOrderRepository) ListOrderHistoryAfter(
+	ctx context.Context,
+	afterID int64,
+	orderID *uuid.UUID,
+	limit int,
+) ([]model.HistoryEntry, error) {
+	params := ListOrderHistoryAfterParams{
+		AfterID: afterID,
+		Limit:   int32(limit),
+	}
+
+	if orderID != nil {
+		params.OrderID = toUUID(*orderID)
+	}
+
+	entries, err := r.queries.ListOrderHistoryAfter(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelHistoryEntries(entries), nil
+}
+
+func (r *PostgresOrderRepository) GetLastOrderHistoryID(ctx context.Context) (int64, error) {
+	return r.queries.GetLastOrderHistoryID(ctx)
+}
+
+func (r *PostgresOrderRepository) CreateOutboxEvent(ctx context.Context, event model.OutboxEvent) error {
+	_, err := r.queries.CreateOutboxEvent(ctx, CreateOutboxEventParams{
+		ID:          toUUID(event.ID),
+		AggregateID: toUUID(event.AggregateID),
+		EventType:   event.EventType,
+		Payload:     event.Payload,
+	})
+
+	return err
+}
+
+func (r *PostgresOrderRepository) ReceiveOutboxEvent(ctx context.Context, id uuid.UUID) (model.OutboxEvent, bool, error) {
+	event, err := r.queries.ReceiveOutboxEvent(ctx, toUUID(id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.OutboxEvent{}, false, nil
+		}
+
+		return model.OutboxEvent{}, false, err
+	}
+
+	return model.OutboxEvent{
+		ID:          event.ID.Bytes,
+		AggregateID: event.AggregateID.Bytes,
+		EventType:   event.EventType,
+		Payload:     event.Payload,
+	}, true, nil
+}
+
+func (r *PostgresOrderRepository) ListUnreceivedOutboxEventIDs(ctx context.Context, limit int) ([]uuid.UUID, error) {
+	ids, err := r.queries.ListUnreceivedOutboxEventIDs(ctx, int32(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, id.Bytes)
+	}
+
+	return result, nil
+}
+
+// LeasePendingOutboxEvents leases the events in a single statement with FOR UPDATE SKIP LOCKED, so multiple relays
+// can run in parallel without holding locks while they publish.
+func (r *PostgresOrderRepository) LeasePendingOutboxEvents(
+	ctx context.Context,
+	limit int,
+	lease time.Duration,
+) ([]model.OutboxEvent, error) {
+	events, err := r.queries.LeasePendingOutboxEvents(ctx, LeasePendingOutboxEventsParams{
+		BatchSize:    int32(limit),
+		LeaseSeconds: lease.Seconds(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the rows an update returns are not ordered
+	slices.SortFunc(events, func(a, b Outbox) int {
+		return a.CreatedAt.Time.Compare(b.CreatedAt.Time)
+	})
+
+	result := make([]model.OutboxEvent, 0, len(events))
+	for _, event := range events {
+		result = append(result, model.OutboxEvent{
+			ID:          event.ID.Bytes,
+			AggregateID: event.AggregateID.Bytes,
+			EventType:   event.EventType,
+			Payload:     event.Payload,
+		})
+	}
+
+	return result, nil
+}
+
+func (r *PostgresOrderRepository) MarkOutboxEventDispatched(ctx context.Context, id uuid.UUID) error {
+	return r.queries.MarkOutboxEventDispatched(ctx, toUUID(id))
+}
+
+func (r *PostgresOrderRepository) RequeueOutboxEvent(ctx context.Context, id uuid.UUID) (bool, error) {
+	requeued, err := r.queries.RequeueOutboxEvent(ctx, toUUID(id))
+	if err != nil {
+		return false, err
+	}
+
+	return requeued > 0, nil
+}
+
+func (r *PostgresOrderRepository) withItems(ctx context.Context, order Order) (model.Order, error) {
+	items, err := r.getOrderItems(ctx, order.ID)
+	if err != nil {
+		return model.Order{}, err
+	}
+
+	return toModelOrder(order, items[order.ID.Bytes]), nil
+}
+
+// getOrderItems loads the line items of the given orders keyed by order id.
+func (r *PostgresOrderRepository) getOrderItems(ctx context.Context, orderIDs ...pgtype.UUID) (map[[16]byte][]OrderItem, error) {
+	items, err := r.queries.ListOrderItemsByOrderIDs(ctx, orderIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get order items: %w", err)
+	}
+
+	itemsByOrderID := make(map[[16]byte][]OrderItem, len(orderIDs))
+	for _, item := range items {
+		itemsByOrderID[item.OrderID.Bytes] = append(itemsByOrderID[item.OrderID.Bytes], item)
+	}
+
+	return itemsByOrderID, nil
+}
+
+func toModelOrder(order Order, items []OrderItem) model.Order {
+	// orders created before prices were recorded fall back to the scale amounts are stored with
+	scale, err := money.MinorUnits(order.Currency)
+	if err != nil {
+		scale = money.Scale(fromNumeric(order.Total))
+	}
+
+	modelItems := make([]model.OrderItem, 0, len(items))
+	for _, item := range items {
+		modelItems = append(modelItems, model.OrderItem{
+			Sku:       item.Sku.Bytes,
+			Quantity:  int(item.Quantity),
+			UnitPrice: money.FormatAmount(fromNumeric(item.UnitPrice), scale),
+		})
+	}
+
+	modelOrder := model.Order{
+		ID:                    order.ID.Bytes,
+		Items:                 modelItems,
+		Currency:              order.Currency,
+		Subtotal:              money.FormatAmount(fromNumeric(order.Subtotal), scale),
+		Tax:                   money.FormatAmount(fromNumeric(order.Tax), scale),
+		Total:                 money.FormatAmount(fromNumeric(order.Total), scale),
+		CreatedAt:             order.CreatedAt.Time,
+		UpdatedAt:             order.UpdatedAt.Time,
+		Status:                domain.OrderStatus(order.Status),
+		LegacyShippingAddress: order.LegacyShippingAddress.String,
+		Version:               order.Version,
+	}
+
+	// orders created before structured addresses were introduced only have the legacy address
+	if order.ShippingName.Valid {
+		modelOrder.ShippingAddress = &model.Address{
+			Name:       order.ShippingName.String,
+			Lines:      order.ShippingLines,
+			City:       order.ShippingCity.String,
+			Region:     order.ShippingRegion.String,
+			PostalCode: order.ShippingPostalCode.String,
+			Country:    order.ShippingCountry.String,
+		}
+	}
+
+	if order.CancellationReason.Valid {
+		modelOrder.Cancellation = &model.Cancellation{
+			Reason:     string(order.CancellationReason.CancellationReason),
+			Note:       order.CancellationNote.String,
+			CanceledAt: order.CanceledAt.Time,
+		}
+	}
+
+	return modelOrder
+}
+
+func toModelHistoryEntries(entries []OrderHistory) []model.HistoryEntry {
+	modelEntries := make([]model.HistoryEntry, 0, len(entries))
+	for _, entry := range entries {
+		modelEntry := model.HistoryEntry{
+			ID:        entry.ID,
+			OrderID:   entry.OrderID.Bytes,
+			Action:    entry.Action,
+			Actor:     entry.Actor,
+			OldValues: entry.OldValues,
+			NewValues: entry.NewValues,
+			CreatedAt: entry.CreatedAt.Time,
+		}
+
+		if entry.TraceID.Valid {
+			modelEntry.TraceID = &entry.TraceID.String
+		}
+
+		modelEntries = append(modelEntries, modelEntry)
+	}
+
+	return modelEntries
+}
+
+func toNumeric(amount money.Amount) pgtype.Numeric {
+	return pgtype.Numeric{Int: amount.Unscaled(), Exp: amount.Exp(), Valid: true}
+}
+
+// fromNumeric reads NaN, infinity and null as zero, amount columns hold none of them.
+func fromNumeric(n pgtype.Numeric) money.Amount {
+	if !n.Valid || n.NaN || n.InfinityModifier != pgtype.Finite {
+		return money.Amount{}
+	}
+
+	return money.NewAmount(n.Int, n.Exp)
+}
+
+func toUUID(id uuid.UUID) pgtype.UUID {
+	return pgtype.UUID{Bytes: id, Valid: true}
+}
+
+func notFound(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.ErrNotFound
+	}
+
+	return err
+}
+
+// conflict maps the missing row of a conditional write to model.ErrConflict.
+func conflict(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.ErrConflict
+	}
+
+	return err
+}
@@ -3,8 +3,260 @@ select * from orders
 where id = $1;
 
 -- name: CreateOrder :one
-insert into orders (quantity, shipping_address, sku, idempotency_key) values ($1, $2, $3, $4) returning *;
+insert into orders (
+    currency, tax_rate, subtotal, tax, total,
+    shipping_name, shipping_lines, shipping_city, shipping_region, shipping_postal_code, shipping_country
+) values (
+    @currency, @tax_rate, @subtotal, @tax, @total,
+    @shipping_name, @shipping_lines, @shipping_city, @shipping_region, @shipping_postal_code, @shipping_country
+) returning *;
 
--- name: GetOrderByIdempotencyKey :one
+-- name: CreateOrderItems :many
+insert into order_items (order_id, position, sku, quantity, unit_price)
+select @order_id, unnest(@positions::int[]), unnest(@skus::uuid[]), unnest(@quantities::int[]), unnest(@unit_prices::numeric[])
+returning *;
+
+-- name: ListOrderItemsByOrderIDs :many
+select * from order_items
+where order_id = any(@order_ids::uuid[])
+order by order_id, position;
+
+-- name: ClaimIdempotencyKey :one
+-- an expired key is taken over by the new request, a live one returns no rows
+insert into idempotency_keys (key, request_hash, expires_at)
+values (@key, @request_hash, now() + make_interval(secs => @ttl_seconds::int))
+on conflict (key) do update
+set request_hash = excluded.request_hash, order_id = null, created_at = now(), expires_at = excluded.expires_at
+where idempotency_keys.expires_at <= now()
+returning *;
+
+-- name: GetIdempotencyKey :one
+select * from idempotency_keys
+where key = $1;
+
+-- name: SetIdempotencyKeyOrder :exec
+update idempotency_keys set order_id = @order_id
+where key = @key;
+
+-- name: UpdateOrderStatus :one
+update orders set status = @status, updated_at = now(), version = version + 1
+where id = @id and status = @current_status and version = @version
+returning *;
+
+-- name: CreateOutboxEvent :one
+insert into outbox (id, aggregate_id, event_type, payload) values ($1, $2, $3, $4) returning *;
+
+-- name: LeasePendingOutboxEvents :many
+-- leased events are not due again before the lease ends, a relay stopping while it publishes them leaves them to be
+-- published again afterwards
+with due as (
+    select id
+    from outbox
+    where dispatched_at is null and (leased_until is null or leased_until <= now())
+    order by created_at
+    limit @batch_size
+    for update skip locked
+)
+update outbox o set leased_until = now() + make_interval(secs => @lease_seconds::float8)
+from due
+where o.id = due.id
+returning o.*;
+
+-- name: MarkOutboxEventDispatched :exec
+update outbox set dispatched_at = now(), leased_until = null
+where id = $1;
+
+-- name: RequeueOutboxEvent :execrows
+update outbox set dispatched_at = null, received_at = null, leased_until = null
+where id = $1 and (dispatched_at is not null or received_at is not null);
+
+-- name: ReceiveOutboxEvent :one
+update outbox set received_at = now()
+where id = $1 and received_at is null
+returning *;
+
+-- name: ListUnreceivedOutboxEventIDs :many
+select id from outbox
+where received_at is null
+order by created_at
+limit $1;
+
+-- name: ListOrdersAsc :many
+select * from orders
+where (sqlc.narg('status')::order_status is null or status = sqlc.narg('status'))
+  and (sqlc.narg('sku')::uuid is null
+    or exists (select 1 from order_items oi where oi.order_id = orders.id and oi.sku = sqlc.narg('sku')))
+  and (sqlc.narg('created_from')::timestamp is null or created_at >= sqlc.narg('created_from'))
+  and (sqlc.narg('created_to')::timestamp is null or created_at < sqlc.narg('created_to'))
+  and (sqlc.narg('cursor_created_at')::timestamp is null
+    or (created_at, id) > (sqlc.narg('cursor_created_at'), sqlc.narg('cursor_id')::uuid))
+order by created_at asc, id asc
+limit sqlc.arg('limit');
+
+-- name: ListOrdersDesc :many
 select * from orders
-where idempotency_key = $1;
+where (sqlc.narg('status')::order_status is null or status = sqlc.narg('status'))
+  and (sqlc.narg('sku')::uuid is null
+    or exists (select 1 from order_items oi where oi.order_id = orders.id and oi.sku = sqlc.narg('sku')))
+  and (sqlc.narg('created_from')::timestamp is null or created_at >= sqlc.narg('created_from'))
+  and (sqlc.narg('created_to')::timestamp is null or created_at < sqlc.narg('created_to'))
+  and (sqlc.narg('cursor_created_at')::timestamp is null
+    or (created_at, id) < (sqlc.narg('cursor_created_at'), sqlc.narg('cursor_id')::uuid))
+order by created_at desc, id desc
+limit sqlc.arg('limit');
+
+-- name: CancelOrder :one
+update orders set
+    status = 'canceled',
+    cancellation_reason = @reason,
+    cancellation_note = @note,
+    canceled_at = now(),
+    updated_at = now(),
+    version = version + 1
+where id = @id and status = @current_status and version = @version
+returning *;
+
+-- name: CreateOrderHistoryEntry :one
+insert into order_history (order_id, action, actor, old_values, new_values, trace_id)
+values ($1, $2, $3, $4, $5, $6) returning *;
+
+-- name: ListOrderHistory :many
+select * from order_history
+where order_id = $1
+order by id;
+
+-- name: ListOrderHistoryAfter :many
+select * from order_history
+where tx_id < pg_snapshot_xmin(pg_current_snapshot())
+  and (tx_id, id) > (
+    coalesce(
+      (select h.tx_id from order_history h where h.id <= sqlc.arg('after_id') order by h.id desc limit 1),
+      '0'::xid8
+    ),
+    sqlc.arg('after_id')
+  )
+  and (sqlc.narg('order_id')::uuid is null or order_id = sqlc.narg('order_id'))
+order by tx_id, id
+limit sqlc.arg('limit');
+
+-- name: GetLastOrderHistoryID :one
+select coalesce(
+  (select id from order_history where tx_id < pg_snapshot_xmin(pg_current_snapshot()) order by tx_id desc, id desc limit 1),
+  0
+)::bigint;
+
+-- name: CreateDeadLetterEvent :one
+insert into dead_letter_events (event_id, aggregate_id, event_type, payload, error, attempts, trace)
+values (@event_id, @aggregate_id, @event_type, @payload, @error, @attempts, @trace)
+returning *;
+
+-- name: ListDeadLetterEvents :many
+select * from dead_letter_events
+where (sqlc.narg('event_type')::text is null or event_type = sqlc.narg('event_type'))
+  and (sqlc.narg('cursor_id')::bigint is null or id < sqlc.narg('cursor_id'))
+order by id desc
+limit sqlc.arg('limit');
+
+-- name: GetDeadLetterEvent :one
+select * from dead_letter_events
+where id = $1;
+
+-- name: DeleteDeadLetterEvent :one
+delete from dead_letter_events
+where id = $1
+returning *;
+
+-- name: CreateWebhookSubscription :one
+insert into webhook_subscriptions (url, event_types, secret)
+values (@url, @event_types, @secret)
+returning *;
+
+-- name: ListWebhookSubscriptions :many
+select * from webhook_subscriptions
+order by created_at, id;
+
+-- name: GetWebhookSubscription :one
+select * from webhook_subscriptions
+where id = $1;
+
+-- name: UpdateWebhookSubscription :one
+-- enabling a subscription again gives it a clean failure count
+update webhook_subscriptions set
+    url = coalesce(sqlc.narg('url'), url),
+    event_types = coalesce(sqlc.narg('event_types')::text[], event_types),
+    active = coalesce(sqlc.narg('active'), active),
+    consecutive_failures = case when sqlc.narg('active')::boolean then 0 else consecutive_failures end,
+    disabled_at = case
+        when sqlc.narg('active')::boolean then null
+        when not sqlc.narg('active')::boolean then coalesce(disabled_at, now())
+        else disabled_at
+    end,
+    updated_at = now()
+where id = sqlc.arg('id')
+returning *;
+
+-- name: DeleteWebhookSubscription :execrows
+delete from webhook_subscriptions
+where id = $1;
+
+-- name: CreateWebhookDeliveries :execrows
+insert into webhook_deliveries (subscription_id, event_id, event_type, payload)
+select id, @event_id, @event_type, @payload
+from webhook_subscriptions
+where active and (cardinality(event_types) = 0 or @event_type::text = any(event_types))
+on conflict (subscription_id, event_id) do nothing;
+
+-- name: LeaseDueWebhookDeliveries :many
+-- leased deliveries are not due again before the lease ends, a dispatcher stopping while it posts them leaves them to
+-- be retried afterwards
+with due as (
+    select d.id
+    from webhook_deliveries d
+    join webhook_subscriptions s on s.id = d.subscription_id
+    where d.status = 'pending' and d.next_attempt_at <= now() and s.active
+    order by d.next_attempt_at
+    limit @batch_size
+    for update of d skip locked
+)
+update webhook_deliveries d set next_attempt_at = now() + make_interval(secs => @lease_seconds::float8)
+from due, webhook_subscriptions s
+where d.id = due.id and s.id = d.subscription_id
+returning d.id, d.event_type, d.payload, d.attempts, d.subscription_id, s.url, s.secret;
+
+-- name: CreateWebhookDeliveryAttempt :exec
+insert into webhook_delivery_attempts (delivery_id, attempt, status_code, error, duration_ms)
+values (@delivery_id, @attempt, @status_code, @error, @duration_ms);
+
+-- name: MarkWebhookDeliverySucceeded :exec
+update webhook_deliveries set
+    status = 'succeeded', attempts = attempts + 1, last_status_code = @status_code, last_error = null, delivered_at = now()
+where id = @id;
+
+-- name: MarkWebhookDeliveryFailed :exec
+-- the delivery stays pending until it runs out of attempts
+update webhook_deliveries set
+    status = case when attempts + 1 >= @max_attempts::int then 'failed'::webhook_delivery_status else 'pending' end,
+    attempts = attempts + 1,
+    last_status_code = @status_code,
+    last_error = @error,
+    next_attempt_at = now() + make_interval(secs => @retry_in_seconds::float8)
+where id = @id;
+
+-- name: ResetWebhookSubscriptionFailures :exec
+update webhook_subscriptions set consecutive_failures = 0
+where id = $1 and consecutive_failures > 0;
+
+-- name: RecordWebhookSubscriptionFailure :one
+update webhook_subscriptions set
+    consecutive_failures = consecutive_failures + 1,
+    active = active and consecutive_failures + 1 < @disable_after::int,
+    disabled_at = case when consecutive_failures + 1 >= @disable_after::int then coalesce(disabled_at, now()) else disabled_at end,
+    updated_at = now()
+where id = @id
+returning *;
+
+-- name: ListWebhookDeliveries :many
+select * from webhook_deliveries
+where subscription_id = $1
+order by created_at desc, id desc
+limit $2;
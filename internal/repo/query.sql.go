@@ -11,40 +11,479 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const cancelOrder = `-- name: CancelOrder :one
+update orders set
+    status = 'canceled',
+    cancellation_reason = $1,
+    cancellation_note = $2,
+    canceled_at = now(),
+    updated_at = now(),
+    version = version + 1
+where id = $3 and status = $4 and version = $5
+returning id, created_at, updated_at, status, legacy_shipping_address, currency, tax_rate, subtotal, tax, total, shipping_name, shipping_lines, shipping_city, shipping_region, shipping_postal_code, shipping_country, cancellation_reason, cancellation_note, canceled_at, version
+`
+
+type CancelOrderParams struct {
+	Reason        NullCancellationReason
+	Note          pgtype.Text
+	ID            pgtype.UUID
+	CurrentStatus OrderStatus
+	Version       int32
+}
+
+func (q *Queries) CancelOrder(ctx context.Context, arg CancelOrderParams) (Order, error) {
+	row := q.db.QueryRow(ctx, cancelOrder,
+		arg.Reason,
+		arg.Note,
+		arg.ID,
+		arg.CurrentStatus,
+		arg.Version,
+	)
+	var i Order
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.LegacyShippingAddress,
+		&i.Currency,
+		&i.TaxRate,
+		&i.Subtotal,
+		&i.Tax,
+		&i.Total,
+		&i.ShippingName,
+		&i.ShippingLines,
+		&i.ShippingCity,
+		&i.ShippingRegion,
+		&i.ShippingPostalCode,
+		&i.ShippingCountry,
+		&i.CancellationReason,
+		&i.CancellationNote,
+		&i.CanceledAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const claimIdempotencyKey = `-- name: ClaimIdempotencyKey :one
+insert into idempotency_keys (key, request_hash, expires_at)
+values ($1, $2, now() + make_interval(secs => $3::int))
+on conflict (key) do update
+set request_hash = excluded.request_hash, order_id = null, created_at = now(), expires_at = excluded.expires_at
+where idempotency_keys.expires_at <= now()
+returning key, request_hash, order_id, created_at, expires_at
+`
+
+type ClaimIdempotencyKeyParams struct {
+	Key         string
+	RequestHash pgtype.Text
+	TtlSeconds  int32
+}
+
+// an expired key is taken over by the new request, a live one returns no rows
+func (q *Queries) ClaimIdempotencyKey(ctx context.Context, arg ClaimIdempotencyKeyParams) (IdempotencyKey, error) {
+	row := q.db.QueryRow(ctx, claimIdempotencyKey, arg.Key, arg.RequestHash, arg.TtlSeconds)
+	var i IdempotencyKey
+	err := row.Scan(
+		&i.Key,
+		&i.RequestHash,
+		&i.OrderID,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const createDeadLetterEvent = `-- name: CreateDeadLetterEvent :one
+insert into dead_letter_events (event_id, aggregate_id, event_type, payload, error, attempts, trace)
+values ($1, $2, $3, $4, $5, $6, $7)
+returning id, event_id, aggregate_id, event_type, payload, error, attempts, trace, created_at
+`
+
+type CreateDeadLetterEventParams struct {
+	EventID     string
+	AggregateID pgtype.UUID
+	EventType   string
+	Payload     []byte
+	Error       string
+	Attempts    int32
+	Trace       []byte
+}
+
+func (q *Queries) CreateDeadLetterEvent(ctx context.Context, arg CreateDeadLetterEventParams) (DeadLetterEvent, error) {
+	row := q.db.QueryRow(ctx, createDeadLetterEvent,
+		arg.EventID,
+		arg.AggregateID,
+		arg.EventType,
+		arg.Payload,
+		arg.Error,
+		arg.Attempts,
+		arg.Trace,
+	)
+	var i DeadLetterEvent
+	err := row.Scan(
+		&i.ID,
+		&i.EventID,
+		&i.AggregateID,
+		&i.EventType,
+		&i.Payload,
+		&i.Error,
+		&i.Attempts,
+		&i.Trace,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const createOrder = `-- name: CreateOrder :one
-insert into orders (quantity, shipping_address, sku, idempotency_key) values ($1, $2, $3, $4) returning id, quantity, created_at, updated_at, status, idempotency_key, shipping_address, sku
+insert into orders (
+    currency, tax_rate, subtotal, tax, total,
+    shipping_name, shipping_lines, shipping_city, shipping_region, shipping_postal_code, shipping_country
+) values (
+    $1, $2, $3, $4, $5,
+    $6, $7, $8, $9, $10, $11
+) returning id, created_at, updated_at, status, legacy_shipping_address, currency, tax_rate, subtotal, tax, total, shipping_name, shipping_lines, shipping_city, shipping_region, shipping_postal_code, shipping_country, cancellation_reason, cancellation_note, canceled_at, version
 `
 
 type CreateOrderParams struct {
-	Quantity        int32
-	ShippingAddress string
-	Sku             pgtype.UUID
-	IdempotencyKey  pgtype.UUID
+	Currency           string
+	TaxRate            pgtype.Numeric
+	Subtotal           pgtype.Numeric
+	Tax                pgtype.Numeric
+	Total              pgtype.Numeric
+	ShippingName       pgtype.Text
+	ShippingLines      []string
+	ShippingCity       pgtype.Text
+	ShippingRegion     pgtype.Text
+	ShippingPostalCode pgtype.Text
+	ShippingCountry    pgtype.Text
 }
 
 func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) (Order, error) {
 	row := q.db.QueryRow(ctx, createOrder,
-		arg.Quantity,
-		arg.ShippingAddress,
-		arg.Sku,
-		arg.IdempotencyKey,
+		arg.Currency,
+		arg.TaxRate,
+		arg.Subtotal,
+		arg.Tax,
+		arg.Total,
+		arg.ShippingName,
+		arg.ShippingLines,
+		arg.ShippingCity,
+		arg.ShippingRegion,
+		arg.ShippingPostalCode,
+		arg.ShippingCountry,
 	)
 	var i Order
 	err := row.Scan(
 		&i.ID,
-		&i.Quantity,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Status,
-		&i.IdempotencyKey,
-		&i.ShippingAddress,
-		&i.Sku,
+		&i.LegacyShippingAddress,
+		&i.Currency,
+		&i.TaxRate,
+		&i.Subtotal,
+		&i.Tax,
+		&i.Total,
+		&i.ShippingName,
+		&i.ShippingLines,
+		&i.ShippingCity,
+		&i.ShippingRegion,
+		&i.ShippingPostalCode,
+		&i.ShippingCountry,
+		&i.CancellationReason,
+		&i.CancellationNote,
+		&i.CanceledAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const createOrderHistoryEntry = `-- name: CreateOrderHistoryEntry :one
+insert into order_history (order_id, action, actor, old_values, new_values, trace_id)
+values ($1, $2, $3, $4, $5, $6) returning id, order_id, action, actor, old_values, new_values, trace_id, created_at, tx_id
+`
+
+type CreateOrderHistoryEntryParams struct {
+	OrderID   pgtype.UUID
+	Action    string
+	Actor     string
+	OldValues []byte
+	NewValues []byte
+	TraceID   pgtype.Text
+}
+
+func (q *Queries) CreateOrderHistoryEntry(ctx context.Context, arg CreateOrderHistoryEntryParams) (OrderHistory, error) {
+	row := q.db.QueryRow(ctx, createOrderHistoryEntry,
+		arg.OrderID,
+		arg.Action,
+		arg.Actor,
+		arg.OldValues,
+		arg.NewValues,
+		arg.TraceID,
+	)
+	var i OrderHistory
+	err := row.Scan(
+		&i.ID,
+		&i.OrderID,
+		&i.Action,
+		&i.Actor,
+		&i.OldValues,
+		&i.NewValues,
+		&i.TraceID,
+		&i.CreatedAt,
+		&i.TxID,
+	)
+	return i, err
+}
+
+const createOrderItems = `-- name: CreateOrderItems :many
+insert into order_items (order_id, position, sku, quantity, unit_price)
+select $1, unnest($2::int[]), unnest($3::uuid[]), unnest($4::int[]), unnest($5::numeric[])
+returning id, order_id, position, sku, quantity, unit_price, created_at
+`
+
+type CreateOrderItemsParams struct {
+	OrderID    pgtype.UUID
+	Positions  []int32
+	Skus       []pgtype.UUID
+	Quantities []int32
+	UnitPrices []pgtype.Numeric
+}
+
+func (q *Queries) CreateOrderItems(ctx context.Context, arg CreateOrderItemsParams) ([]OrderItem, error) {
+	rows, err := q.db.Query(ctx, createOrderItems,
+		arg.OrderID,
+		arg.Positions,
+		arg.Skus,
+		arg.Quantities,
+		arg.UnitPrices,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OrderItem
+	for rows.Next() {
+		var i OrderItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrderID,
+			&i.Position,
+			&i.Sku,
+			&i.Quantity,
+			&i.UnitPrice,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createOutboxEvent = `-- name: CreateOutboxEvent :one
+insert into outbox (id, aggregate_id, event_type, payload) values ($1, $2, $3, $4) returning id, aggregate_id, event_type, payload, created_at, dispatched_at, received_at, leased_until
+`
+
+type CreateOutboxEventParams struct {
+	ID          pgtype.UUID
+	AggregateID pgtype.UUID
+	EventType   string
+	Payload     []byte
+}
+
+func (q *Queries) CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) (Outbox, error) {
+	row := q.db.QueryRow(ctx, createOutboxEvent,
+		arg.ID,
+		arg.AggregateID,
+		arg.EventType,
+		arg.Payload,
+	)
+	var i Outbox
+	err := row.Scan(
+		&i.ID,
+		&i.AggregateID,
+		&i.EventType,
+		&i.Payload,
+		&i.CreatedAt,
+		&i.DispatchedAt,
+		&i.ReceivedAt,
+		&i.LeasedUntil,
 	)
 	return i, err
 }
 
+const createWebhookDeliveries = `-- name: CreateWebhookDeliveries :execrows
+insert into webhook_deliveries (subscription_id, event_id, event_type, payload)
+select id, $1, $2, $3
+from webhook_subscriptions
+where active and (cardinality(event_types) = 0 or $2::text = any(event_types))
+on conflict (subscription_id, event_id) do nothing
+`
+
+type CreateWebhookDeliveriesParams struct {
+	EventID   string
+	EventType string
+	Payload   []byte
+}
+
+func (q *Queries) CreateWebhookDeliveries(ctx context.Context, arg CreateWebhookDeliveriesParams) (int64, error) {
+	result, err := q.db.Exec(ctx, createWebhookDeliveries, arg.EventID, arg.EventType, arg.Payload)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const createWebhookDeliveryAttempt = `-- name: CreateWebhookDeliveryAttempt :exec
+insert into webhook_delivery_attempts (delivery_id, attempt, status_code, error, duration_ms)
+values ($1, $2, $3, $4, $5)
+`
+
+type CreateWebhookDeliveryAttemptParams struct {
+	DeliveryID pgtype.UUID
+	Attempt    int32
+	StatusCode pgtype.Int4
+	Error      pgtype.Text
+	DurationMs int32
+}
+
+func (q *Queries) CreateWebhookDeliveryAttempt(ctx context.Context, arg CreateWebhookDeliveryAttemptParams) error {
+	_, err := q.db.Exec(ctx, createWebhookDeliveryAttempt,
+		arg.DeliveryID,
+		arg.Attempt,
+		arg.StatusCode,
+		arg.Error,
+		arg.DurationMs,
+	)
+	return err
+}
+
+const createWebhookSubscription = `-- name: CreateWebhookSubscription :one
+insert into webhook_subscriptions (url, event_types, secret)
+values ($1, $2, $3)
+returning id, url, event_types, secret, active, consecutive_failures, disabled_at, created_at, updated_at
+`
+
+type CreateWebhookSubscriptionParams struct {
+	Url        string
+	EventTypes []string
+	Secret     string
+}
+
+func (q *Queries) CreateWebhookSubscription(ctx context.Context, arg CreateWebhookSubscriptionParams) (WebhookSubscription, error) {
+	row := q.db.QueryRow(ctx, createWebhookSubscription, arg.Url, arg.EventTypes, arg.Secret)
+	var i WebhookSubscription
+	err := row.Scan(
+		&i.ID,
+		&i.Url,
+		&i.EventTypes,
+		&i.Secret,
+		&i.Active,
+		&i.ConsecutiveFailures,
+		&i.DisabledAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteDeadLetterEvent = `-- name: DeleteDeadLetterEvent :one
+delete from dead_letter_events
+where id = $1
+returning id, event_id, aggregate_id, event_type, payload, error, attempts, trace, created_at
+`
+
+func (q *Queries) DeleteDeadLetterEvent(ctx context.Context, id int64) (DeadLetterEvent, error) {
+	row := q.db.QueryRow(ctx, deleteDeadLetterEvent, id)
+	var i DeadLetterEvent
+	err := row.Scan(
+		&i.ID,
+		&i.EventID,
+		&i.AggregateID,
+		&i.EventType,
+		&i.Payload,
+		&i.Error,
+		&i.Attempts,
+		&i.Trace,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteWebhookSubscription = `-- name: DeleteWebhookSubscription :execrows
+delete from webhook_subscriptions
+where id = $1
+`
+
+func (q *Queries) DeleteWebhookSubscription(ctx context.Context, id pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteWebhookSubscription, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getDeadLetterEvent = `-- name: GetDeadLetterEvent :one
+select id, event_id, aggregate_id, event_type, payload, error, attempts, trace, created_at from dead_letter_events
+where id = $1
+`
+
+func (q *Queries) GetDeadLetterEvent(ctx context.Context, id int64) (DeadLetterEvent, error) {
+	row := q.db.QueryRow(ctx, getDeadLetterEvent, id)
+	var i DeadLetterEvent
+	err := row.Scan(
+		&i.ID,
+		&i.EventID,
+		&i.AggregateID,
+		&i.EventType,
+		&i.Payload,
+		&i.Error,
+		&i.Attempts,
+		&i.Trace,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getIdempotencyKey = `-- name: GetIdempotencyKey :one
+select key, request_hash, order_id, created_at, expires_at from idempotency_keys
+where key = $1
+`
+
+func (q *Queries) GetIdempotencyKey(ctx context.Context, key string) (IdempotencyKey, error) {
+	row := q.db.QueryRow(ctx, getIdempotencyKey, key)
+	var i IdempotencyKey
+	err := row.Scan(
+		&i.Key,
+		&i.RequestHash,
+		&i.OrderID,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const getLastOrderHistoryID = `-- name: GetLastOrderHistoryID :one
+select coalesce(
+  (select id from order_history where tx_id < pg_snapshot_xmin(pg_current_snapshot()) order by tx_id desc, id desc limit 1),
+  0
+)::bigint
+`
+
+func (q *Queries) GetLastOrderHistoryID(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, getLastOrderHistoryID)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
 const getOrderByID = `-- name: GetOrderByID :one
-select id, quantity, created_at, updated_at, status, idempotency_key, shipping_address, sku from orders
+select id, created_at, updated_at, status, legacy_shipping_address, currency, tax_rate, subtotal, tax, total, shipping_name, shipping_lines, shipping_city, shipping_region, shipping_postal_code, shipping_country, cancellation_reason, cancellation_note, canceled_at, version from orders
 where id = $1
 `
 
@@ -53,34 +492,815 @@ func (q *Queries) GetOrderByID(ctx context.Context, id pgtype.UUID) (Order, erro
 	var i Order
 	err := row.Scan(
 		&i.ID,
-		&i.Quantity,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Status,
-		&i.IdempotencyKey,
-		&i.ShippingAddress,
-		&i.Sku,
+		&i.LegacyShippingAddress,
+		&i.Currency,
+		&i.TaxRate,
+		&i.Subtotal,
+		&i.Tax,
+		&i.Total,
+		&i.ShippingName,
+		&i.ShippingLines,
+		&i.ShippingCity,
+		&i.ShippingRegion,
+		&i.ShippingPostalCode,
+		&i.ShippingCountry,
+		&i.CancellationReason,
+		&i.CancellationNote,
+		&i.CanceledAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const getWebhookSubscription = `-- name: GetWebhookSubscription :one
+select id, url, event_types, secret, active, consecutive_failures, disabled_at, created_at, updated_at from webhook_subscriptions
+where id = $1
+`
+
+func (q *Queries) GetWebhookSubscription(ctx context.Context, id pgtype.UUID) (WebhookSubscription, error) {
+	row := q.db.QueryRow(ctx, getWebhookSubscription, id)
+	var i WebhookSubscription
+	err := row.Scan(
+		&i.ID,
+		&i.Url,
+		&i.EventTypes,
+		&i.Secret,
+		&i.Active,
+		&i.ConsecutiveFailures,
+		&i.DisabledAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const leaseDueWebhookDeliveries = `-- name: LeaseDueWebhookDeliveries :many
+with due as (
+    select d.id
+    from webhook_deliveries d
+    join webhook_subscriptions s on s.id = d.subscription_id
+    where d.status = 'pending' and d.next_attempt_at <= now() and s.active
+    order by d.next_attempt_at
+    limit $2
+    for update of d skip locked
+)
+update webhook_deliveries d set next_attempt_at = now() + make_interval(secs => $1::float8)
+from due, webhook_subscriptions s
+where d.id = due.id and s.id = d.subscription_id
+returning d.id, d.event_type, d.payload, d.attempts, d.subscription_id, s.url, s.secret
+`
+
+type LeaseDueWebhookDeliveriesParams struct {
+	LeaseSeconds float64
+	BatchSize    int32
+}
+
+type LeaseDueWebhookDeliveriesRow struct {
+	ID             pgtype.UUID
+	EventType      string
+	Payload        []byte
+	Attempts       int32
+	SubscriptionID pgtype.UUID
+	Url            string
+	Secret         string
+}
+
+// leased deliveries are not due again before the lease ends, a dispatcher stopping while it posts them leaves them to
+// be retried afterwards
+func (q *Queries) LeaseDueWebhookDeliveries(ctx context.Context, arg LeaseDueWebhookDeliveriesParams) ([]LeaseDueWebhookDeliveriesRow, error) {
+	rows, err := q.db.Query(ctx, leaseDueWebhookDeliveries, arg.LeaseSeconds, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []LeaseDueWebhookDeliveriesRow
+	for rows.Next() {
+		var i LeaseDueWebhookDeliveriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventType,
+			&i.Payload,
+			&i.Attempts,
+			&i.SubscriptionID,
+			&i.Url,
+			&i.Secret,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const leasePendingOutboxEvents = `-- name: LeasePendingOutboxEvents :many
+with due as (
+    select id
+    from outbox
+    where dispatched_at is null and (leased_until is null or leased_until <= now())
+    order by created_at
+    limit $2
+    for update skip locked
+)
+update outbox o set leased_until = now() + make_interval(secs => $1::float8)
+from due
+where o.id = due.id
+returning o.id, o.aggregate_id, o.event_type, o.payload, o.created_at, o.dispatched_at, o.received_at, o.leased_until
+`
+
+type LeasePendingOutboxEventsParams struct {
+	LeaseSeconds float64
+	BatchSize    int32
+}
+
+// leased events are not due again before the lease ends, a relay stopping while it publishes them leaves them to be
+// published again afterwards
+func (q *Queries) LeasePendingOutboxEvents(ctx context.Context, arg LeasePendingOutboxEventsParams) ([]Outbox, error) {
+	rows, err := q.db.Query(ctx, leasePendingOutboxEvents, arg.LeaseSeconds, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Outbox
+	for rows.Next() {
+		var i Outbox
+		if err := rows.Scan(
+			&i.ID,
+			&i.AggregateID,
+			&i.EventType,
+			&i.Payload,
+			&i.CreatedAt,
+			&i.DispatchedAt,
+			&i.ReceivedAt,
+			&i.LeasedUntil,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDeadLetterEvents = `-- name: ListDeadLetterEvents :many
+select id, event_id, aggregate_id, event_type, payload, error, attempts, trace, created_at from dead_letter_events
+where ($1::text is null or event_type = $1)
+  and ($2::bigint is null or id < $2)
+order by id desc
+limit $3
+`
+
+type ListDeadLetterEventsParams struct {
+	EventType pgtype.Text
+	CursorID  pgtype.Int8
+	Limit     int32
+}
+
+func (q *Queries) ListDeadLetterEvents(ctx context.Context, arg ListDeadLetterEventsParams) ([]DeadLetterEvent, error) {
+	rows, err := q.db.Query(ctx, listDeadLetterEvents, arg.EventType, arg.CursorID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []DeadLetterEvent
+	for rows.Next() {
+		var i DeadLetterEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventID,
+			&i.AggregateID,
+			&i.EventType,
+			&i.Payload,
+			&i.Error,
+			&i.Attempts,
+			&i.Trace,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrderHistory = `-- name: ListOrderHistory :many
+select id, order_id, action, actor, old_values, new_values, trace_id, created_at, tx_id from order_history
+where order_id = $1
+order by id
+`
+
+func (q *Queries) ListOrderHistory(ctx context.Context, orderID pgtype.UUID) ([]OrderHistory, error) {
+	rows, err := q.db.Query(ctx, listOrderHistory, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OrderHistory
+	for rows.Next() {
+		var i OrderHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrderID,
+			&i.Action,
+			&i.Actor,
+			&i.OldValues,
+			&i.NewValues,
+			&i.TraceID,
+			&i.CreatedAt,
+			&i.TxID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrderHistoryAfter = `-- name: ListOrderHistoryAfter :many
+select id, order_id, action, actor, old_values, new_values, trace_id, created_at, tx_id from order_history
+where tx_id < pg_snapshot_xmin(pg_current_snapshot())
+  and (tx_id, id) > (
+    coalesce(
+      (select h.tx_id from order_history h where h.id <= $1 order by h.id desc limit 1),
+      '0'::xid8
+    ),
+    $1
+  )
+  and ($2::uuid is null or order_id = $2)
+order by tx_id, id
+limit $3
+`
+
+type ListOrderHistoryAfterParams struct {
+	AfterID int64
+	OrderID pgtype.UUID
+	Limit   int32
+}
+
+func (q *Queries) ListOrderHistoryAfter(ctx context.Context, arg ListOrderHistoryAfterParams) ([]OrderHistory, error) {
+	rows, err := q.db.Query(ctx, listOrderHistoryAfter, arg.AfterID, arg.OrderID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OrderHistory
+	for rows.Next() {
+		var i OrderHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrderID,
+			&i.Action,
+			&i.Actor,
+			&i.OldValues,
+			&i.NewValues,
+			&i.TraceID,
+			&i.CreatedAt,
+			&i.TxID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrderItemsByOrderIDs = `-- name: ListOrderItemsByOrderIDs :many
+select id, order_id, position, sku, quantity, unit_price, created_at from order_items
+where order_id = any($1::uuid[])
+order by order_id, position
+`
+
+func (q *Queries) ListOrderItemsByOrderIDs(ctx context.Context, orderIds []pgtype.UUID) ([]OrderItem, error) {
+	rows, err := q.db.Query(ctx, listOrderItemsByOrderIDs, orderIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OrderItem
+	for rows.Next() {
+		var i OrderItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrderID,
+			&i.Position,
+			&i.Sku,
+			&i.Quantity,
+			&i.UnitPrice,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrdersAsc = `-- name: ListOrdersAsc :many
+select id, created_at, updated_at, status, legacy_shipping_address, currency, tax_rate, subtotal, tax, total, shipping_name, shipping_lines, shipping_city, shipping_region, shipping_postal_code, shipping_country, cancellation_reason, cancellation_note, canceled_at, version from orders
+where ($1::order_status is null or status = $1)
+  and ($2::uuid is null
+    or exists (select 1 from order_items oi where oi.order_id = orders.id and oi.sku = $2))
+  and ($3::timestamp is null or created_at >= $3)
+  and ($4::timestamp is null or created_at < $4)
+  and ($5::timestamp is null
+    or (created_at, id) > ($5, $6::uuid))
+order by created_at asc, id asc
+limit $7
+`
+
+type ListOrdersAscParams struct {
+	Status          NullOrderStatus
+	Sku             pgtype.UUID
+	CreatedFrom     pgtype.Timestamp
+	CreatedTo       pgtype.Timestamp
+	CursorCreatedAt pgtype.Timestamp
+	CursorID        pgtype.UUID
+	Limit           int32
+}
+
+func (q *Queries) ListOrdersAsc(ctx context.Context, arg ListOrdersAscParams) ([]Order, error) {
+	rows, err := q.db.Query(ctx, listOrdersAsc,
+		arg.Status,
+		arg.Sku,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Order
+	for rows.Next() {
+		var i Order
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Status,
+			&i.LegacyShippingAddress,
+			&i.Currency,
+			&i.TaxRate,
+			&i.Subtotal,
+			&i.Tax,
+			&i.Total,
+			&i.ShippingName,
+			&i.ShippingLines,
+			&i.ShippingCity,
+			&i.ShippingRegion,
+			&i.ShippingPostalCode,
+			&i.ShippingCountry,
+			&i.CancellationReason,
+			&i.CancellationNote,
+			&i.CanceledAt,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrdersDesc = `-- name: ListOrdersDesc :many
+select id, created_at, updated_at, status, legacy_shipping_address, currency, tax_rate, subtotal, tax, total, shipping_name, shipping_lines, shipping_city, shipping_region, shipping_postal_code, shipping_country, cancellation_reason, cancellation_note, canceled_at, version from orders
+where ($1::order_status is null or status = $1)
+  and ($2::uuid is null
+    or exists (select 1 from order_items oi where oi.order_id = orders.id and oi.sku = $2))
+  and ($3::timestamp is null or created_at >= $3)
+  and ($4::timestamp is null or created_at < $4)
+  and ($5::timestamp is null
+    or (created_at, id) < ($5, $6::uuid))
+order by created_at desc, id desc
+limit $7
+`
+
+type ListOrdersDescParams struct {
+	Status          NullOrderStatus
+	Sku             pgtype.UUID
+	CreatedFrom     pgtype.Timestamp
+	CreatedTo       pgtype.Timestamp
+	CursorCreatedAt pgtype.Timestamp
+	CursorID        pgtype.UUID
+	Limit           int32
+}
+
+func (q *Queries) ListOrdersDesc(ctx context.Context, arg ListOrdersDescParams) ([]Order, error) {
+	rows, err := q.db.Query(ctx, listOrdersDesc,
+		arg.Status,
+		arg.Sku,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Order
+	for rows.Next() {
+		var i Order
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Status,
+			&i.LegacyShippingAddress,
+			&i.Currency,
+			&i.TaxRate,
+			&i.Subtotal,
+			&i.Tax,
+			&i.Total,
+			&i.ShippingName,
+			&i.ShippingLines,
+			&i.ShippingCity,
+			&i.ShippingRegion,
+			&i.ShippingPostalCode,
+			&i.ShippingCountry,
+			&i.CancellationReason,
+			&i.CancellationNote,
+			&i.CanceledAt,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUnreceivedOutboxEventIDs = `-- name: ListUnreceivedOutboxEventIDs :many
+select id from outbox
+where received_at is null
+order by created_at
+limit $1
+`
+
+func (q *Queries) ListUnreceivedOutboxEventIDs(ctx context.Context, limit int32) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, listUnreceivedOutboxEventIDs, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []pgtype.UUID
+	for rows.Next() {
+		var id pgtype.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookDeliveries = `-- name: ListWebhookDeliveries :many
+select id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_status_code, last_error, delivered_at, created_at from webhook_deliveries
+where subscription_id = $1
+order by created_at desc, id desc
+limit $2
+`
+
+type ListWebhookDeliveriesParams struct {
+	SubscriptionID pgtype.UUID
+	Limit          int32
+}
+
+func (q *Queries) ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error) {
+	rows, err := q.db.Query(ctx, listWebhookDeliveries, arg.SubscriptionID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebhookDelivery
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.SubscriptionID,
+			&i.EventID,
+			&i.EventType,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.LastStatusCode,
+			&i.LastError,
+			&i.DeliveredAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookSubscriptions = `-- name: ListWebhookSubscriptions :many
+select id, url, event_types, secret, active, consecutive_failures, disabled_at, created_at, updated_at from webhook_subscriptions
+order by created_at, id
+`
+
+func (q *Queries) ListWebhookSubscriptions(ctx context.Context) ([]WebhookSubscription, error) {
+	rows, err := q.db.Query(ctx, listWebhookSubscriptions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebhookSubscription
+	for rows.Next() {
+		var i WebhookSubscription
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.EventTypes,
+			&i.Secret,
+			&i.Active,
+			&i.ConsecutiveFailures,
+			&i.DisabledAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markOutboxEventDispatched = `-- name: MarkOutboxEventDispatched :exec
+update outbox set dispatched_at = now(), leased_until = null
+where id = $1
+`
+
+func (q *Queries) MarkOutboxEventDispatched(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, markOutboxEventDispatched, id)
+	return err
+}
+
+const markWebhookDeliveryFailed = `-- name: MarkWebhookDeliveryFailed :exec
+update webhook_deliveries set
+    status = case when attempts + 1 >= $1::int then 'failed'::webhook_delivery_status else 'pending' end,
+    attempts = attempts + 1,
+    last_status_code = $2,
+    last_error = $3,
+    next_attempt_at = now() + make_interval(secs => $4::float8)
+where id = $5
+`
+
+type MarkWebhookDeliveryFailedParams struct {
+	MaxAttempts    int32
+	StatusCode     pgtype.Int4
+	Error          pgtype.Text
+	RetryInSeconds float64
+	ID             pgtype.UUID
+}
+
+// the delivery stays pending until it runs out of attempts
+func (q *Queries) MarkWebhookDeliveryFailed(ctx context.Context, arg MarkWebhookDeliveryFailedParams) error {
+	_, err := q.db.Exec(ctx, markWebhookDeliveryFailed,
+		arg.MaxAttempts,
+		arg.StatusCode,
+		arg.Error,
+		arg.RetryInSeconds,
+		arg.ID,
+	)
+	return err
+}
+
+const markWebhookDeliverySucceeded = `-- name: MarkWebhookDeliverySucceeded :exec
+update webhook_deliveries set
+    status = 'succeeded', attempts = attempts + 1, last_status_code = $1, last_error = null, delivered_at = now()
+where id = $2
+`
+
+type MarkWebhookDeliverySucceededParams struct {
+	StatusCode pgtype.Int4
+	ID         pgtype.UUID
+}
+
+func (q *Queries) MarkWebhookDeliverySucceeded(ctx context.Context, arg MarkWebhookDeliverySucceededParams) error {
+	_, err := q.db.Exec(ctx, markWebhookDeliverySucceeded, arg.StatusCode, arg.ID)
+	return err
+}
+
+const receiveOutboxEvent = `-- name: ReceiveOutboxEvent :one
+update outbox set received_at = now()
+where id = $1 and received_at is null
+returning id, aggregate_id, event_type, payload, created_at, dispatched_at, received_at, leased_until
+`
+
+func (q *Queries) ReceiveOutboxEvent(ctx context.Context, id pgtype.UUID) (Outbox, error) {
+	row := q.db.QueryRow(ctx, receiveOutboxEvent, id)
+	var i Outbox
+	err := row.Scan(
+		&i.ID,
+		&i.AggregateID,
+		&i.EventType,
+		&i.Payload,
+		&i.CreatedAt,
+		&i.DispatchedAt,
+		&i.ReceivedAt,
+		&i.LeasedUntil,
+	)
+	return i, err
+}
+
+const recordWebhookSubscriptionFailure = `-- name: RecordWebhookSubscriptionFailure :one
+update webhook_subscriptions set
+    consecutive_failures = consecutive_failures + 1,
+    active = active and consecutive_failures + 1 < $1::int,
+    disabled_at = case when consecutive_failures + 1 >= $1::int then coalesce(disabled_at, now()) else disabled_at end,
+    updated_at = now()
+where id = $2
+returning id, url, event_types, secret, active, consecutive_failures, disabled_at, created_at, updated_at
+`
+
+type RecordWebhookSubscriptionFailureParams struct {
+	DisableAfter int32
+	ID           pgtype.UUID
+}
+
+func (q *Queries) RecordWebhookSubscriptionFailure(ctx context.Context, arg RecordWebhookSubscriptionFailureParams) (WebhookSubscription, error) {
+	row := q.db.QueryRow(ctx, recordWebhookSubscriptionFailure, arg.DisableAfter, arg.ID)
+	var i WebhookSubscription
+	err := row.Scan(
+		&i.ID,
+		&i.Url,
+		&i.EventTypes,
+		&i.Secret,
+		&i.Active,
+		&i.ConsecutiveFailures,
+		&i.DisabledAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
-const getOrderByIdempotencyKey = `-- name: GetOrderByIdempotencyKey :one
-select id, quantity, created_at, updated_at, status, idempotency_key, shipping_address, sku from orders
-where idempotency_key = $1
+const requeueOutboxEvent = `-- name: RequeueOutboxEvent :execrows
+update outbox set dispatched_at = null, received_at = null, leased_until = null
+where id = $1 and (dispatched_at is not null or received_at is not null)
+`
+
+func (q *Queries) RequeueOutboxEvent(ctx context.Context, id pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, requeueOutboxEvent, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const resetWebhookSubscriptionFailures = `-- name: ResetWebhookSubscriptionFailures :exec
+update webhook_subscriptions set consecutive_failures = 0
+where id = $1 and consecutive_failures > 0
 `
 
-func (q *Queries) GetOrderByIdempotencyKey(ctx context.Context, idempotencyKey pgtype.UUID) (Order, error) {
-	row := q.db.QueryRow(ctx, getOrderByIdempotencyKey, idempotencyKey)
+func (q *Queries) ResetWebhookSubscriptionFailures(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, resetWebhookSubscriptionFailures, id)
+	return err
+}
+
+const setIdempotencyKeyOrder = `-- name: SetIdempotencyKeyOrder :exec
+update idempotency_keys set order_id = $1
+where key = $2
+`
+
+type SetIdempotencyKeyOrderParams struct {
+	OrderID pgtype.UUID
+	Key     string
+}
+
+func (q *Queries) SetIdempotencyKeyOrder(ctx context.Context, arg SetIdempotencyKeyOrderParams) error {
+	_, err := q.db.Exec(ctx, setIdempotencyKeyOrder, arg.OrderID, arg.Key)
+	return err
+}
+
+const updateOrderStatus = `-- name: UpdateOrderStatus :one
+update orders set status = $1, updated_at = now(), version = version + 1
+where id = $2 and status = $3 and version = $4
+returning id, created_at, updated_at, status, legacy_shipping_address, currency, tax_rate, subtotal, tax, total, shipping_name, shipping_lines, shipping_city, shipping_region, shipping_postal_code, shipping_country, cancellation_reason, cancellation_note, canceled_at, version
+`
+
+type UpdateOrderStatusParams struct {
+	Status        OrderStatus
+	ID            pgtype.UUID
+	CurrentStatus OrderStatus
+	Version       int32
+}
+
+func (q *Queries) UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) (Order, error) {
+	row := q.db.QueryRow(ctx, updateOrderStatus,
+		arg.Status,
+		arg.ID,
+		arg.CurrentStatus,
+		arg.Version,
+	)
 	var i Order
 	err := row.Scan(
 		&i.ID,
-		&i.Quantity,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Status,
-		&i.IdempotencyKey,
-		&i.ShippingAddress,
-		&i.Sku,
+		&i.LegacyShippingAddress,
+		&i.Currency,
+		&i.TaxRate,
+		&i.Subtotal,
+		&i.Tax,
+		&i.Total,
+		&i.ShippingName,
+		&i.ShippingLines,
+		&i.ShippingCity,
+		&i.ShippingRegion,
+		&i.ShippingPostalCode,
+		&i.ShippingCountry,
+		&i.CancellationReason,
+		&i.CancellationNote,
+		&i.CanceledAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const updateWebhookSubscription = `-- name: UpdateWebhookSubscription :one
+update webhook_subscriptions set
+    url = coalesce($1, url),
+    event_types = coalesce($2::text[], event_types),
+    active = coalesce($3, active),
+    consecutive_failures = case when $3::boolean then 0 else consecutive_failures end,
+    disabled_at = case
+        when $3::boolean then null
+        when not $3::boolean then coalesce(disabled_at, now())
+        else disabled_at
+    end,
+    updated_at = now()
+where id = $4
+returning id, url, event_types, secret, active, consecutive_failures, disabled_at, created_at, updated_at
+`
+
+type UpdateWebhookSubscriptionParams struct {
+	Url        pgtype.Text
+	EventTypes []string
+	Active     pgtype.Bool
+	ID         pgtype.UUID
+}
+
+// enabling a subscription again gives it a clean failure count
+func (q *Queries) UpdateWebhookSubscription(ctx context.Context, arg UpdateWebhookSubscriptionParams) (WebhookSubscription, error) {
+	row := q.db.QueryRow(ctx, updateWebhookSubscription,
+		arg.Url,
+		arg.EventTypes,
+		arg.Active,
+		arg.ID,
+	)
+	var i WebhookSubscription
+	err := row.Scan(
+		&i.ID,
+		&i.Url,
+		&i.EventTypes,
+		&i.Secret,
+		&i.Active,
+		&i.ConsecutiveFailures,
+		&i.DisabledAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
@@ -0,0 +1,24 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// SchemaVersion returns the version of the newest migration applied by dbmate, the schema_migrations table is owned
+// by dbmate so the query is not generated.
+func SchemaVersion(ctx context.Context, db DBTX) (string, error) {
+	version := ""
+	err := db.QueryRow(ctx, "SELECT version FROM schema_migrations ORDER BY version DESC LIMIT 1").Scan(&version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", errors.New("no migrations applied")
+	}
+	if err != nil {
+		return "", fmt.Errorf("get schema version: %w", err)
+	}
+
+	return version, nil
+}
@@ -0,0 +1,307 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/leetm4n/orders-service/internal/model"
+)
+
+// PostgresWebhookRepository implements model.WebhookRepository with the generated queries.
+type PostgresWebhookRepository struct {
+	pool    *pgxpool.Pool
+	queries *Queries
+}
+
+func NewPostgresWebhookRepository(pool *pgxpool.Pool) *PostgresWebhookRepository {
+	return &PostgresWebhookRepository{
+		pool:    pool,
+		queries: New(pool),
+	}
+}
+
+func (r *PostgresWebhookRepository) CreateWebhookSubscription(
+	ctx context.Context,
+	subscription model.NewWebhookSubscription,
+) (model.WebhookSubscription, error) {
+	eventTypes := subscription.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	created, err := r.queries.CreateWebhookSubscription(ctx, CreateWebhookSubscriptionParams{
+		Url:        subscription.URL,
+		EventTypes: eventTypes,
+		Secret:     subscription.Secret,
+	})
+	if err != nil {
+		return model.WebhookSubscription{}, err
+	}
+
+	return toModelWebhookSubscription(created), nil
+}
+
+func (r *PostgresWebhookRepository) ListWebhookSubscriptions(ctx context.Context) ([]model.WebhookSubscription, error) {
+	subscriptions, err := r.queries.ListWebhookSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.WebhookSubscription, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		result = append(result, toModelWebhookSubscription(subscription))
+	}
+
+	return result, nil
+}
+
+func (r *PostgresWebhookRepository) GetWebhookSubscription(ctx context.Context, id uuid.UUID) (model.WebhookSubscription, error) {
+	subscription, err := r.queries.GetWebhookSubscription(ctx, toUUID(id))
+	if err != nil {
+		return model.WebhookSubscription{}, notFound(err)
+	}
+
+	return toModelWebhookSubscription(subscription), nil
+}
+
+func (r *PostgresWebhookRepository) UpdateWebhookSubscription(
+	ctx context.Context,
+	id uuid.UUID,
+	update model.WebhookSubscriptionUpdate,
+) (model.WebhookSubscription, error) {
+	params := UpdateWebhookSubscriptionParams{
+		ID:         toUUID(id),
+		EventTypes: update.EventTypes,
+	}
+
+	if update.URL != nil {
+		params.Url = pgtype.Text{String: *update.URL, Valid: true}
+	}
+
+	if update.Active != nil {
+		params.Active = pgtype.Bool{Bool: *update.Active, Valid: true}
+	}
+
+	subscription, err := r.queries.UpdateWebhookSubscription(ctx, params)
+	if err != nil {
+		return model.WebhookSubscription{}, notFound(err)
+	}
+
+	return toModelWebhookSubscription(subscription), nil
+}
+
+func (r *PostgresWebhookRepository) DeleteWebhookSubscription(ctx context.Context, id uuid.UUID) error {
+	deleted, err := r.queries.DeleteWebhookSubscription(ctx, toUUID(id))
+	if err != nil {
+		return err
+	}
+
+	if deleted == 0 {
+		return model.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *PostgresWebhookRepository) ListWebhookDeliveries(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+	limit int,
+) ([]model.WebhookDelivery, error) {
+	deliveries, err := r.queries.ListWebhookDeliveries(ctx, ListWebhookDeliveriesParams{
+		SubscriptionID: toUUID(subscriptionID),
+		Limit:          int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		result = append(result, toModelWebhookDelivery(delivery))
+	}
+
+	return result, nil
+}
+
+func (r *PostgresWebhookRepository) CreateWebhookDeliveries(
+	ctx context.Context,
+	eventID, eventType string,
+	payload []byte,
+) (int, error) {
+	created, err := r.queries.CreateWebhookDeliveries(ctx, CreateWebhookDeliveriesParams{
+		EventID:   eventID,
+		EventType: eventType,
+		Payload:   payload,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(created), nil
+}
+
+// LeaseDueWebhookDeliveries leases the deliveries in a single statement with FOR UPDATE SKIP LOCKED, so multiple
+// dispatchers can run in parallel without holding locks while they post.
+func (r *PostgresWebhookRepository) LeaseDueWebhookDeliveries(
+	ctx context.Context,
+	limit int,
+	lease time.Duration,
+) ([]model.LeasedWebhookDelivery, error) {
+	deliveries, err := r.queries.LeaseDueWebhookDeliveries(ctx, LeaseDueWebhookDeliveriesParams{
+		BatchSize:    int32(limit),
+		LeaseSeconds: lease.Seconds(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.LeasedWebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		result = append(result, model.LeasedWebhookDelivery{
+			ID:             delivery.ID.Bytes,
+			SubscriptionID: delivery.SubscriptionID.Bytes,
+			EventType:      delivery.EventType,
+			Payload:        delivery.Payload,
+			Attempts:       int(delivery.Attempts),
+			URL:            delivery.Url,
+			Secret:         delivery.Secret,
+		})
+	}
+
+	return result, nil
+}
+
+func (r *PostgresWebhookRepository) RecordWebhookDeliveryAttempt(
+	ctx context.Context,
+	attempt model.WebhookDeliveryAttempt,
+	policy model.WebhookRetryPolicy,
+) (bool, error) {
+	disabled := false
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		var err error
+		disabled, err = recordWebhookDeliveryAttempt(ctx, r.queries.WithTx(tx), attempt, policy)
+
+		return err
+	})
+
+	return disabled, err
+}
+
+func recordWebhookDeliveryAttempt(
+	ctx context.Context,
+	qtx *Queries,
+	attempt model.WebhookDeliveryAttempt,
+	policy model.WebhookRetryPolicy,
+) (bool, error) {
+	statusCode := pgtype.Int4{}
+	if attempt.StatusCode != nil {
+		statusCode = pgtype.Int4{Int32: int32(*attempt.StatusCode), Valid: true}
+	}
+
+	if err := qtx.CreateWebhookDeliveryAttempt(ctx, CreateWebhookDeliveryAttemptParams{
+		DeliveryID: toUUID(attempt.DeliveryID),
+		Attempt:    int32(attempt.Attempt),
+		StatusCode: statusCode,
+		Error:      toText(attempt.Error),
+		DurationMs: int32(attempt.Duration.Milliseconds()),
+	}); err != nil {
+		return false, fmt.Errorf("create delivery attempt: %w", err)
+	}
+
+	if attempt.Error == nil {
+		if err := qtx.MarkWebhookDeliverySucceeded(ctx, MarkWebhookDeliverySucceededParams{
+			ID:         toUUID(attempt.DeliveryID),
+			StatusCode: statusCode,
+		}); err != nil {
+			return false, fmt.Errorf("mark delivery succeeded: %w", err)
+		}
+
+		if err := qtx.ResetWebhookSubscriptionFailures(ctx, toUUID(attempt.SubscriptionID)); err != nil {
+			return false, fmt.Errorf("reset subscription failures: %w", err)
+		}
+
+		return false, nil
+	}
+
+	if err := qtx.MarkWebhookDeliveryFailed(ctx, MarkWebhookDeliveryFailedParams{
+		ID:             toUUID(attempt.DeliveryID),
+		MaxAttempts:    int32(policy.MaxAttempts),
+		StatusCode:     statusCode,
+		Error:          toText(attempt.Error),
+		RetryInSeconds: policy.RetryIn.Seconds(),
+	}); err != nil {
+		return false, fmt.Errorf("mark delivery failed: %w", err)
+	}
+
+	subscription, err := qtx.RecordWebhookSubscriptionFailure(ctx, RecordWebhookSubscriptionFailureParams{
+		ID:           toUUID(attempt.SubscriptionID),
+		DisableAfter: int32(policy.DisableAfterFailures),
+	})
+	if err != nil {
+		return false, fmt.Errorf("record subscription failure: %w", err)
+	}
+
+	return subscription.ConsecutiveFailures == int32(policy.DisableAfterFailures), nil
+}
+
+func toModelWebhookSubscription(subscription WebhookSubscription) model.WebhookSubscription {
+	result := model.WebhookSubscription{
+		ID:                  subscription.ID.Bytes,
+		URL:                 subscription.Url,
+		EventTypes:          subscription.EventTypes,
+		Secret:              subscription.Secret,
+		Active:              subscription.Active,
+		ConsecutiveFailures: int(subscription.ConsecutiveFailures),
+		CreatedAt:           subscription.CreatedAt.Time,
+		UpdatedAt:           subscription.UpdatedAt.Time,
+	}
+
+	if subscription.DisabledAt.Valid {
+		result.DisabledAt = &subscription.DisabledAt.Time
+	}
+
+	return result
+}
+
+func toModelWebhookDelivery(delivery WebhookDelivery) model.WebhookDelivery {
+	result := model.WebhookDelivery{
+		ID:             delivery.ID.Bytes,
+		SubscriptionID: delivery.SubscriptionID.Bytes,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Payload:        delivery.Payload,
+		Status:         model.WebhookDeliveryStatus(delivery.Status),
+		Attempts:       int(delivery.Attempts),
+		NextAttemptAt:  delivery.NextAttemptAt.Time,
+		CreatedAt:      delivery.CreatedAt.Time,
+	}
+
+	if delivery.LastStatusCode.Valid {
+		statusCode := int(delivery.LastStatusCode.Int32)
+		result.LastStatusCode = &statusCode
+	}
+
+	if delivery.LastError.Valid {
+		result.LastError = &delivery.LastError.String
+	}
+
+	if delivery.DeliveredAt.Valid {
+		result.DeliveredAt = &delivery.DeliveredAt.Time
+	}
+
+	return result
+}
+
+func toText(s *string) pgtype.Text {
+	if s == nil {
+		return pgtype.Text{}
+	}
+
+	return pgtype.Text{String: *s, Valid: true}
+}
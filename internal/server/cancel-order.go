@@ -0,0 +1,50 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/leetm4n/orders-service/api"
+	"github.com/leetm4n/orders-service/internal/service"
+	openapiTypes "github.com/oapi-codegen/runtime/types"
+)
+
+func (s *ServerImpl) CancelOrder(w http.ResponseWriter, r *http.Request, orderId openapiTypes.UUID, params api.CancelOrderParams) {
+	requestBody := api.CancelOrderRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		fail(w, r, malformedBody(err))
+		return
+	}
+
+	order, err := s.orders.GetOrder(r.Context(), orderId)
+	if err != nil {
+		fail(w, r, err)
+		return
+	}
+
+	if !checkIfMatch(w, r, params.IfMatch, order.Version) {
+		return
+	}
+
+	canceledOrder, err := s.orders.CancelOrder(r.Context(), service.CancelOrderInput{
+		ID:      orderId,
+		Reason:  string(requestBody.Reason),
+		Note:    requestBody.Note,
+		Version: order.Version,
+		Actor:   params.XActor,
+	})
+	if err != nil {
+		fail(w, r, err)
+		return
+	}
+
+	w.Header().Set("ETag", orderETag(canceledOrder.Version))
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(toAPIOrder(canceledOrder)); err != nil {
+		slog.Error("failed to write order response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/leetm4n/orders-service/api"
+	"github.com/leetm4n/orders-service/internal/model"
+)
+
+func (s *ServerImpl) CreateWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+	requestBody := api.CreateWebhookSubscriptionRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		fail(w, r, malformedBody(err))
+		return
+	}
+
+	if err := s.webhookURLPolicy.CheckURL(requestBody.Url); err != nil {
+		fail(w, r, invalidField("/url", err))
+		return
+	}
+
+	secret := generateWebhookSecret()
+	if requestBody.Secret != nil {
+		secret = *requestBody.Secret
+	}
+
+	subscription, err := s.webhooks.CreateWebhookSubscription(r.Context(), model.NewWebhookSubscription{
+		URL:        requestBody.Url,
+		EventTypes: fromAPIWebhookEventTypes(requestBody.EventTypes),
+		Secret:     secret,
+	})
+	if err != nil {
+		fail(w, r, fmt.Errorf("create webhook subscription: %w", err))
+		return
+	}
+
+	response := toAPIWebhookSubscription(subscription)
+	response.Secret = &subscription.Secret
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("failed to write webhook subscription response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,25 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/leetm4n/orders-service/internal/model"
+	"github.com/leetm4n/orders-service/pkg/problem"
+	openapiTypes "github.com/oapi-codegen/runtime/types"
+)
+
+func (s *ServerImpl) DeleteWebhookSubscription(w http.ResponseWriter, r *http.Request, webhookId openapiTypes.UUID) {
+	if err := s.webhooks.DeleteWebhookSubscription(r.Context(), webhookId); err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			fail(w, r, problem.New(http.StatusNotFound, codeWebhookNotFound, "webhook subscription not found"))
+			return
+		}
+
+		fail(w, r, fmt.Errorf("delete webhook subscription: %w", err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
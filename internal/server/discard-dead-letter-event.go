@@ -0,0 +1,24 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/leetm4n/orders-service/internal/model"
+	"github.com/leetm4n/orders-service/pkg/problem"
+)
+
+func (s *ServerImpl) DiscardDeadLetterEvent(w http.ResponseWriter, r *http.Request, deadLetterEventId int64) {
+	if err := s.deadLetters.DeleteDeadLetterEvent(r.Context(), deadLetterEventId); err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			fail(w, r, problem.New(http.StatusNotFound, codeDeadLetterEventNotFound, "dead letter event not found"))
+			return
+		}
+
+		fail(w, r, fmt.Errorf("delete dead letter event: %w", err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/leetm4n/orders-service/pkg/problem"
+)
+
+func orderETag(version int32) string {
+	return strconv.Quote(strconv.Itoa(int(version)))
+}
+
+// etagMatches reports whether the If-Match / If-None-Match header value matches etag. Weak comparison ignores
+// the W/ prefix of the listed tags (If-None-Match), strong comparison never matches weak tags (If-Match).
+func etagMatches(header, etag string, weak bool) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+
+		if candidate == "*" {
+			return true
+		}
+
+		if strings.HasPrefix(candidate, "W/") {
+			if !weak {
+				continue
+			}
+
+			candidate = strings.TrimPrefix(candidate, "W/")
+		}
+
+		if candidate == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkIfMatch writes 428 when the If-Match header is missing or 412 when it does not match the current version
+// of the order and reports whether the mutation may proceed.
+func checkIfMatch(w http.ResponseWriter, r *http.Request, ifMatch *string, version int32) bool {
+	if ifMatch == nil {
+		fail(w, r, problem.New(http.StatusPreconditionRequired, codePreconditionRequired, "If-Match header is required"))
+		return false
+	}
+
+	if !etagMatches(*ifMatch, orderETag(version), false) {
+		fail(w, r, problem.New(http.StatusPreconditionFailed, codeVersionMismatch, "order version does not match If-Match"))
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,59 @@
+package server
+
+import "testing"
+
+func TestEtagMatches(t *testing.T) {
+	type args struct {
+		header string
+		etag   string
+		weak   bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "should match same tag",
+			args: args{header: `"3"`, etag: `"3"`},
+			want: true,
+		},
+		{
+			name: "should not match other version",
+			args: args{header: `"2"`, etag: `"3"`},
+			want: false,
+		},
+		{
+			name: "should match any tag in list",
+			args: args{header: `"1", "3"`, etag: `"3"`},
+			want: true,
+		},
+		{
+			name: "should match wildcard",
+			args: args{header: `*`, etag: `"3"`},
+			want: true,
+		},
+		{
+			name: "should not match weak tag with strong comparison",
+			args: args{header: `W/"3"`, etag: `"3"`},
+			want: false,
+		},
+		{
+			name: "should match weak tag with weak comparison",
+			args: args{header: `W/"3"`, etag: `"3"`, weak: true},
+			want: true,
+		},
+		{
+			name: "should not match unquoted tag",
+			args: args{header: `3`, etag: `"3"`},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := etagMatches(tt.args.header, tt.args.etag, tt.args.weak); got != tt.want {
+				t.Errorf("etagMatches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/leetm4n/orders-service/api"
+	"github.com/leetm4n/orders-service/internal/model"
+	"github.com/leetm4n/orders-service/pkg/problem"
+	"github.com/leetm4n/orders-service/pkg/tracing"
+	openapiTypes "github.com/oapi-codegen/runtime/types"
+)
+
+func (s *ServerImpl) GetDeadLetterEvent(w http.ResponseWriter, r *http.Request, deadLetterEventId int64) {
+	deadLetterEvent, err := s.deadLetters.GetDeadLetterEvent(r.Context(), deadLetterEventId)
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			fail(w, r, problem.New(http.StatusNotFound, codeDeadLetterEventNotFound, "dead letter event not found"))
+			return
+		}
+
+		fail(w, r, fmt.Errorf("get dead letter event: %w", err))
+		return
+	}
+
+	response, err := toAPIDeadLetterEvent(deadLetterEvent)
+	if err != nil {
+		fail(w, r, fmt.Errorf("map dead letter event %d: %w", deadLetterEvent.ID, err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("failed to write dead letter event response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func toAPIDeadLetterEvent(deadLetterEvent model.DeadLetterEvent) (api.DeadLetterEvent, error) {
+	item := api.DeadLetterEvent{
+		Id:        deadLetterEvent.ID,
+		EventId:   deadLetterEvent.EventID,
+		EventType: deadLetterEvent.EventType,
+		Error:     deadLetterEvent.Error,
+		Attempts:  deadLetterEvent.Attempts,
+		CreatedAt: deadLetterEvent.CreatedAt,
+	}
+
+	if deadLetterEvent.AggregateID != nil {
+		aggregateID := openapiTypes.UUID(*deadLetterEvent.AggregateID)
+		item.AggregateId = &aggregateID
+	}
+
+	// the payload is passed on undecoded, it is not an object for events that were not valid JSON
+	item.Payload = json.RawMessage(deadLetterEvent.Payload)
+
+	trace := tracing.TraceEnvelope{}
+	if err := json.Unmarshal(deadLetterEvent.Trace, &trace); err != nil {
+		return api.DeadLetterEvent{}, err
+	}
+
+	item.Trace = api.TraceEnvelope(trace)
+
+	return item, nil
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/leetm4n/orders-service/internal/model"
+)
+
+func TestToAPIDeadLetterEvent(t *testing.T) {
+	type args struct {
+		payload string
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "should pass on an event payload",
+			args: args{payload: `{"specversion":"1.0","id":"1","source":"/orders-service","type":"order.created"}`},
+		},
+		{
+			name: "should pass on the payload of a malformed event stored as string",
+			args: args{payload: `"{\"items\": "`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toAPIDeadLetterEvent(model.DeadLetterEvent{
+				ID:        1,
+				EventID:   "1",
+				EventType: "order.created",
+				Payload:   []byte(tt.args.payload),
+				Trace:     []byte(`{}`),
+			})
+			if err != nil {
+				t.Fatalf("toAPIDeadLetterEvent() error = %v", err)
+			}
+
+			b, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+
+			resp := struct {
+				Payload json.RawMessage `json:"payload"`
+			}{}
+			if err := json.Unmarshal(b, &resp); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+
+			if string(resp.Payload) != tt.args.payload {
+				t.Errorf("payload = %s, want %s", resp.Payload, tt.args.payload)
+			}
+		})
+	}
+}
@@ -9,7 +9,7 @@ import (
 	"github.com/leetm4n/orders-service/api"
 )
 
-func (s *ServerImpl) GetHealth(w http.ResponseWriter, r *http.Request) {
+func (s *ServerImpl) GetLiveness(w http.ResponseWriter, r *http.Request) {
 	resp := api.GetHealthResponse{
 		Status:    "ok",
 		Timestamp: time.Now(),
@@ -22,3 +22,8 @@ func (s *ServerImpl) GetHealth(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// GetHealth serves the former /healthz liveness probe until the probes of existing deployments moved to /livez.
+func (s *ServerImpl) GetHealth(w http.ResponseWriter, r *http.Request) {
+	s.GetLiveness(w, r)
+}
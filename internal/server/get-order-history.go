@@ -0,0 +1,66 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/leetm4n/orders-service/api"
+	"github.com/leetm4n/orders-service/internal/model"
+	openapiTypes "github.com/oapi-codegen/runtime/types"
+)
+
+func (s *ServerImpl) GetOrderHistory(w http.ResponseWriter, r *http.Request, orderId openapiTypes.UUID) {
+	entries, err := s.orders.ListOrderHistory(r.Context(), orderId)
+	if err != nil {
+		fail(w, r, err)
+		return
+	}
+
+	response := api.OrderHistoryResponse{
+		Items: make([]api.OrderHistoryEntry, 0, len(entries)),
+	}
+
+	for _, entry := range entries {
+		item, err := toAPIOrderHistoryEntry(entry)
+		if err != nil {
+			fail(w, r, fmt.Errorf("map order history entry %d: %w", entry.ID, err))
+			return
+		}
+
+		response.Items = append(response.Items, item)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("failed to write order history response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func toAPIOrderHistoryEntry(entry model.HistoryEntry) (api.OrderHistoryEntry, error) {
+	item := api.OrderHistoryEntry{
+		Id:        entry.ID,
+		Action:    api.OrderHistoryEntryAction(entry.Action),
+		Actor:     entry.Actor,
+		TraceId:   entry.TraceID,
+		CreatedAt: entry.CreatedAt,
+	}
+
+	if err := json.Unmarshal(entry.NewValues, &item.NewValues); err != nil {
+		return api.OrderHistoryEntry{}, err
+	}
+
+	if entry.OldValues != nil {
+		oldValues := map[string]interface{}{}
+		if err := json.Unmarshal(entry.OldValues, &oldValues); err != nil {
+			return api.OrderHistoryEntry{}, err
+		}
+
+		item.OldValues = &oldValues
+	}
+
+	return item, nil
+}
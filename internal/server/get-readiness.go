@@ -0,0 +1,60 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/leetm4n/orders-service/api"
+	"github.com/leetm4n/orders-service/pkg/health"
+)
+
+func (s *ServerImpl) GetReadiness(w http.ResponseWriter, r *http.Request) {
+	report := s.readiness.Check(r.Context())
+
+	resp := toAPIReadiness(report)
+
+	status := http.StatusOK
+	if !report.Ready() {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		slog.Error("failed to write readiness response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func toAPIReadiness(report health.Report) api.Readiness {
+	resp := api.Readiness{
+		Status:    api.Ready,
+		Timestamp: time.Now(),
+		Checks:    make([]api.ReadinessCheck, 0, len(report.Results)),
+	}
+
+	for _, result := range report.Results {
+		check := api.ReadinessCheck{
+			Name:       result.Name,
+			Status:     api.Up,
+			DurationMs: float64(result.Duration.Microseconds()) / 1000,
+		}
+
+		if result.Err != nil {
+			errMessage := result.Err.Error()
+			check.Status = api.Down
+			check.Error = &errMessage
+			resp.Status = api.NotReady
+		}
+
+		resp.Checks = append(resp.Checks, check)
+	}
+
+	if report.ShuttingDown {
+		resp.Status = api.ShuttingDown
+	}
+
+	return resp
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/leetm4n/orders-service/api"
+	"github.com/leetm4n/orders-service/internal/repo/memory"
+	"github.com/leetm4n/orders-service/internal/service"
+	"github.com/leetm4n/orders-service/pkg/health"
+)
+
+func TestGetReadiness(t *testing.T) {
+	type args struct {
+		check    health.Check
+		shutdown bool
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantStatus  int
+		wantReady   api.ReadinessStatus
+		wantChecked api.ReadinessCheckStatus
+	}{
+		{
+			name:        "should be ready when the checks pass",
+			args:        args{check: func(context.Context) error { return nil }},
+			wantStatus:  http.StatusOK,
+			wantReady:   api.Ready,
+			wantChecked: api.Up,
+		},
+		{
+			name:        "should not be ready when a check fails",
+			args:        args{check: func(context.Context) error { return errors.New("connection refused") }},
+			wantStatus:  http.StatusServiceUnavailable,
+			wantReady:   api.NotReady,
+			wantChecked: api.Down,
+		},
+		{
+			name:        "should not be ready while shutting down",
+			args:        args{check: func(context.Context) error { return nil }, shutdown: true},
+			wantStatus:  http.StatusServiceUnavailable,
+			wantReady:   api.ShuttingDown,
+			wantChecked: api.Up,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readiness := health.NewChecker(0)
+			readiness.Add("database", tt.args.check)
+
+			if tt.args.shutdown {
+				readiness.Shutdown()
+			}
+
+			handler := New(ServerOptions{
+				Orders:    service.NewOrderService(service.OrderServiceOptions{Repository: memory.NewOrderRepository()}),
+				Readiness: readiness,
+			}).server.Handler
+
+			got := serve(handler, http.MethodGet, "/readyz", "", nil)
+			if got.Code != tt.wantStatus {
+				t.Fatalf("GET /readyz status = %d, want %d: %s", got.Code, tt.wantStatus, got.Body)
+			}
+
+			resp := api.Readiness{}
+			if err := json.NewDecoder(got.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode readiness error = %v", err)
+			}
+
+			if resp.Status != tt.wantReady || len(resp.Checks) != 1 || resp.Checks[0].Status != tt.wantChecked {
+				t.Errorf("GET /readyz = %+v, want %q with database %q", resp, tt.wantReady, tt.wantChecked)
+			}
+		})
+	}
+}
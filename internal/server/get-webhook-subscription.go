@@ -0,0 +1,33 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/leetm4n/orders-service/internal/model"
+	"github.com/leetm4n/orders-service/pkg/problem"
+	openapiTypes "github.com/oapi-codegen/runtime/types"
+)
+
+func (s *ServerImpl) GetWebhookSubscription(w http.ResponseWriter, r *http.Request, webhookId openapiTypes.UUID) {
+	subscription, err := s.webhooks.GetWebhookSubscription(r.Context(), webhookId)
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			fail(w, r, problem.New(http.StatusNotFound, codeWebhookNotFound, "webhook subscription not found"))
+			return
+		}
+
+		fail(w, r, fmt.Errorf("get webhook subscription: %w", err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(toAPIWebhookSubscription(subscription)); err != nil {
+		slog.Error("failed to write webhook subscription response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
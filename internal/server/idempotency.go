@@ -0,0 +1,18 @@
+package server
+
+import (
+	"github.com/leetm4n/orders-service/api"
+)
+
+// idempotencyKey prefers the Idempotency-Key header over the deprecated body field.
+func idempotencyKey(params api.CreateOrderParams, request api.CreateOrderRequest) string {
+	if params.IdempotencyKey != nil {
+		return *params.IdempotencyKey
+	}
+
+	if request.IdempotencyKey != nil {
+		return request.IdempotencyKey.String()
+	}
+
+	return ""
+}
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/leetm4n/orders-service/api"
+	openapiTypes "github.com/oapi-codegen/runtime/types"
+)
+
+func TestIdempotencyKey(t *testing.T) {
+	bodyKey := openapiTypes.UUID{}
+	_ = bodyKey.UnmarshalText([]byte("3deb76e4-cd89-4aa3-b143-89e9c0ed11db"))
+
+	headerKey := "header-key"
+
+	type args struct {
+		params  api.CreateOrderParams
+		request api.CreateOrderRequest
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "should prefer the header",
+			args: args{
+				params:  api.CreateOrderParams{IdempotencyKey: &headerKey},
+				request: api.CreateOrderRequest{IdempotencyKey: &bodyKey},
+			},
+			want: headerKey,
+		},
+		{
+			name: "should fall back to the deprecated body field",
+			args: args{request: api.CreateOrderRequest{IdempotencyKey: &bodyKey}},
+			want: "3deb76e4-cd89-4aa3-b143-89e9c0ed11db",
+		},
+		{
+			name: "should be empty without a key",
+			args: args{},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := idempotencyKey(tt.args.params, tt.args.request); got != tt.want {
+				t.Errorf("idempotencyKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/leetm4n/orders-service/api"
+	"github.com/leetm4n/orders-service/internal/model"
+	"github.com/leetm4n/orders-service/internal/service"
+)
+
+const defaultListDeadLetterEventsLimit = 20
+
+// deadLetterCursor is the id of the last dead letter event on a page.
+type deadLetterCursor struct {
+	ID int64 `json:"id"`
+}
+
+func (s *ServerImpl) ListDeadLetterEvents(w http.ResponseWriter, r *http.Request, params api.ListDeadLetterEventsParams) {
+	limit := defaultListDeadLetterEventsLimit
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	// one extra row is fetched to know whether there is a next page
+	filter := model.DeadLetterFilter{
+		EventType: params.EventType,
+		Limit:     limit + 1,
+	}
+
+	if params.Cursor != nil {
+		cursor := deadLetterCursor{}
+		if err := service.DecodeCursor(*params.Cursor, &cursor); err != nil {
+			fail(w, r, err)
+			return
+		}
+
+		filter.BeforeID = &cursor.ID
+	}
+
+	deadLetterEvents, err := s.deadLetters.ListDeadLetterEvents(r.Context(), filter)
+	if err != nil {
+		fail(w, r, fmt.Errorf("list dead letter events: %w", err))
+		return
+	}
+
+	response := api.ListDeadLetterEventsResponse{
+		Items: make([]api.DeadLetterEvent, 0, min(len(deadLetterEvents), limit)),
+	}
+
+	if len(deadLetterEvents) > limit {
+		deadLetterEvents = deadLetterEvents[:limit]
+
+		nextCursor, err := service.EncodeCursor(deadLetterCursor{ID: deadLetterEvents[len(deadLetterEvents)-1].ID})
+		if err != nil {
+			fail(w, r, fmt.Errorf("encode cursor: %w", err))
+			return
+		}
+
+		response.NextCursor = &nextCursor
+	}
+
+	for _, deadLetterEvent := range deadLetterEvents {
+		item, err := toAPIDeadLetterEvent(deadLetterEvent)
+		if err != nil {
+			fail(w, r, fmt.Errorf("map dead letter event %d: %w", deadLetterEvent.ID, err))
+			return
+		}
+
+		response.Items = append(response.Items, item)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("failed to write list dead letter events response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/leetm4n/orders-service/api"
+	"github.com/leetm4n/orders-service/internal/model"
+	"github.com/leetm4n/orders-service/internal/repo/memory"
+	"github.com/leetm4n/orders-service/pkg/problem"
+)
+
+func TestListDeadLetterEventsPages(t *testing.T) {
+	deadLetters := memory.NewDeadLetterRepository(memory.NewOrderRepository())
+	for _, id := range []string{"1", "2", "3"} {
+		if err := deadLetters.CreateDeadLetterEvent(t.Context(), model.DeadLetterEvent{
+			EventID:   id,
+			EventType: "order.created",
+			Payload:   []byte(`{}`),
+			Error:     "failed",
+			Trace:     []byte(`{}`),
+		}); err != nil {
+			t.Fatalf("CreateDeadLetterEvent() error = %v", err)
+		}
+	}
+
+	handler := New(ServerOptions{DeadLetters: deadLetters}).server.Handler
+
+	eventIDs := []string{}
+	target := "/admin/dead-letter-events?limit=2"
+	for pages := 0; target != ""; pages++ {
+		if pages == 3 {
+			t.Fatal("ListDeadLetterEvents() did not stop handing out cursors")
+		}
+
+		got := serve(handler, http.MethodGet, target, "", nil)
+		if got.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d: %s", got.Code, http.StatusOK, got.Body)
+		}
+
+		page := api.ListDeadLetterEventsResponse{}
+		if err := json.NewDecoder(got.Body).Decode(&page); err != nil {
+			t.Fatalf("decode dead letter events error = %v", err)
+		}
+
+		for _, event := range page.Items {
+			eventIDs = append(eventIDs, event.EventId)
+		}
+
+		target = ""
+		if page.NextCursor != nil {
+			target = "/admin/dead-letter-events?limit=2&cursor=" + url.QueryEscape(*page.NextCursor)
+		}
+	}
+
+	if len(eventIDs) != 3 || eventIDs[0] != "3" || eventIDs[2] != "1" {
+		t.Errorf("ListDeadLetterEvents() = %v, want [3 2 1]", eventIDs)
+	}
+
+	got := serve(handler, http.MethodGet, "/admin/dead-letter-events?cursor=invalid", "", nil)
+	details := problem.Details{}
+	if err := json.NewDecoder(got.Body).Decode(&details); err != nil {
+		t.Fatalf("decode problem error = %v", err)
+	}
+
+	if got.Code != http.StatusBadRequest || details.Code != codeInvalidCursor {
+		t.Errorf("problem = %+v, want %q", details, codeInvalidCursor)
+	}
+}
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/leetm4n/orders-service/api"
+	"github.com/leetm4n/orders-service/internal/domain"
+	"github.com/leetm4n/orders-service/internal/service"
+)
+
+func (s *ServerImpl) ListOrders(w http.ResponseWriter, r *http.Request, params api.ListOrdersParams) {
+	in := service.ListOrdersInput{
+		Sku:         params.Sku,
+		CreatedFrom: params.CreatedFrom,
+		CreatedTo:   params.CreatedTo,
+	}
+
+	if params.Limit != nil {
+		in.Limit = *params.Limit
+	}
+
+	if params.Sort != nil {
+		in.Sort = service.SortOrder(*params.Sort)
+	}
+
+	if params.Cursor != nil {
+		in.Cursor = *params.Cursor
+	}
+
+	if params.Status != nil {
+		status := domain.OrderStatus(*params.Status)
+		in.Status = &status
+	}
+
+	orders, nextCursor, err := s.orders.ListOrders(r.Context(), in)
+	if err != nil {
+		fail(w, r, err)
+		return
+	}
+
+	response := api.ListOrdersResponse{
+		Items: make([]api.Order, 0, len(orders)),
+	}
+
+	if nextCursor != "" {
+		response.NextCursor = &nextCursor
+	}
+
+	for _, order := range orders {
+		response.Items = append(response.Items, toAPIOrder(order))
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("failed to write list orders response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
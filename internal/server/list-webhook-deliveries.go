@@ -0,0 +1,54 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/leetm4n/orders-service/api"
+	"github.com/leetm4n/orders-service/internal/model"
+	"github.com/leetm4n/orders-service/pkg/problem"
+	openapiTypes "github.com/oapi-codegen/runtime/types"
+)
+
+const defaultListWebhookDeliveriesLimit = 20
+
+func (s *ServerImpl) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request, webhookId openapiTypes.UUID, params api.ListWebhookDeliveriesParams) {
+	limit := defaultListWebhookDeliveriesLimit
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	if _, err := s.webhooks.GetWebhookSubscription(r.Context(), webhookId); err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			fail(w, r, problem.New(http.StatusNotFound, codeWebhookNotFound, "webhook subscription not found"))
+			return
+		}
+
+		fail(w, r, fmt.Errorf("get webhook subscription: %w", err))
+		return
+	}
+
+	deliveries, err := s.webhooks.ListWebhookDeliveries(r.Context(), webhookId, limit)
+	if err != nil {
+		fail(w, r, fmt.Errorf("list webhook deliveries: %w", err))
+		return
+	}
+
+	response := api.ListWebhookDeliveriesResponse{
+		Items: make([]api.WebhookDelivery, 0, len(deliveries)),
+	}
+
+	for _, delivery := range deliveries {
+		response.Items = append(response.Items, toAPIWebhookDelivery(delivery))
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("failed to write list webhook deliveries response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
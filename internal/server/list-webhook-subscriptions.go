@@ -0,0 +1,33 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/leetm4n/orders-service/api"
+)
+
+func (s *ServerImpl) ListWebhookSubscriptions(w http.ResponseWriter, r *http.Request) {
+	subscriptions, err := s.webhooks.ListWebhookSubscriptions(r.Context())
+	if err != nil {
+		fail(w, r, fmt.Errorf("list webhook subscriptions: %w", err))
+		return
+	}
+
+	response := api.ListWebhookSubscriptionsResponse{
+		Items: make([]api.WebhookSubscription, 0, len(subscriptions)),
+	}
+
+	for _, subscription := range subscriptions {
+		response.Items = append(response.Items, toAPIWebhookSubscription(subscription))
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("failed to write list webhook subscriptions response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/leetm4n/orders-service/api"
+	"github.com/leetm4n/orders-service/internal/model"
+	"github.com/leetm4n/orders-service/internal/service"
+)
+
+// streamOrderEvents writes the order history as server-sent events until the client goes away or the server shuts
+// down. The history id is the event id, so a client reconnecting with Last-Event-ID continues where it stopped.
+func (s *ServerImpl) streamOrderEvents(w http.ResponseWriter, r *http.Request, in service.WatchOrderHistoryInput) {
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-s.shutdown:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	rc := http.NewResponseController(w)
+
+	var (
+		started   bool
+		lastWrite time.Time
+		writeErr  error
+	)
+	err := s.orders.WatchOrderHistory(ctx, in, func(events []model.OrderStatusEvent) error {
+		if !started {
+			// overrides the json content type set by ContentTypeSetterMW
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Header().Set("Cache-Control", "no-cache")
+			w.WriteHeader(http.StatusOK)
+			started = true
+		} else if len(events) == 0 {
+			if time.Since(lastWrite) < s.streamHeartbeatInterval {
+				return nil
+			}
+
+			if _, writeErr = io.WriteString(w, ": heartbeat\n\n"); writeErr != nil {
+				return writeErr
+			}
+		}
+
+		for _, event := range events {
+			if writeErr = writeEvent(w, event.ID, event.Action, toAPIOrderStatusEvent(event)); writeErr != nil {
+				return writeErr
+			}
+		}
+
+		lastWrite = time.Now()
+		writeErr = rc.Flush()
+
+		return writeErr
+	})
+	if ctx.Err() != nil || (writeErr != nil && errors.Is(err, writeErr)) {
+		return
+	}
+
+	if started {
+		// the status is already sent, ending the stream makes the client reconnect with Last-Event-ID
+		slog.Error("failed to stream order events", "error", err)
+		return
+	}
+
+	fail(w, r, err)
+}
+
+// writeEvent writes a server-sent event with data encoded as a single line of json.
+func writeEvent(w io.Writer, id int64, event string, data any) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", id, event, b)
+
+	return err
+}
+
+func toAPIOrderStatusEvent(event model.OrderStatusEvent) api.OrderStatusEvent {
+	apiEvent := api.OrderStatusEvent{
+		Id:        event.ID,
+		OrderId:   event.OrderID,
+		Action:    event.Action,
+		Status:    api.OrderStatus(event.Status),
+		Actor:     event.Actor,
+		TraceId:   event.TraceID,
+		CreatedAt: event.CreatedAt,
+	}
+
+	if event.PreviousStatus != nil {
+		previousStatus := api.OrderStatus(*event.PreviousStatus)
+		apiEvent.PreviousStatus = &previousStatus
+	}
+
+	return apiEvent
+}
@@ -0,0 +1,18 @@
+package server
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteEvent(t *testing.T) {
+	b := bytes.Buffer{}
+	if err := writeEvent(&b, 42, "status_changed", map[string]string{"status": "shipped"}); err != nil {
+		t.Fatalf("writeEvent() error = %v", err)
+	}
+
+	want := "id: 42\nevent: status_changed\ndata: {\"status\":\"shipped\"}\n\n"
+	if b.String() != want {
+		t.Errorf("writeEvent() = %q, want %q", b.String(), want)
+	}
+}
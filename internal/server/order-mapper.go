@@ -0,0 +1,70 @@
+package server
+
+import (
+	"github.com/leetm4n/orders-service/api"
+	"github.com/leetm4n/orders-service/internal/model"
+)
+
+func toAPIOrder(order model.Order) api.Order {
+	apiItems := make([]api.OrderItem, 0, len(order.Items))
+	for _, item := range order.Items {
+		apiItems = append(apiItems, api.OrderItem{
+			Sku:       item.Sku,
+			Quantity:  item.Quantity,
+			UnitPrice: item.UnitPrice,
+		})
+	}
+
+	return api.Order{
+		Id:                    order.ID,
+		Items:                 apiItems,
+		Currency:              order.Currency,
+		Subtotal:              order.Subtotal,
+		Tax:                   order.Tax,
+		Total:                 order.Total,
+		CreatedAt:             order.CreatedAt,
+		UpdatedAt:             order.UpdatedAt,
+		Status:                api.OrderStatus(order.Status),
+		ShippingAddress:       toAPIShippingAddress(order.ShippingAddress),
+		LegacyShippingAddress: toOptional(order.LegacyShippingAddress),
+		Cancellation:          toAPICancellation(order.Cancellation),
+		Version:               int(order.Version),
+	}
+}
+
+// toAPIShippingAddress returns nil for orders created before structured addresses were introduced.
+func toAPIShippingAddress(address *model.Address) *api.ShippingAddress {
+	if address == nil {
+		return nil
+	}
+
+	return &api.ShippingAddress{
+		Name:       address.Name,
+		Lines:      address.Lines,
+		City:       address.City,
+		Region:     toOptional(address.Region),
+		PostalCode: address.PostalCode,
+		Country:    address.Country,
+	}
+}
+
+func toAPICancellation(cancellation *model.Cancellation) *api.OrderCancellation {
+	if cancellation == nil {
+		return nil
+	}
+
+	return &api.OrderCancellation{
+		Reason:     api.CancellationReason(cancellation.Reason),
+		Note:       toOptional(cancellation.Note),
+		CanceledAt: cancellation.CanceledAt,
+	}
+}
+
+// toOptional returns nil for empty strings, which the model uses for absent optional values.
+func toOptional(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
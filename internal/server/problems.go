@@ -0,0 +1,83 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/leetm4n/orders-service/internal/domain"
+	"github.com/leetm4n/orders-service/internal/service"
+	"github.com/leetm4n/orders-service/pkg/problem"
+)
+
+const (
+	codeInvalidCursor                problem.Code = "invalid_cursor"
+	codeOrderNotFound                problem.Code = "order_not_found"
+	codeIllegalStatusTransition      problem.Code = "illegal_status_transition"
+	codeConcurrentModification       problem.Code = "concurrent_modification"
+	codePreconditionRequired         problem.Code = "precondition_required"
+	codeVersionMismatch              problem.Code = "version_mismatch"
+	codeIdempotencyKeyReused         problem.Code = "idempotency_key_reused"
+	codeWebhookNotFound              problem.Code = "webhook_not_found"
+	codeDeadLetterEventNotFound      problem.Code = "dead_letter_event_not_found"
+	codeDeadLetterEventNotReplayable problem.Code = "dead_letter_event_not_replayable"
+)
+
+// fail ends the request with err, errors of the order service are mapped to the problem the client gets.
+func fail(w http.ResponseWriter, r *http.Request, err error) {
+	problem.Fail(w, r, toProblem(err))
+}
+
+func toProblem(err error) error {
+	var validationErr *service.ValidationError
+	switch {
+	case errors.Is(err, service.ErrInvalidCursor):
+		return &problem.Error{Status: http.StatusBadRequest, Code: codeInvalidCursor, Detail: err.Error(), Err: err}
+	case errors.As(err, &validationErr):
+		return &problem.Error{
+			Status: http.StatusBadRequest,
+			Code:   problem.CodeValidationFailed,
+			Detail: validationErr.Error(),
+			Err:    err,
+		}
+	case errors.Is(err, service.ErrOrderNotFound):
+		return &problem.Error{Status: http.StatusNotFound, Code: codeOrderNotFound, Detail: "order not found", Err: err}
+	case errors.Is(err, domain.ErrIllegalStatusTransition):
+		return &problem.Error{Status: http.StatusConflict, Code: codeIllegalStatusTransition, Detail: err.Error(), Err: err}
+	case errors.Is(err, service.ErrConcurrentModification):
+		return &problem.Error{
+			Status: http.StatusPreconditionFailed,
+			Code:   codeConcurrentModification,
+			Detail: service.ErrConcurrentModification.Error(),
+			Err:    err,
+		}
+	case errors.Is(err, service.ErrIdempotencyKeyReused):
+		return &problem.Error{
+			Status: http.StatusUnprocessableEntity,
+			Code:   codeIdempotencyKeyReused,
+			Detail: service.ErrIdempotencyKeyReused.Error(),
+			Err:    err,
+		}
+	default:
+		return err
+	}
+}
+
+// malformedBody is the problem of a request body that cannot be decoded.
+func malformedBody(err error) *problem.Error {
+	return &problem.Error{
+		Status: http.StatusBadRequest,
+		Code:   problem.CodeMalformedRequest,
+		Detail: "request body is not valid json",
+		Err:    err,
+	}
+}
+
+// invalidField is the problem of a request with a single invalid field.
+func invalidField(field string, err error) *problem.Error {
+	return &problem.Error{
+		Status: http.StatusBadRequest,
+		Code:   problem.CodeValidationFailed,
+		Detail: err.Error(),
+		Fields: []problem.FieldError{{Field: field, Message: err.Error()}},
+	}
+}
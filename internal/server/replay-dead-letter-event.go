@@ -0,0 +1,28 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/leetm4n/orders-service/internal/model"
+	"github.com/leetm4n/orders-service/pkg/problem"
+)
+
+func (s *ServerImpl) ReplayDeadLetterEvent(w http.ResponseWriter, r *http.Request, deadLetterEventId int64) {
+	if err := s.deadLetters.ReplayDeadLetterEvent(r.Context(), deadLetterEventId); err != nil {
+		switch {
+		case errors.Is(err, model.ErrNotFound):
+			fail(w, r, problem.New(http.StatusNotFound, codeDeadLetterEventNotFound, "dead letter event not found"))
+		case errors.Is(err, model.ErrConflict):
+			fail(w, r, problem.New(http.StatusConflict, codeDeadLetterEventNotReplayable,
+				"dead letter event has no aggregate id and cannot be replayed"))
+		default:
+			fail(w, r, fmt.Errorf("replay dead letter event: %w", err))
+		}
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
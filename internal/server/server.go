@@ -2,7 +2,6 @@ package server
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -10,38 +9,57 @@ import (
 	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
 	"github.com/leetm4n/orders-service/api"
 	"github.com/leetm4n/orders-service/internal/model"
-	"github.com/leetm4n/orders-service/internal/repo"
+	"github.com/leetm4n/orders-service/internal/service"
+	"github.com/leetm4n/orders-service/pkg/health"
 	"github.com/leetm4n/orders-service/pkg/middlewares"
+	"github.com/leetm4n/orders-service/pkg/webhooks"
 	validationMw "github.com/oapi-codegen/nethttp-middleware"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
-	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 )
 
 var _ api.ServerInterface = (*ServerImpl)(nil)
 
 type ServerImpl struct {
-	queries          *repo.Queries
-	orderCreatedChan chan<- model.OrderCreatedEvent
-	tracer           trace.Tracer
+	orders      *service.OrderService
+	webhooks    model.WebhookRepository
+	deadLetters model.DeadLetterRepository
+	// streams send a heartbeat after streamHeartbeatInterval without events and end once shutdown is closed
+	streamHeartbeatInterval time.Duration
+	shutdown                <-chan struct{}
+	readiness               *health.Checker
+	webhookURLPolicy        webhooks.URLPolicy
 }
 
 type Server struct {
 	port                       int
 	host                       string
 	gracefulShutdownTimeoutSec int
+	readinessDrainDelay        time.Duration
+	readiness                  *health.Checker
 	server                     *http.Server
 }
 
+// ServerOptions configures the server, Webhooks and DeadLetters store what /webhooks and /admin/dead-letter-events
+// serve. Metrics is the handler of /metrics, without it the endpoint is not served. Readiness runs the checks of /readyz,
+// without it the service is ready until it shuts down. ReadinessDrainDelay is how long the server keeps serving after
+// it reported not ready on shutdown, so load balancers stop sending requests before the listener closes.
+// WebhookURLPolicy restricts the urls webhooks can be subscribed with.
 type ServerOptions struct {
 	Port                       int
 	Host                       string
 	GracefulShutdownTimeoutSec int
-	Queries                    *repo.Queries
-	OrderCreatedChan           chan<- model.OrderCreatedEvent
+	Orders                     *service.OrderService
+	Webhooks                   model.WebhookRepository
+	DeadLetters                model.DeadLetterRepository
+	StreamHeartbeatInterval    time.Duration
+	Metrics                    http.Handler
+	Readiness                  *health.Checker
+	ReadinessDrainDelay        time.Duration
+	WebhookURLPolicy           webhooks.URLPolicy
 }
 
 func New(opts ServerOptions) *Server {
@@ -53,53 +71,65 @@ func New(opts ServerOptions) *Server {
 	}
 
 	validationMW := validationMw.OapiRequestValidatorWithOptions(spec, &validationMw.Options{
-		ErrorHandler: func(w http.ResponseWriter, message string, statusCode int) {
-			w.WriteHeader(statusCode)
-			_ = json.NewEncoder(w).Encode(api.ErrorResponse{
-				Error: message,
-				Code:  statusCode,
-			})
-		},
+		Options:              openapi3filter.Options{MultiError: true},
+		ErrorHandlerWithOpts: handleValidationError,
 	})
 
+	// event streams never finish on their own, so they are ended when the server starts shutting down
+	streamsCtx, stopStreams := context.WithCancel(context.Background())
+
+	readiness := opts.Readiness
+	if readiness == nil {
+		readiness = health.NewChecker(0)
+	}
+
 	s := &ServerImpl{
-		queries:          opts.Queries,
-		orderCreatedChan: opts.OrderCreatedChan,
-		tracer:           otel.Tracer("orders-service"),
+		orders:                  opts.Orders,
+		webhooks:                opts.Webhooks,
+		deadLetters:             opts.DeadLetters,
+		streamHeartbeatInterval: opts.StreamHeartbeatInterval,
+		shutdown:                streamsCtx.Done(),
+		readiness:               readiness,
+		webhookURLPolicy:        opts.WebhookURLPolicy,
 	}
 
-	isNotHealthzPath := func(r *http.Request) bool {
-		return r.URL.Path != "/healthz"
+	isNotProbePath := func(r *http.Request) bool {
+		return r.URL.Path != "/livez" && r.URL.Path != "/readyz" && r.URL.Path != "/healthz"
 	}
 
 	handler := middlewares.ContentTypeSetterMW(
 		middlewares.ErrorHandlerMW(
 			middlewares.LoggerMW(
 				validationMW(api.HandlerWithOptions(s, api.StdHTTPServerOptions{
-					BaseRouter: mux,
-					ErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-						w.WriteHeader(http.StatusBadRequest)
-						_ = json.NewEncoder(w).Encode(api.ErrorResponse{
-							Error: err.Error(),
-							Code:  http.StatusBadRequest,
-						})
-					},
+					BaseRouter:       mux,
+					Middlewares:      []api.MiddlewareFunc{middlewares.RouteTagMW},
+					ErrorHandlerFunc: handleParamError,
 				})),
 			),
 		),
 	)
 
-	otelMux := otelhttp.NewHandler(handler, "orders-ms-server", otelhttp.WithFilter(isNotHealthzPath))
+	otelMux := otelhttp.NewHandler(handler, "orders-ms-server", otelhttp.WithFilter(isNotProbePath))
+
+	// metrics are served outside of the api, they are neither validated nor traced
+	root := http.NewServeMux()
+	root.Handle("/", otelMux)
+	if opts.Metrics != nil {
+		root.Handle("GET /metrics", opts.Metrics)
+	}
 
 	server := &http.Server{
 		Addr:    fmt.Sprintf("%s:%d", opts.Host, opts.Port),
-		Handler: otelMux,
+		Handler: root,
 	}
+	server.RegisterOnShutdown(stopStreams)
 
 	return &Server{
 		port:                       opts.Port,
 		host:                       opts.Host,
 		gracefulShutdownTimeoutSec: opts.GracefulShutdownTimeoutSec,
+		readinessDrainDelay:        opts.ReadinessDrainDelay,
+		readiness:                  readiness,
 		server:                     server,
 	}
 }
@@ -109,7 +139,13 @@ func (s *Server) Start(ctx context.Context) error {
 
 	eg, ctx := errgroup.WithContext(ctx)
 
+	// serving is done once the listener closed, nothing can be routed here anymore
+	serving, stopServing := context.WithCancel(context.Background())
+	defer stopServing()
+
 	eg.Go(func() error {
+		defer stopServing()
+
 		if err := s.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			return fmt.Errorf("err running server: %w", err)
 
@@ -121,6 +157,15 @@ func (s *Server) Start(ctx context.Context) error {
 	eg.Go(func() error {
 		<-ctx.Done()
 
+		// report not ready first and keep serving for the drain delay, so no new requests are routed here by the time
+		// the listener closes, a listener that failed ends the delay
+		s.readiness.Shutdown()
+
+		select {
+		case <-time.After(s.readinessDrainDelay):
+		case <-serving.Done():
+		}
+
 		shutdownCtx, cancel := context.WithTimeout(context.Background(),
 			time.Duration(s.gracefulShutdownTimeoutSec)*time.Second)
 		defer cancel()
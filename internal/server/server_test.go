@@ -0,0 +1,301 @@
+package server
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leetm4n/orders-service/api"
+	"github.com/leetm4n/orders-service/internal/repo/memory"
+	"github.com/leetm4n/orders-service/internal/service"
+	"github.com/leetm4n/orders-service/pkg/money"
+)
+
+func newTestHandler(t *testing.T) http.Handler {
+	t.Helper()
+
+	taxRatePercent, err := money.ParseAmount("27")
+	if err != nil {
+		t.Fatalf("ParseAmount() error = %v", err)
+	}
+
+	orders := memory.NewOrderRepository()
+
+	return New(ServerOptions{
+		Orders: service.NewOrderService(service.OrderServiceOptions{
+			Repository:        orders,
+			TaxRatePercent:    taxRatePercent,
+			IdempotencyKeyTTL: time.Hour,
+			EventSource:       "/orders-service",
+			WatchPollInterval: time.Millisecond,
+		}),
+		Webhooks:    memory.NewWebhookRepository(),
+		DeadLetters: memory.NewDeadLetterRepository(orders),
+	}).server.Handler
+}
+
+func TestStartEndsDrainDelayWhenListenerFails(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	defer listener.Close()
+
+	s := New(ServerOptions{
+		Host:                "127.0.0.1",
+		Port:                listener.Addr().(*net.TCPAddr).Port,
+		ReadinessDrainDelay: time.Minute,
+	})
+
+	stopped := make(chan error)
+	go func() {
+		stopped <- s.Start(t.Context())
+	}()
+
+	select {
+	case err := <-stopped:
+		if err == nil {
+			t.Error("Start() error = nil, want the listen error")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Start() waited for the drain delay after the listener failed")
+	}
+}
+
+func serve(handler http.Handler, method, target, body string, header http.Header) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		r.Header.Set("Content-Type", "application/json")
+	}
+
+	for key, values := range header {
+		r.Header[key] = values
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	return w
+}
+
+func TestOrderLifecycleWithMemoryStorage(t *testing.T) {
+	handler := newTestHandler(t)
+
+	created := serve(handler, http.MethodPost, "/orders", `{
+		"items": [{"sku": "3deb76e4-cd89-4aa3-b143-89e9c0ed11ad", "quantity": 4, "unitPrice": "19.99"}],
+		"currency": "EUR",
+		"shippingAddress": {
+			"name": "John Doe", "lines": ["Andrassy ut 1."], "city": "Budapest", "postalCode": "1061", "country": "HU"
+		}
+	}`, nil)
+	if created.Code != http.StatusCreated {
+		t.Fatalf("POST /orders status = %d, want %d: %s", created.Code, http.StatusCreated, created.Body)
+	}
+
+	order := api.Order{}
+	if err := json.NewDecoder(created.Body).Decode(&order); err != nil {
+		t.Fatalf("decode order error = %v", err)
+	}
+
+	etag := created.Header().Get("ETag")
+	orderPath := "/orders/" + order.Id.String()
+
+	type args struct {
+		method string
+		target string
+		body   string
+		header http.Header
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantStatus int
+		// wantHeader holds headers the response must have, an empty value means the header must be missing
+		wantHeader http.Header
+	}{
+		{
+			name:       "should get the created order",
+			args:       args{method: http.MethodGet, target: orderPath},
+			wantStatus: http.StatusOK,
+			wantHeader: http.Header{"Content-Type": {"application/json"}},
+		},
+		{
+			name:       "should not return an unchanged order",
+			args:       args{method: http.MethodGet, target: orderPath, header: http.Header{"If-None-Match": {etag}}},
+			wantStatus: http.StatusNotModified,
+			wantHeader: http.Header{"Content-Type": {""}, "Etag": {etag}},
+		},
+		{
+			name: "should transition the current version",
+			args: args{
+				method: http.MethodPost,
+				target: orderPath + "/transitions",
+				body:   `{"status": "shipped"}`,
+				header: http.Header{"If-Match": {etag}},
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "should reject a stale version",
+			args: args{
+				method: http.MethodPost,
+				target: orderPath + "/transitions",
+				body:   `{"status": "delivered"}`,
+				header: http.Header{"If-Match": {etag}},
+			},
+			wantStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:       "should list the order history",
+			args:       args{method: http.MethodGet, target: orderPath + "/history"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "should keep serving the former liveness probe",
+			args:       args{method: http.MethodGet, target: "/healthz"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "should list webhooks",
+			args:       args{method: http.MethodGet, target: "/webhooks"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "should list dead letters",
+			args:       args{method: http.MethodGet, target: "/admin/dead-letter-events"},
+			wantStatus: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := serve(handler, tt.args.method, tt.args.target, tt.args.body, tt.args.header)
+			if got.Code != tt.wantStatus {
+				t.Errorf("%s %s status = %d, want %d: %s", tt.args.method, tt.args.target, got.Code,
+					tt.wantStatus, got.Body)
+			}
+
+			for key := range tt.wantHeader {
+				if got.Header().Get(key) != tt.wantHeader.Get(key) {
+					t.Errorf("%s %s header %s = %q, want %q", tt.args.method, tt.args.target, key,
+						got.Header().Get(key), tt.wantHeader.Get(key))
+				}
+			}
+		})
+	}
+}
+
+func TestWebhookSubscriptionLifecycleWithMemoryStorage(t *testing.T) {
+	handler := newTestHandler(t)
+
+	created := serve(handler, http.MethodPost, "/webhooks", `{
+		"url": "https://hooks.example.com/orders", "eventTypes": ["order.created"]
+	}`, nil)
+	if created.Code != http.StatusCreated {
+		t.Fatalf("POST /webhooks status = %d, want %d: %s", created.Code, http.StatusCreated, created.Body)
+	}
+
+	subscription := api.WebhookSubscription{}
+	if err := json.NewDecoder(created.Body).Decode(&subscription); err != nil {
+		t.Fatalf("decode webhook subscription error = %v", err)
+	}
+
+	if subscription.Secret == nil || *subscription.Secret == "" {
+		t.Errorf("POST /webhooks secret = %v, want a generated secret", subscription.Secret)
+	}
+
+	webhookPath := "/webhooks/" + subscription.Id.String()
+
+	type args struct {
+		method string
+		target string
+		body   string
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantStatus int
+	}{
+		{
+			name:       "should get the created subscription",
+			args:       args{method: http.MethodGet, target: webhookPath},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "should disable the subscription",
+			args:       args{method: http.MethodPatch, target: webhookPath, body: `{"active": false}`},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "should list the deliveries of the subscription",
+			args:       args{method: http.MethodGet, target: webhookPath + "/deliveries"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "should delete the subscription",
+			args:       args{method: http.MethodDelete, target: webhookPath},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "should not find the deleted subscription",
+			args:       args{method: http.MethodGet, target: webhookPath},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "should not find a missing dead letter",
+			args:       args{method: http.MethodPost, target: "/admin/dead-letter-events/1/replay"},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := serve(handler, tt.args.method, tt.args.target, tt.args.body, nil)
+			if got.Code != tt.wantStatus {
+				t.Errorf("%s %s status = %d, want %d: %s", tt.args.method, tt.args.target, got.Code,
+					tt.wantStatus, got.Body)
+			}
+		})
+	}
+}
+
+func TestMetricsEndpoint(t *testing.T) {
+	metrics := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("orders_created_total 1\n"))
+	})
+
+	type args struct {
+		metrics http.Handler
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantStatus int
+	}{
+		{
+			name:       "should serve metrics bypassing the api validation",
+			args:       args{metrics: metrics},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "should not serve metrics without a handler",
+			args:       args{},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := New(ServerOptions{
+				Orders:  service.NewOrderService(service.OrderServiceOptions{Repository: memory.NewOrderRepository()}),
+				Metrics: tt.args.metrics,
+			}).server.Handler
+
+			got := serve(handler, http.MethodGet, "/metrics", "", nil)
+			if got.Code != tt.wantStatus {
+				t.Errorf("GET /metrics status = %d, want %d: %s", got.Code, tt.wantStatus, got.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/leetm4n/orders-service/api"
+	"github.com/leetm4n/orders-service/internal/service"
+)
+
+func (s *ServerImpl) StreamAllOrderEvents(w http.ResponseWriter, r *http.Request, params api.StreamAllOrderEventsParams) {
+	s.streamOrderEvents(w, r, service.WatchOrderHistoryInput{
+		AfterID: params.LastEventID,
+	})
+}
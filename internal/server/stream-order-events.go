@@ -0,0 +1,16 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/leetm4n/orders-service/api"
+	"github.com/leetm4n/orders-service/internal/service"
+	openapiTypes "github.com/oapi-codegen/runtime/types"
+)
+
+func (s *ServerImpl) StreamOrderEvents(w http.ResponseWriter, r *http.Request, orderId openapiTypes.UUID, params api.StreamOrderEventsParams) {
+	s.streamOrderEvents(w, r, service.WatchOrderHistoryInput{
+		OrderID: &orderId,
+		AfterID: params.LastEventID,
+	})
+}
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/leetm4n/orders-service/api"
+	"github.com/leetm4n/orders-service/internal/domain"
+	"github.com/leetm4n/orders-service/internal/service"
+	openapiTypes "github.com/oapi-codegen/runtime/types"
+)
+
+func (s *ServerImpl) TransitionOrder(w http.ResponseWriter, r *http.Request, orderId openapiTypes.UUID, params api.TransitionOrderParams) {
+	requestBody := api.TransitionOrderRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		fail(w, r, malformedBody(err))
+		return
+	}
+
+	order, err := s.orders.GetOrder(r.Context(), orderId)
+	if err != nil {
+		fail(w, r, err)
+		return
+	}
+
+	if !checkIfMatch(w, r, params.IfMatch, order.Version) {
+		return
+	}
+
+	updatedOrder, err := s.orders.TransitionOrder(r.Context(), service.TransitionOrderInput{
+		ID:      orderId,
+		Status:  domain.OrderStatus(requestBody.Status),
+		Version: order.Version,
+		Actor:   params.XActor,
+	})
+	if err != nil {
+		fail(w, r, err)
+		return
+	}
+
+	w.Header().Set("ETag", orderETag(updatedOrder.Version))
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(toAPIOrder(updatedOrder)); err != nil {
+		slog.Error("failed to write order response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
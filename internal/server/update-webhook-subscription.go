@@ -0,0 +1,59 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/leetm4n/orders-service/api"
+	"github.com/leetm4n/orders-service/internal/model"
+	"github.com/leetm4n/orders-service/pkg/problem"
+	openapiTypes "github.com/oapi-codegen/runtime/types"
+)
+
+func (s *ServerImpl) UpdateWebhookSubscription(w http.ResponseWriter, r *http.Request, webhookId openapiTypes.UUID) {
+	requestBody := api.UpdateWebhookSubscriptionRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		fail(w, r, malformedBody(err))
+		return
+	}
+
+	update := model.WebhookSubscriptionUpdate{
+		URL:        requestBody.Url,
+		EventTypes: fromAPIWebhookEventTypes(requestBody.EventTypes),
+		Active:     requestBody.Active,
+	}
+
+	// an explicitly empty filter subscribes to every event type, which needs a non nil slice
+	if requestBody.EventTypes != nil && update.EventTypes == nil {
+		update.EventTypes = []string{}
+	}
+
+	if requestBody.Url != nil {
+		if err := s.webhookURLPolicy.CheckURL(*requestBody.Url); err != nil {
+			fail(w, r, invalidField("/url", err))
+			return
+		}
+	}
+
+	subscription, err := s.webhooks.UpdateWebhookSubscription(r.Context(), webhookId, update)
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			fail(w, r, problem.New(http.StatusNotFound, codeWebhookNotFound, "webhook subscription not found"))
+			return
+		}
+
+		fail(w, r, fmt.Errorf("update webhook subscription: %w", err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(toAPIWebhookSubscription(subscription)); err != nil {
+		slog.Error("failed to write webhook subscription response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,146 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/leetm4n/orders-service/api"
+	"github.com/leetm4n/orders-service/pkg/problem"
+	validationMw "github.com/oapi-codegen/nethttp-middleware"
+)
+
+// handleValidationError fails requests the openapi validator rejected, every invalid parameter and field of the
+// body is listed in the problem.
+func handleValidationError(
+	_ context.Context,
+	err error,
+	w http.ResponseWriter,
+	r *http.Request,
+	opts validationMw.ErrorHandlerOpts,
+) {
+	var requestErr *openapi3filter.RequestError
+	var parseErr *openapi3filter.ParseError
+	switch {
+	case errors.Is(err, routers.ErrMethodNotAllowed):
+		problem.Fail(w, r, problem.New(http.StatusMethodNotAllowed, problem.CodeMethodNotAllowed, err.Error()))
+	case opts.StatusCode == http.StatusNotFound:
+		problem.Fail(w, r, problem.New(http.StatusNotFound, problem.CodeNotFound, err.Error()))
+	case opts.StatusCode != http.StatusBadRequest:
+		problem.Fail(w, r, err)
+	case errors.As(err, &requestErr) && requestErr.RequestBody != nil && errors.As(requestErr.Err, &parseErr):
+		problem.Fail(w, r, malformedBody(err))
+	default:
+		problem.Fail(w, r, &problem.Error{
+			Status: http.StatusBadRequest,
+			Code:   problem.CodeValidationFailed,
+			Detail: "request does not match the api specification",
+			Fields: fieldErrors(err),
+			Err:    err,
+		})
+	}
+}
+
+// handleParamError fails requests whose parameters cannot be bound to the generated types.
+func handleParamError(w http.ResponseWriter, r *http.Request, err error) {
+	e := &problem.Error{
+		Status: http.StatusBadRequest,
+		Code:   problem.CodeValidationFailed,
+		Detail: err.Error(),
+		Err:    err,
+	}
+
+	if name := paramName(err); name != "" {
+		e.Fields = []problem.FieldError{{Field: name, Message: err.Error()}}
+	}
+
+	problem.Fail(w, r, e)
+}
+
+func fieldErrors(err error) []problem.FieldError {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		fields := []problem.FieldError{}
+		for _, err := range e {
+			fields = append(fields, fieldErrors(err)...)
+		}
+
+		return fields
+	case *openapi3filter.RequestError:
+		if e.Parameter != nil {
+			return []problem.FieldError{{Field: e.Parameter.Name, Message: requestErrorMessage(e)}}
+		}
+
+		return bodyFieldErrors(e.Err, requestErrorMessage(e))
+	default:
+		return []problem.FieldError{{Field: "", Message: err.Error()}}
+	}
+}
+
+// bodyFieldErrors points at the invalid fields of the body, errors not caused by the schema point at the whole body.
+func bodyFieldErrors(err error, message string) []problem.FieldError {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		fields := []problem.FieldError{}
+		for _, err := range e {
+			fields = append(fields, bodyFieldErrors(err, message)...)
+		}
+
+		return fields
+	case *openapi3.SchemaError:
+		return []problem.FieldError{{Field: jsonPointer(e.JSONPointer()), Message: e.Reason}}
+	default:
+		return []problem.FieldError{{Field: "", Message: message}}
+	}
+}
+
+func requestErrorMessage(e *openapi3filter.RequestError) string {
+	var schemaErr *openapi3.SchemaError
+	switch {
+	case errors.As(e.Err, &schemaErr):
+		return schemaErr.Reason
+	case e.Err != nil:
+		return e.Err.Error()
+	default:
+		return e.Reason
+	}
+}
+
+// jsonPointer formats the path as RFC 6901 JSON pointer, the pointer of the whole document is empty.
+func jsonPointer(path []string) string {
+	var b strings.Builder
+	for _, token := range path {
+		b.WriteString("/")
+		b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(token))
+	}
+
+	return b.String()
+}
+
+func paramName(err error) string {
+	var (
+		invalidFormatErr *api.InvalidParamFormatError
+		requiredErr      *api.RequiredParamError
+		requiredHeadErr  *api.RequiredHeaderError
+		unmarshalingErr  *api.UnmarshalingParamError
+		tooManyValuesErr *api.TooManyValuesForParamError
+	)
+	switch {
+	case errors.As(err, &invalidFormatErr):
+		return invalidFormatErr.ParamName
+	case errors.As(err, &requiredErr):
+		return requiredErr.ParamName
+	case errors.As(err, &requiredHeadErr):
+		return requiredHeadErr.ParamName
+	case errors.As(err, &unmarshalingErr):
+		return unmarshalingErr.ParamName
+	case errors.As(err, &tooManyValuesErr):
+		return tooManyValuesErr.ParamName
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/leetm4n/orders-service/pkg/problem"
+)
+
+func TestRequestProblems(t *testing.T) {
+	handler := newTestHandler(t)
+
+	type args struct {
+		method string
+		target string
+		body   string
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantStatus int
+		wantCode   problem.Code
+		wantFields []string
+	}{
+		{
+			name: "should list every invalid field of the body",
+			args: args{method: http.MethodPost, target: "/orders", body: `{
+				"items": [{"sku": "3deb76e4-cd89-4aa3-b143-89e9c0ed11ad", "quantity": 0, "unitPrice": "x"}],
+				"currency": "EUR",
+				"shippingAddress": {
+					"name": "John Doe", "lines": ["Andrassy ut 1."], "city": "Budapest", "postalCode": "1061",
+					"country": "HU"
+				}
+			}`},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   problem.CodeValidationFailed,
+			wantFields: []string{"/items/0/quantity", "/items/0/unitPrice"},
+		},
+		{
+			name:       "should point at invalid parameters",
+			args:       args{method: http.MethodGet, target: "/orders?limit=0"},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   problem.CodeValidationFailed,
+			wantFields: []string{"limit"},
+		},
+		{
+			name:       "should reject a body that is not json",
+			args:       args{method: http.MethodPost, target: "/orders", body: `{"items": `},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   problem.CodeMalformedRequest,
+		},
+		{
+			name:       "should map service errors",
+			args:       args{method: http.MethodGet, target: "/orders/3deb76e4-cd89-4aa3-b143-89e9c0ed11ad"},
+			wantStatus: http.StatusNotFound,
+			wantCode:   codeOrderNotFound,
+		},
+		{
+			name:       "should not find unknown paths",
+			args:       args{method: http.MethodGet, target: "/unknown"},
+			wantStatus: http.StatusNotFound,
+			wantCode:   problem.CodeNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := serve(handler, tt.args.method, tt.args.target, tt.args.body, nil)
+			if got.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", got.Code, tt.wantStatus, got.Body)
+			}
+
+			if contentType := got.Header().Get("Content-Type"); contentType != problem.ContentType {
+				t.Errorf("Content-Type = %q, want %q", contentType, problem.ContentType)
+			}
+
+			details := problem.Details{}
+			if err := json.NewDecoder(got.Body).Decode(&details); err != nil {
+				t.Fatalf("decode problem error = %v", err)
+			}
+
+			wantInstance, _, _ := strings.Cut(tt.args.target, "?")
+			if details.Code != tt.wantCode || details.Status != tt.wantStatus || details.Instance != wantInstance {
+				t.Errorf("problem = %+v, want code %q at %q", details, tt.wantCode, wantInstance)
+			}
+
+			fields := []string{}
+			for _, field := range details.Errors {
+				fields = append(fields, field.Field)
+			}
+
+			for _, want := range tt.wantFields {
+				if !slices.Contains(fields, want) {
+					t.Errorf("problem fields = %v, want %q among them", fields, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"crypto/rand"
+
+	"github.com/leetm4n/orders-service/api"
+	"github.com/leetm4n/orders-service/internal/model"
+	openapiTypes "github.com/oapi-codegen/runtime/types"
+)
+
+// generateWebhookSecret returns a random secret with 128 bits of entropy.
+func generateWebhookSecret() string {
+	return rand.Text()
+}
+
+func fromAPIWebhookEventTypes(eventTypes *[]api.WebhookEventType) []string {
+	if eventTypes == nil {
+		return nil
+	}
+
+	result := make([]string, 0, len(*eventTypes))
+	for _, eventType := range *eventTypes {
+		result = append(result, string(eventType))
+	}
+
+	return result
+}
+
+func toAPIWebhookSubscription(subscription model.WebhookSubscription) api.WebhookSubscription {
+	result := api.WebhookSubscription{
+		Id:                  openapiTypes.UUID(subscription.ID),
+		Url:                 subscription.URL,
+		EventTypes:          make([]api.WebhookEventType, 0, len(subscription.EventTypes)),
+		Active:              subscription.Active,
+		ConsecutiveFailures: subscription.ConsecutiveFailures,
+		DisabledAt:          subscription.DisabledAt,
+		CreatedAt:           subscription.CreatedAt,
+		UpdatedAt:           subscription.UpdatedAt,
+	}
+
+	for _, eventType := range subscription.EventTypes {
+		result.EventTypes = append(result.EventTypes, api.WebhookEventType(eventType))
+	}
+
+	return result
+}
+
+func toAPIWebhookDelivery(delivery model.WebhookDelivery) api.WebhookDelivery {
+	return api.WebhookDelivery{
+		Id:             openapiTypes.UUID(delivery.ID),
+		EventId:        delivery.EventID,
+		EventType:      api.WebhookEventType(delivery.EventType),
+		Status:         api.WebhookDeliveryStatus(delivery.Status),
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+	}
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/leetm4n/orders-service/internal/domain"
+	"github.com/leetm4n/orders-service/internal/model"
+)
+
+type CancelOrderInput struct {
+	ID     uuid.UUID
+	Reason string
+	Note   *string
+	// Version the change is based on, the change fails with ErrConcurrentModification when the order moved on.
+	Version int32
+	Actor   *string
+}
+
+// CancelOrder cancels the order, orders past pending fail with domain.ErrIllegalStatusTransition.
+func (s *OrderService) CancelOrder(ctx context.Context, in CancelOrderInput) (model.Order, error) {
+	order, err := getOrder(ctx, s.orders, in.ID)
+	if err != nil {
+		return model.Order{}, err
+	}
+
+	if order.Version != in.Version {
+		return model.Order{}, ErrConcurrentModification
+	}
+
+	if _, err := order.Status.TransitionTo(domain.OrderStatusCanceled); err != nil {
+		return model.Order{}, err
+	}
+
+	var canceledOrder model.Order
+	err = s.orders.InTx(ctx, func(repo model.OrderRepository) error {
+		// status and version are compared again in the update, so a concurrent change results in a conflict instead
+		// of a lost update
+		canceledOrder, err = repo.CancelOrder(ctx, order, in.Reason, in.Note)
+		if err != nil {
+			if errors.Is(err, model.ErrConflict) {
+				return ErrConcurrentModification
+			}
+
+			return fmt.Errorf("cancel order: %w", err)
+		}
+
+		if err := recordHistory(ctx, repo, canceledOrder.ID, model.HistoryActionCanceled, in.Actor,
+			map[string]any{"status": order.Status},
+			map[string]any{"status": canceledOrder.Status, "cancellation": canceledOrder.Cancellation},
+		); err != nil {
+			return fmt.Errorf("record order history: %w", err)
+		}
+
+		if err := s.emitEvent(ctx, repo, canceledOrder.ID, model.OrderCanceledEventType, model.OrderCanceledEvent{
+			Order: canceledOrder,
+		}); err != nil {
+			return fmt.Errorf("write order canceled event to outbox: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return model.Order{}, err
+	}
+
+	s.metrics.statusChanged(ctx, order.Status, canceledOrder.Status)
+
+	return canceledOrder, nil
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/leetm4n/orders-service/internal/domain"
+	"github.com/leetm4n/orders-service/internal/repo/memory"
+)
+
+func TestCancelOrder(t *testing.T) {
+	note := "changed my mind"
+
+	type args struct {
+		// shipped ships the seeded order before canceling it
+		shipped bool
+		note    *string
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantStatus domain.OrderStatus
+		wantNote   string
+		wantErr    error
+	}{
+		{
+			name:       "should cancel pending order with note",
+			args:       args{note: &note},
+			wantStatus: domain.OrderStatusCanceled,
+			wantNote:   note,
+		},
+		{
+			name:       "should cancel pending order without note",
+			args:       args{},
+			wantStatus: domain.OrderStatusCanceled,
+		},
+		{
+			name:    "should reject canceling shipped order",
+			args:    args{shipped: true},
+			wantErr: domain.ErrIllegalStatusTransition,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(t, memory.NewOrderRepository())
+			order := seedOrder(t, s)
+
+			if tt.args.shipped {
+				var err error
+				order, err = s.TransitionOrder(t.Context(), TransitionOrderInput{
+					ID:      order.ID,
+					Status:  domain.OrderStatusShipped,
+					Version: order.Version,
+				})
+				if err != nil {
+					t.Fatalf("TransitionOrder() error = %v", err)
+				}
+			}
+
+			got, err := s.CancelOrder(t.Context(), CancelOrderInput{
+				ID:      order.ID,
+				Reason:  "customer_request",
+				Note:    tt.args.note,
+				Version: order.Version,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CancelOrder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got.Status != tt.wantStatus {
+				t.Errorf("CancelOrder() status = %s, want %s", got.Status, tt.wantStatus)
+			}
+			if tt.wantErr == nil && (got.Cancellation == nil || got.Cancellation.Note != tt.wantNote) {
+				t.Errorf("CancelOrder() cancellation = %+v, want note %q", got.Cancellation, tt.wantNote)
+			}
+		})
+	}
+}
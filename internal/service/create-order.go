@@ -0,0 +1,312 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/leetm4n/orders-service/internal/domain"
+	"github.com/leetm4n/orders-service/internal/model"
+	"github.com/leetm4n/orders-service/pkg/money"
+)
+
+// MaxQuantity is the largest quantity of an order item both APIs accept.
+const MaxQuantity = 1000
+
+// the limits of the OpenAPI schema of the REST API, lengths count characters
+const (
+	maxOrderItems           = 100
+	maxAddressLines         = 3
+	maxNameLength           = 200
+	maxAddressLineLength    = 200
+	maxCityLength           = 100
+	maxRegionLength         = 100
+	maxPostalCodeLength     = 20
+	maxIdempotencyKeyLength = 255
+)
+
+var (
+	errInvalidUnitPrice = errors.New("unit price must be a non-negative decimal amount below 10^9")
+	errInvalidQuantity  = fmt.Errorf("quantity must be between 1 and %d", MaxQuantity)
+	errInvalidCountry   = errors.New("country must be an ISO 3166-1 alpha-2 code")
+
+	// unitPricePattern is the pattern of the UnitPrice schema of the REST API
+	unitPricePattern = regexp.MustCompile(`^\d{1,9}(\.\d{1,3})?$`)
+	countryPattern   = regexp.MustCompile(`^[A-Z]{2}$`)
+)
+
+type OrderItemInput struct {
+	Sku       uuid.UUID
+	Quantity  int
+	UnitPrice string
+}
+
+type AddressInput struct {
+	Name       string
+	Lines      []string
+	City       string
+	Region     *string
+	PostalCode string
+	Country    string
+}
+
+type CreateOrderInput struct {
+	Items           []OrderItemInput
+	Currency        string
+	ShippingAddress AddressInput
+	Actor           *string
+	// IdempotencyKey is optional, a key reused with another order is rejected.
+	IdempotencyKey string
+}
+
+// CreateOrder creates the order and reports whether it was created earlier by a request with the same
+// idempotency key instead.
+func (s *OrderService) CreateOrder(ctx context.Context, in CreateOrderInput) (model.Order, bool, error) {
+	newOrder, err := s.newOrder(in)
+	if err != nil {
+		return model.Order{}, false, err
+	}
+
+	requestHash := ""
+	if in.IdempotencyKey != "" {
+		requestHash, err = requestFingerprint(newOrder)
+		if err != nil {
+			return model.Order{}, false, fmt.Errorf("fingerprint request: %w", err)
+		}
+	}
+
+	var (
+		order    model.Order
+		replayed bool
+	)
+	err = s.orders.InTx(ctx, func(repo model.OrderRepository) error {
+		if in.IdempotencyKey != "" {
+			// a concurrent request with the same key blocks here until the other transaction finishes
+			if err := repo.ClaimIdempotencyKey(ctx, in.IdempotencyKey, requestHash, s.idempotencyKeyTTL); err != nil {
+				if errors.Is(err, model.ErrConflict) {
+					replayed = true
+					order, err = replayCreateOrder(ctx, repo, in.IdempotencyKey, requestHash)
+
+					return err
+				}
+
+				return fmt.Errorf("claim idempotency key: %w", err)
+			}
+		}
+
+		createdOrder, err := repo.CreateOrder(ctx, newOrder)
+		if err != nil {
+			return fmt.Errorf("create order: %w", err)
+		}
+
+		if in.IdempotencyKey != "" {
+			if err := repo.SetIdempotencyKeyOrder(ctx, in.IdempotencyKey, createdOrder.ID); err != nil {
+				return fmt.Errorf("set order of idempotency key: %w", err)
+			}
+		}
+
+		if err := recordHistory(ctx, repo, createdOrder.ID, model.HistoryActionCreated, in.Actor, nil, createdOrder); err != nil {
+			return fmt.Errorf("record order history: %w", err)
+		}
+
+		if err := s.emitEvent(ctx, repo, createdOrder.ID, model.OrderCreatedEventType, model.OrderCreatedEvent{
+			Order: createdOrder,
+		}); err != nil {
+			return fmt.Errorf("write order created event to outbox: %w", err)
+		}
+
+		order = createdOrder
+
+		return nil
+	})
+	if err != nil {
+		return model.Order{}, false, err
+	}
+
+	if !replayed {
+		s.metrics.orderCreated(ctx, order)
+	}
+
+	return order, replayed, nil
+}
+
+// newOrder validates the input and calculates the totals of the order it describes.
+func (s *OrderService) newOrder(in CreateOrderInput) (model.NewOrder, error) {
+	if err := validateCreateOrderInput(in); err != nil {
+		return model.NewOrder{}, &ValidationError{Err: err}
+	}
+
+	items := make([]model.NewOrderItem, 0, len(in.Items))
+	lineItems := make([]domain.LineItem, 0, len(in.Items))
+	for _, item := range in.Items {
+		if item.Quantity < 1 || item.Quantity > MaxQuantity {
+			return model.NewOrder{}, &ValidationError{Err: errInvalidQuantity}
+		}
+
+		if !unitPricePattern.MatchString(item.UnitPrice) {
+			return model.NewOrder{}, &ValidationError{Err: errInvalidUnitPrice}
+		}
+
+		unitPrice, err := money.ParseAmount(item.UnitPrice)
+		if err != nil {
+			return model.NewOrder{}, &ValidationError{Err: errInvalidUnitPrice}
+		}
+
+		items = append(items, model.NewOrderItem{Sku: item.Sku, Quantity: item.Quantity, UnitPrice: unitPrice})
+		lineItems = append(lineItems, domain.LineItem{Quantity: int64(item.Quantity), UnitPrice: unitPrice})
+	}
+
+	totals, err := domain.CalculateTotals(in.Currency, lineItems, s.taxRatePercent)
+	if err != nil {
+		return model.NewOrder{}, &ValidationError{Err: err}
+	}
+
+	address := in.ShippingAddress
+	country := strings.ToUpper(address.Country)
+	if !countryPattern.MatchString(country) {
+		return model.NewOrder{}, &ValidationError{Err: errInvalidCountry}
+	}
+
+	postalCode, err := domain.NormalizePostalCode(country, address.PostalCode)
+	if err != nil {
+		return model.NewOrder{}, &ValidationError{Err: err}
+	}
+
+	newOrder := model.NewOrder{
+		Currency: in.Currency,
+		TaxRate:  s.taxRatePercent,
+		Subtotal: totals.Subtotal,
+		Tax:      totals.Tax,
+		Total:    totals.Total,
+		Items:    items,
+		ShippingAddress: model.Address{
+			Name:       address.Name,
+			Lines:      address.Lines,
+			City:       address.City,
+			PostalCode: postalCode,
+			Country:    country,
+		},
+	}
+
+	if address.Region != nil {
+		newOrder.ShippingAddress.Region = *address.Region
+	}
+
+	return newOrder, nil
+}
+
+// validateCreateOrderInput checks the sizes the OpenAPI schema checks for the REST API, so gRPC requests are held to
+// the same limits. Amounts, currency, country and postal code are validated while the order is built.
+func validateCreateOrderInput(in CreateOrderInput) error {
+	if len(in.Items) == 0 || len(in.Items) > maxOrderItems {
+		return fmt.Errorf("items must contain 1 to %d items", maxOrderItems)
+	}
+
+	if utf8.RuneCountInString(in.IdempotencyKey) > maxIdempotencyKeyLength {
+		return fmt.Errorf("idempotency key must be at most %d characters", maxIdempotencyKeyLength)
+	}
+
+	address := in.ShippingAddress
+	switch {
+	case !validLength(address.Name, 1, maxNameLength):
+		return fmt.Errorf("shipping address name must be 1 to %d characters", maxNameLength)
+	case len(address.Lines) == 0 || len(address.Lines) > maxAddressLines:
+		return fmt.Errorf("shipping address must have 1 to %d lines", maxAddressLines)
+	case !validLength(address.City, 1, maxCityLength):
+		return fmt.Errorf("shipping address city must be 1 to %d characters", maxCityLength)
+	case address.Region != nil && !validLength(*address.Region, 0, maxRegionLength):
+		return fmt.Errorf("shipping address region must be at most %d characters", maxRegionLength)
+	case !validLength(address.PostalCode, 1, maxPostalCodeLength):
+		return fmt.Errorf("shipping address postal code must be 1 to %d characters", maxPostalCodeLength)
+	}
+
+	for i, line := range address.Lines {
+		if !validLength(line, 1, maxAddressLineLength) {
+			return fmt.Errorf("shipping address line %d must be 1 to %d characters", i+1, maxAddressLineLength)
+		}
+	}
+
+	return nil
+}
+
+func validLength(s string, minLength, maxLength int) bool {
+	n := utf8.RuneCountInString(s)
+
+	return n >= minLength && n <= maxLength
+}
+
+// requestFingerprint hashes the items, currency and shipping address of the validated order, so requests describing
+// the same order in a different notation (e.g. "19.9" and "19.90") match. It is computed from the service input, so
+// the same order sent over REST and gRPC has the same fingerprint.
+func requestFingerprint(order model.NewOrder) (string, error) {
+	// prices were validated against the minor unit of the currency
+	scale, err := money.MinorUnits(order.Currency)
+	if err != nil {
+		return "", err
+	}
+
+	type item struct {
+		Sku       uuid.UUID
+		Quantity  int
+		UnitPrice string
+	}
+
+	items := make([]item, 0, len(order.Items))
+	for _, orderItem := range order.Items {
+		items = append(items, item{
+			Sku:       orderItem.Sku,
+			Quantity:  orderItem.Quantity,
+			UnitPrice: money.FormatAmount(orderItem.UnitPrice, scale),
+		})
+	}
+
+	b, err := json.Marshal(struct {
+		Items           []item
+		Currency        string
+		ShippingAddress model.Address
+	}{
+		Items:           items,
+		Currency:        order.Currency,
+		ShippingAddress: order.ShippingAddress,
+	})
+	if err != nil {
+		return "", fmt.Errorf("err marshalling request: %w", err)
+	}
+
+	sum := sha256.Sum256(b)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// replayCreateOrder returns the order created by the first request with the idempotency key, provided it was made
+// with the same request.
+func replayCreateOrder(ctx context.Context, repo model.OrderRepository, key, requestHash string) (model.Order, error) {
+	idempotencyKey, err := repo.GetIdempotencyKey(ctx, key)
+	if err != nil {
+		return model.Order{}, fmt.Errorf("get idempotency key: %w", err)
+	}
+
+	// keys migrated from the request body were stored without a fingerprint
+	if idempotencyKey.RequestHash != "" && idempotencyKey.RequestHash != requestHash {
+		return model.Order{}, ErrIdempotencyKeyReused
+	}
+
+	// the key is claimed in the transaction creating the order, so a committed key always has one
+	if idempotencyKey.OrderID == nil {
+		return model.Order{}, fmt.Errorf("idempotency key %q has no order", key)
+	}
+
+	order, err := getOrder(ctx, repo, *idempotencyKey.OrderID)
+	if err != nil {
+		return model.Order{}, fmt.Errorf("get order of idempotency key: %w", err)
+	}
+
+	return order, nil
+}
@@ -0,0 +1,347 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/leetm4n/orders-service/internal/repo/memory"
+	"github.com/leetm4n/orders-service/pkg/money"
+)
+
+func TestCreateOrder(t *testing.T) {
+	errOutbox := errors.New("outbox unavailable")
+
+	type args struct {
+		// seed creates orders before the tested request
+		seed []CreateOrderInput
+		in   CreateOrderInput
+		errs map[string]error
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantTotal    string
+		wantReplayed bool
+		wantErr      error
+		wantOrders   int
+	}{
+		{
+			name:       "should create order with totals",
+			args:       args{in: newCreateOrderInput("19.99", "")},
+			wantTotal:  "101.55",
+			wantOrders: 1,
+		},
+		{
+			name:       "should reject invalid unit price",
+			args:       args{in: newCreateOrderInput("19.9.9", "")},
+			wantErr:    errInvalidUnitPrice,
+			wantOrders: 0,
+		},
+		{
+			name: "should accept largest quantity",
+			args: args{in: func() CreateOrderInput {
+				in := newCreateOrderInput("19.99", "")
+				in.Items[0].Quantity = MaxQuantity
+				return in
+			}()},
+			wantTotal:  "25387.30",
+			wantOrders: 1,
+		},
+		{
+			name: "should reject quantity above limit",
+			args: args{in: func() CreateOrderInput {
+				in := newCreateOrderInput("19.99", "")
+				in.Items[0].Quantity = MaxQuantity + 1
+				return in
+			}()},
+			wantErr:    errInvalidQuantity,
+			wantOrders: 0,
+		},
+		{
+			name:       "should reject price more precise than currency",
+			args:       args{in: newCreateOrderInput("19.999", "")},
+			wantErr:    &ValidationError{},
+			wantOrders: 0,
+		},
+		{
+			name:       "should reject unit price of 10^9",
+			args:       args{in: newCreateOrderInput("1000000000", "")},
+			wantErr:    errInvalidUnitPrice,
+			wantOrders: 0,
+		},
+		{
+			name: "should reject unsupported currency",
+			args: args{in: func() CreateOrderInput {
+				in := newCreateOrderInput("19.99", "")
+				in.Currency = "eur"
+				return in
+			}()},
+			wantErr:    money.ErrUnsupportedCurrency,
+			wantOrders: 0,
+		},
+		{
+			name: "should reject too many items",
+			args: args{in: func() CreateOrderInput {
+				in := newCreateOrderInput("19.99", "")
+				in.Items = slices.Repeat(in.Items, maxOrderItems+1)
+				return in
+			}()},
+			wantErr:    &ValidationError{},
+			wantOrders: 0,
+		},
+		{
+			name: "should accept names of the longest length in characters",
+			args: args{in: func() CreateOrderInput {
+				in := newCreateOrderInput("19.99", "")
+				in.ShippingAddress.Name = strings.Repeat("é", maxNameLength)
+				in.ShippingAddress.Lines = []string{strings.Repeat("ő", maxAddressLineLength)}
+				return in
+			}()},
+			wantTotal:  "101.55",
+			wantOrders: 1,
+		},
+		{
+			name: "should reject name longer than the limit",
+			args: args{in: func() CreateOrderInput {
+				in := newCreateOrderInput("19.99", "")
+				in.ShippingAddress.Name = strings.Repeat("a", maxNameLength+1)
+				return in
+			}()},
+			wantErr:    &ValidationError{},
+			wantOrders: 0,
+		},
+		{
+			name: "should reject empty address line",
+			args: args{in: func() CreateOrderInput {
+				in := newCreateOrderInput("19.99", "")
+				in.ShippingAddress.Lines = []string{"Andrassy ut 1.", ""}
+				return in
+			}()},
+			wantErr:    &ValidationError{},
+			wantOrders: 0,
+		},
+		{
+			name: "should reject invalid country",
+			args: args{in: func() CreateOrderInput {
+				in := newCreateOrderInput("19.99", "")
+				in.ShippingAddress.Country = "HUN"
+				return in
+			}()},
+			wantErr:    errInvalidCountry,
+			wantOrders: 0,
+		},
+		{
+			name:       "should reject idempotency key longer than the limit",
+			args:       args{in: newCreateOrderInput("19.99", strings.Repeat("k", maxIdempotencyKeyLength+1))},
+			wantErr:    &ValidationError{},
+			wantOrders: 0,
+		},
+		{
+			name: "should replay order created with same idempotency key",
+			args: args{
+				seed: []CreateOrderInput{newCreateOrderInput("19.99", "key")},
+				in:   newCreateOrderInput("19.99", "key"),
+			},
+			wantTotal:    "101.55",
+			wantReplayed: true,
+			wantOrders:   1,
+		},
+		{
+			name: "should replay order created with same idempotency key for equivalent request",
+			args: args{
+				seed: []CreateOrderInput{func() CreateOrderInput {
+					in := newCreateOrderInput("19.9", "key")
+					in.ShippingAddress.Country = "GB"
+					in.ShippingAddress.PostalCode = "SW1A 1AA"
+					return in
+				}()},
+				in: func() CreateOrderInput {
+					in := newCreateOrderInput("19.90", "key")
+					in.ShippingAddress.Country = "gb"
+					in.ShippingAddress.PostalCode = "sw1a 1aa"
+					return in
+				}(),
+			},
+			wantTotal:    "101.09",
+			wantReplayed: true,
+			wantOrders:   1,
+		},
+		{
+			name: "should reject idempotency key reused for different request",
+			args: args{
+				seed: []CreateOrderInput{newCreateOrderInput("19.99", "key")},
+				in:   newCreateOrderInput("29.99", "key"),
+			},
+			wantErr:    ErrIdempotencyKeyReused,
+			wantOrders: 1,
+		},
+		{
+			name: "should not store order when event cannot be written",
+			args: args{
+				in:   newCreateOrderInput("19.99", "key"),
+				errs: map[string]error{"CreateOutboxEvent": errOutbox},
+			},
+			wantErr:    errOutbox,
+			wantOrders: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := memory.NewOrderRepository()
+			repo := &failingRepository{OrderRepository: store}
+			s := newTestService(t, repo)
+
+			for _, in := range tt.args.seed {
+				if _, _, err := s.CreateOrder(t.Context(), in); err != nil {
+					t.Fatalf("CreateOrder() seed error = %v", err)
+				}
+			}
+
+			repo.errs = tt.args.errs
+
+			order, replayed, err := s.CreateOrder(t.Context(), tt.args.in)
+			if !matchesErr(err, tt.wantErr) {
+				t.Fatalf("CreateOrder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if order.Total != tt.wantTotal || replayed != tt.wantReplayed {
+				t.Errorf("CreateOrder() = total %q replayed %v, want total %q replayed %v",
+					order.Total, replayed, tt.wantTotal, tt.wantReplayed)
+			}
+			orders, history, events := stored(t, store)
+			if orders != tt.wantOrders || history != tt.wantOrders || events != tt.wantOrders {
+				t.Errorf("CreateOrder() stored %d orders, %d history entries and %d events, want %d of each",
+					orders, history, events, tt.wantOrders)
+			}
+		})
+	}
+}
+
+func TestCreateOrderConcurrentlyWithIdempotencyKey(t *testing.T) {
+	// the in-memory storage serializes transactions like the row lock of the claimed key does
+	s := NewOrderService(OrderServiceOptions{Repository: memory.NewOrderRepository(), IdempotencyKeyTTL: time.Hour})
+	in := newCreateOrderInput("19.99", "key")
+
+	type result struct {
+		id       string
+		replayed bool
+		err      error
+	}
+
+	results := make([]result, 10)
+	wg := sync.WaitGroup{}
+	for i := range results {
+		wg.Go(func() {
+			order, replayed, err := s.CreateOrder(t.Context(), in)
+			results[i] = result{id: order.ID.String(), replayed: replayed, err: err}
+		})
+	}
+	wg.Wait()
+
+	created := 0
+	for _, r := range results {
+		if r.err != nil {
+			t.Fatalf("CreateOrder() error = %v", r.err)
+		}
+
+		if r.id != results[0].id {
+			t.Errorf("CreateOrder() = order %s, want %s of the first request", r.id, results[0].id)
+		}
+
+		if !r.replayed {
+			created++
+		}
+	}
+
+	if created != 1 {
+		t.Errorf("CreateOrder() created %d orders, want 1 and the rest replayed", created)
+	}
+}
+
+// matchesErr compares err with want, a *ValidationError as want matches any validation error.
+func matchesErr(err, want error) bool {
+	var validationErr *ValidationError
+	if errors.As(want, &validationErr) {
+		return errors.As(err, &validationErr)
+	}
+
+	return errors.Is(err, want)
+}
+
+func TestRequestFingerprint(t *testing.T) {
+	actor := "admin"
+
+	type args struct {
+		a CreateOrderInput
+		b CreateOrderInput
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "should match same order",
+			args: args{a: newCreateOrderInput("19.99", "key"), b: newCreateOrderInput("19.99", "key")},
+			want: true,
+		},
+		{
+			name: "should not match different order",
+			args: args{a: newCreateOrderInput("19.99", "key"), b: newCreateOrderInput("29.99", "key")},
+			want: false,
+		},
+		{
+			name: "should match same order in different notation",
+			args: args{
+				a: newCreateOrderInput("19.9", "key"),
+				b: func() CreateOrderInput {
+					in := newCreateOrderInput("19.90", "key")
+					in.ShippingAddress.PostalCode = " 1061"
+					return in
+				}(),
+			},
+			want: true,
+		},
+		{
+			name: "should ignore actor and idempotency key",
+			args: args{
+				a: newCreateOrderInput("19.99", "key"),
+				b: func() CreateOrderInput {
+					in := newCreateOrderInput("19.99", "other")
+					in.Actor = &actor
+					return in
+				}(),
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(t, memory.NewOrderRepository())
+
+			if got := fingerprint(t, s, tt.args.a) == fingerprint(t, s, tt.args.b); got != tt.want {
+				t.Errorf("requestFingerprint() match = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// fingerprint returns the fingerprint of the order the input describes.
+func fingerprint(t *testing.T, s *OrderService, in CreateOrderInput) string {
+	t.Helper()
+
+	order, err := s.newOrder(in)
+	if err != nil {
+		t.Fatalf("newOrder() error = %v", err)
+	}
+
+	requestHash, err := requestFingerprint(order)
+	if err != nil {
+		t.Fatalf("requestFingerprint() error = %v", err)
+	}
+
+	return requestHash
+}
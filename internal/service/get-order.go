@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/leetm4n/orders-service/internal/model"
+)
+
+func (s *OrderService) GetOrder(ctx context.Context, id uuid.UUID) (model.Order, error) {
+	return getOrder(ctx, s.orders, id)
+}
+
+// getOrder loads the order using repo, which may be bound to a transaction.
+func getOrder(ctx context.Context, repo model.OrderRepository, id uuid.UUID) (model.Order, error) {
+	order, err := repo.GetOrder(ctx, id)
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return model.Order{}, ErrOrderNotFound
+		}
+
+		return model.Order{}, fmt.Errorf("get order by id: %w", err)
+	}
+
+	return order, nil
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/leetm4n/orders-service/internal/model"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// recordHistory appends a change of the order to the history using repo, which may be bound to a transaction.
+// oldValues is nil for newly created orders.
+func recordHistory(
+	ctx context.Context,
+	repo model.OrderRepository,
+	orderID uuid.UUID,
+	action string,
+	actor *string,
+	oldValues any,
+	newValues any,
+) error {
+	entry := model.HistoryEntry{
+		OrderID: orderID,
+		Action:  action,
+		Actor:   model.DefaultActor,
+	}
+
+	if actor != nil {
+		entry.Actor = *actor
+	}
+
+	if oldValues != nil {
+		b, err := json.Marshal(oldValues)
+		if err != nil {
+			return err
+		}
+
+		entry.OldValues = b
+	}
+
+	newValuesJSON, err := json.Marshal(newValues)
+	if err != nil {
+		return err
+	}
+
+	entry.NewValues = newValuesJSON
+
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		traceID := sc.TraceID().String()
+		entry.TraceID = &traceID
+	}
+
+	return repo.CreateHistoryEntry(ctx, entry)
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leetm4n/orders-service/internal/domain"
+	"github.com/leetm4n/orders-service/internal/model"
+)
+
+const (
+	DefaultListOrdersLimit = 20
+	MaxListOrdersLimit     = 100
+)
+
+// SortOrder is the order of listed orders, the values are the sort parameter of the REST API.
+type SortOrder string
+
+const (
+	SortCreatedAtAsc  SortOrder = "createdAt"
+	SortCreatedAtDesc SortOrder = "-createdAt"
+)
+
+type ListOrdersInput struct {
+	// Limit defaults to DefaultListOrdersLimit, Sort to SortCreatedAtDesc.
+	Limit       int
+	Sort        SortOrder
+	Cursor      string
+	Status      *domain.OrderStatus
+	Sku         *uuid.UUID
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+}
+
+// ListOrders returns a page of orders and the cursor of the next page, which is empty on the last page.
+func (s *OrderService) ListOrders(ctx context.Context, in ListOrdersInput) ([]model.Order, string, error) {
+	limit := in.Limit
+	if limit == 0 {
+		limit = DefaultListOrdersLimit
+	}
+
+	if limit < 0 || limit > MaxListOrdersLimit {
+		return nil, "", &ValidationError{Err: fmt.Errorf("limit must be between 1 and %d", MaxListOrdersLimit)}
+	}
+
+	sort := in.Sort
+	if sort == "" {
+		sort = SortCreatedAtDesc
+	}
+
+	// one extra order is fetched to know whether there is a next page
+	filter := model.OrderFilter{
+		Status:      in.Status,
+		Sku:         in.Sku,
+		CreatedFrom: in.CreatedFrom,
+		CreatedTo:   in.CreatedTo,
+		Ascending:   sort == SortCreatedAtAsc,
+		Limit:       limit + 1,
+	}
+
+	if in.Cursor != "" {
+		cursor, err := decodeOrderCursor(in.Cursor)
+		if err == nil && cursor.Sort != sort {
+			err = ErrInvalidCursor
+		}
+
+		var cursorID uuid.UUID
+		if err == nil {
+			cursorID, err = uuid.Parse(cursor.ID)
+		}
+
+		if err != nil {
+			return nil, "", &ValidationError{Err: ErrInvalidCursor}
+		}
+
+		filter.After = &model.OrderPosition{CreatedAt: cursor.CreatedAt, ID: cursorID}
+	}
+
+	orders, err := s.orders.ListOrders(ctx, filter)
+	if err != nil {
+		return nil, "", fmt.Errorf("list orders: %w", err)
+	}
+
+	var nextCursor string
+	if len(orders) > limit {
+		orders = orders[:limit]
+		last := orders[len(orders)-1]
+
+		nextCursor, err = encodeOrderCursor(orderCursor{
+			CreatedAt: last.CreatedAt,
+			ID:        last.ID.String(),
+			Sort:      sort,
+		})
+		if err != nil {
+			return nil, "", fmt.Errorf("encode order cursor: %w", err)
+		}
+	}
+
+	return orders, nextCursor, nil
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/leetm4n/orders-service/internal/repo/memory"
+)
+
+func TestListOrders(t *testing.T) {
+	type args struct {
+		in ListOrdersInput
+		// follow lists the next pages with the returned cursors
+		follow bool
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantPages []int
+		wantErr   error
+	}{
+		{
+			name:      "should list orders on one page",
+			args:      args{in: ListOrdersInput{}},
+			wantPages: []int{5},
+		},
+		{
+			name:      "should page orders newest first",
+			args:      args{in: ListOrdersInput{Limit: 2}, follow: true},
+			wantPages: []int{2, 2, 1},
+		},
+		{
+			name:      "should page orders oldest first",
+			args:      args{in: ListOrdersInput{Limit: 3, Sort: SortCreatedAtAsc}, follow: true},
+			wantPages: []int{3, 2},
+		},
+		{
+			name:    "should reject limit above maximum",
+			args:    args{in: ListOrdersInput{Limit: MaxListOrdersLimit + 1}},
+			wantErr: &ValidationError{},
+		},
+		{
+			name:    "should reject malformed cursor",
+			args:    args{in: ListOrdersInput{Cursor: "not a cursor"}},
+			wantErr: ErrInvalidCursor,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(t, memory.NewOrderRepository())
+			for range 5 {
+				seedOrder(t, s)
+			}
+
+			in := tt.args.in
+			pages := []int{}
+			seen := map[uuid.UUID]bool{}
+			for {
+				orders, nextCursor, err := s.ListOrders(t.Context(), in)
+				if !matchesErr(err, tt.wantErr) {
+					t.Fatalf("ListOrders() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if err != nil {
+					return
+				}
+
+				pages = append(pages, len(orders))
+				for i, order := range orders {
+					if seen[order.ID] {
+						t.Fatalf("ListOrders() returned order %s twice", order.ID)
+					}
+					seen[order.ID] = true
+
+					// orders created within the same microsecond are ordered by id
+					newestFirst := in.Sort != SortCreatedAtAsc
+					if i > 0 && ((newestFirst && order.CreatedAt.After(orders[i-1].CreatedAt)) ||
+						(!newestFirst && order.CreatedAt.Before(orders[i-1].CreatedAt))) {
+						t.Errorf("ListOrders() sort %q returned orders out of order", in.Sort)
+					}
+				}
+
+				if !tt.args.follow || nextCursor == "" {
+					break
+				}
+				in.Cursor = nextCursor
+			}
+
+			if !slices.Equal(pages, tt.wantPages) {
+				t.Errorf("ListOrders() pages = %v, want %v", pages, tt.wantPages)
+			}
+		})
+	}
+}
+
+func TestListOrdersCursorOfOtherSort(t *testing.T) {
+	s := newTestService(t, memory.NewOrderRepository())
+	for range 3 {
+		seedOrder(t, s)
+	}
+
+	_, nextCursor, err := s.ListOrders(t.Context(), ListOrdersInput{Limit: 1, Sort: SortCreatedAtAsc})
+	if err != nil {
+		t.Fatalf("ListOrders() error = %v", err)
+	}
+
+	_, _, err = s.ListOrders(t.Context(), ListOrdersInput{Limit: 1, Cursor: nextCursor})
+	if !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("ListOrders() error = %v, wantErr %v", err, ErrInvalidCursor)
+	}
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+
+	"github.com/leetm4n/orders-service/internal/domain"
+	"github.com/leetm4n/orders-service/internal/model"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// orderMetrics count created orders and the status changes of orders, once their transaction committed. Replayed
+// creations are not counted.
+type orderMetrics struct {
+	created       metric.Int64Counter
+	statusChanges metric.Int64Counter
+}
+
+// newOrderMetrics hands errors to the otel error handler, the instruments are usable regardless.
+func newOrderMetrics(meter metric.Meter) orderMetrics {
+	created, err := meter.Int64Counter("orders.created",
+		metric.WithDescription("Orders created, by status and currency."))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	statusChanges, err := meter.Int64Counter("orders.status.changes",
+		metric.WithDescription("Status changes of orders including cancellations, by previous and new status."))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return orderMetrics{created: created, statusChanges: statusChanges}
+}
+
+func (m orderMetrics) orderCreated(ctx context.Context, order model.Order) {
+	m.created.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("order.status", string(order.Status)),
+		attribute.String("order.currency", order.Currency),
+	))
+}
+
+func (m orderMetrics) statusChanged(ctx context.Context, previousStatus, status domain.OrderStatus) {
+	m.statusChanges.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("order.previous_status", string(previousStatus)),
+		attribute.String("order.status", string(status)),
+	))
+}
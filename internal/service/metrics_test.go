@@ -0,0 +1,53 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/leetm4n/orders-service/internal/domain"
+	"github.com/leetm4n/orders-service/internal/model"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestOrderMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	m := newOrderMetrics(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test"))
+
+	m.orderCreated(t.Context(), model.Order{Status: domain.OrderStatusPending, Currency: "EUR"})
+	m.statusChanged(t.Context(), domain.OrderStatusPending, domain.OrderStatusShipped)
+
+	rm := metricdata.ResourceMetrics{}
+	if err := reader.Collect(t.Context(), &rm); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	want := map[string]attribute.Set{
+		"orders.created": attribute.NewSet(
+			attribute.String("order.status", string(domain.OrderStatusPending)),
+			attribute.String("order.currency", "EUR"),
+		),
+		"orders.status.changes": attribute.NewSet(
+			attribute.String("order.previous_status", string(domain.OrderStatusPending)),
+			attribute.String("order.status", string(domain.OrderStatusShipped)),
+		),
+	}
+
+	got := map[string]attribute.Set{}
+	for _, scope := range rm.ScopeMetrics {
+		for _, metric := range scope.Metrics {
+			sum, ok := metric.Data.(metricdata.Sum[int64])
+			if !ok || len(sum.DataPoints) != 1 || sum.DataPoints[0].Value != 1 {
+				t.Fatalf("%s = %+v, want a single count of 1", metric.Name, metric.Data)
+			}
+
+			got[metric.Name] = sum.DataPoints[0].Attributes
+		}
+	}
+
+	for name, attrs := range want {
+		if gotAttrs := got[name]; !gotAttrs.Equals(&attrs) {
+			t.Errorf("%s attributes = %v, want %v", name, gotAttrs.ToSlice(), attrs.ToSlice())
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+)
+
+// orderCursor is the keyset position of the last order on a page, it is handed out to clients as opaque base64 json.
+type orderCursor struct {
+	CreatedAt time.Time `json:"createdAt"`
+	ID        string    `json:"id"`
+	Sort      SortOrder `json:"sort"`
+}
+
+func encodeOrderCursor(cursor orderCursor) (string, error) {
+	return EncodeCursor(cursor)
+}
+
+func decodeOrderCursor(s string) (orderCursor, error) {
+	cursor := orderCursor{}
+	if err := DecodeCursor(s, &cursor); err != nil {
+		return orderCursor{}, err
+	}
+
+	return cursor, nil
+}
+
+// EncodeCursor encodes the position of the last item on a page as the opaque base64 json every list hands out.
+func EncodeCursor(position any) (string, error) {
+	b, err := json.Marshal(position)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DecodeCursor decodes a cursor of EncodeCursor into position, it fails with ErrInvalidCursor.
+func DecodeCursor(s string, position any) error {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+
+	if err := json.Unmarshal(b, position); err != nil {
+		return ErrInvalidCursor
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leetm4n/orders-service/internal/domain"
+	"github.com/leetm4n/orders-service/internal/model"
+)
+
+// watchBatchSize limits the history entries read per poll, a watcher behind by more reads again right away.
+const watchBatchSize = 100
+
+// ListOrderHistory returns the changes of the order in the order they happened.
+func (s *OrderService) ListOrderHistory(ctx context.Context, id uuid.UUID) ([]model.HistoryEntry, error) {
+	if _, err := getOrder(ctx, s.orders, id); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.orders.ListOrderHistory(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("list order history: %w", err)
+	}
+
+	return entries, nil
+}
+
+type WatchOrderHistoryInput struct {
+	// OrderID limits the watch to a single order, AfterID is the id of the last event the watcher has seen.
+	OrderID *uuid.UUID
+	AfterID *int64
+}
+
+// WatchOrderHistory polls the order history and hands the new entries to handle until ctx is done or handle fails,
+// handle is also called without entries after every poll that found none. Entries are handed over in the order they
+// were committed, which is not always the order of their ids. Without AfterID watching an order starts with its whole
+// history and watching every order with the changes made after the call.
+func (s *OrderService) WatchOrderHistory(
+	ctx context.Context,
+	in WatchOrderHistoryInput,
+	handle func(events []model.OrderStatusEvent) error,
+) error {
+	if in.OrderID != nil {
+		if _, err := getOrder(ctx, s.orders, *in.OrderID); err != nil {
+			return err
+		}
+	}
+
+	var afterID int64
+	switch {
+	case in.AfterID != nil:
+		afterID = *in.AfterID
+	case in.OrderID == nil:
+		lastID, err := s.orders.GetLastOrderHistoryID(ctx)
+		if err != nil {
+			return fmt.Errorf("get last order history id: %w", err)
+		}
+
+		afterID = lastID
+	}
+
+	poll := time.NewTicker(s.watchPollInterval)
+	defer poll.Stop()
+
+	for {
+		entries, err := s.orders.ListOrderHistoryAfter(ctx, afterID, in.OrderID, watchBatchSize)
+		if err != nil {
+			return fmt.Errorf("list order history: %w", err)
+		}
+
+		events := make([]model.OrderStatusEvent, 0, len(entries))
+		for _, entry := range entries {
+			event, err := toOrderStatusEvent(entry)
+			if err != nil {
+				return fmt.Errorf("map order history entry %d: %w", entry.ID, err)
+			}
+
+			events = append(events, event)
+		}
+
+		if err := handle(events); err != nil {
+			return err
+		}
+
+		if len(entries) > 0 {
+			afterID = entries[len(entries)-1].ID
+
+			if len(entries) == watchBatchSize {
+				continue
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-poll.C:
+		}
+	}
+}
+
+// toOrderStatusEvent reads the status from the values of the entry, every history action records it.
+func toOrderStatusEvent(entry model.HistoryEntry) (model.OrderStatusEvent, error) {
+	newValues := struct {
+		Status domain.OrderStatus `json:"status"`
+	}{}
+	if err := json.Unmarshal(entry.NewValues, &newValues); err != nil {
+		return model.OrderStatusEvent{}, err
+	}
+
+	event := model.OrderStatusEvent{
+		ID:        entry.ID,
+		OrderID:   entry.OrderID,
+		Action:    entry.Action,
+		Status:    newValues.Status,
+		Actor:     entry.Actor,
+		TraceID:   entry.TraceID,
+		CreatedAt: entry.CreatedAt,
+	}
+
+	if entry.OldValues != nil {
+		oldValues := struct {
+			Status *domain.OrderStatus `json:"status"`
+		}{}
+		if err := json.Unmarshal(entry.OldValues, &oldValues); err != nil {
+			return model.OrderStatusEvent{}, err
+		}
+
+		event.PreviousStatus = oldValues.Status
+	}
+
+	return event, nil
+}
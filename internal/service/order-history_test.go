@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leetm4n/orders-service/internal/domain"
+	"github.com/leetm4n/orders-service/internal/model"
+	"github.com/leetm4n/orders-service/internal/repo/memory"
+)
+
+func TestToOrderStatusEvent(t *testing.T) {
+	orderID := uuid.MustParse("3deb76e4-cd89-4aa3-b143-89e9c0ed11ad")
+	createdAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	pending := domain.OrderStatusPending
+	traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+
+	type args struct {
+		entry model.HistoryEntry
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    model.OrderStatusEvent
+		wantErr bool
+	}{
+		{
+			name: "should map created entry",
+			args: args{entry: model.HistoryEntry{
+				ID:        1,
+				OrderID:   orderID,
+				Action:    "created",
+				Actor:     "system",
+				NewValues: []byte(`{"id":"3deb76e4-cd89-4aa3-b143-89e9c0ed11ad","status":"pending"}`),
+				CreatedAt: createdAt,
+			}},
+			want: model.OrderStatusEvent{
+				ID:        1,
+				OrderID:   orderID,
+				Action:    "created",
+				Status:    domain.OrderStatusPending,
+				Actor:     "system",
+				CreatedAt: createdAt,
+			},
+		},
+		{
+			name: "should map previous status of status change",
+			args: args{entry: model.HistoryEntry{
+				ID:        2,
+				OrderID:   orderID,
+				Action:    "status_changed",
+				Actor:     "warehouse",
+				OldValues: []byte(`{"status":"pending"}`),
+				NewValues: []byte(`{"status":"shipped"}`),
+				TraceID:   &traceID,
+				CreatedAt: createdAt,
+			}},
+			want: model.OrderStatusEvent{
+				ID:             2,
+				OrderID:        orderID,
+				Action:         "status_changed",
+				Status:         domain.OrderStatusShipped,
+				PreviousStatus: &pending,
+				Actor:          "warehouse",
+				TraceID:        &traceID,
+				CreatedAt:      createdAt,
+			},
+		},
+		{
+			name:    "should fail on invalid values",
+			args:    args{entry: model.HistoryEntry{NewValues: []byte(`{`)}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toOrderStatusEvent(tt.args.entry)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("toOrderStatusEvent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toOrderStatusEvent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWatchOrderHistory(t *testing.T) {
+	afterFirst := int64(1)
+	unknownOrderID := uuid.New()
+
+	type args struct {
+		// orderID of the first seeded order is used when watchOrder is set
+		watchOrder bool
+		orderID    *uuid.UUID
+		afterID    *int64
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []int64
+		wantErr error
+	}{
+		{
+			name: "should start with whole history of order",
+			args: args{watchOrder: true},
+			want: []int64{1},
+		},
+		{
+			name: "should start after last event of every order",
+			args: args{},
+			want: []int64{},
+		},
+		{
+			name: "should continue after given event",
+			args: args{afterID: &afterFirst},
+			want: []int64{2},
+		},
+		{
+			name:    "should fail for unknown order",
+			args:    args{orderID: &unknownOrderID},
+			wantErr: ErrOrderNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(t, memory.NewOrderRepository())
+			order := seedOrder(t, s)
+			seedOrder(t, s)
+
+			in := WatchOrderHistoryInput{OrderID: tt.args.orderID, AfterID: tt.args.afterID}
+			if tt.args.watchOrder {
+				in.OrderID = &order.ID
+			}
+
+			ctx, cancel := context.WithCancel(t.Context())
+			defer cancel()
+
+			got := []int64{}
+			err := s.WatchOrderHistory(ctx, in, func(events []model.OrderStatusEvent) error {
+				for _, event := range events {
+					got = append(got, event.ID)
+				}
+
+				// the first poll is enough, the watch ends with the cancellation
+				cancel()
+
+				return nil
+			})
+			if tt.wantErr == nil && errors.Is(err, context.Canceled) {
+				err = nil
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("WatchOrderHistory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WatchOrderHistory() ids = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// historyCallsRepository records the ids ListOrderHistoryAfter continues after.
+type historyCallsRepository struct {
+	model.OrderRepository
+	afterIDs []int64
+}
+
+func (r *historyCallsRepository) ListOrderHistoryAfter(
+	ctx context.Context,
+	afterID int64,
+	orderID *uuid.UUID,
+	limit int,
+) ([]model.HistoryEntry, error) {
+	r.afterIDs = append(r.afterIDs, afterID)
+
+	return r.OrderRepository.ListOrderHistoryAfter(ctx, afterID, orderID, limit)
+}
+
+// TestWatchOrderHistoryContinuesAfterLastEntry checks that the watch continues after the last entry it handed out
+// rather than after the largest id, entries are listed in commit order, which ids do not follow when transactions
+// commit out of order.
+func TestWatchOrderHistoryContinuesAfterLastEntry(t *testing.T) {
+	repo := &historyCallsRepository{OrderRepository: memory.NewOrderRepository()}
+	s := newTestService(t, repo)
+	order := seedOrder(t, s)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	got := []int64{}
+	polls := 0
+	err := s.WatchOrderHistory(ctx, WatchOrderHistoryInput{OrderID: &order.ID},
+		func(events []model.OrderStatusEvent) error {
+			for _, event := range events {
+				got = append(got, event.ID)
+			}
+
+			polls++
+			switch polls {
+			case 1:
+				if _, err := s.TransitionOrder(t.Context(), TransitionOrderInput{
+					ID:      order.ID,
+					Status:  domain.OrderStatusShipped,
+					Version: order.Version,
+				}); err != nil {
+					t.Fatalf("TransitionOrder() error = %v", err)
+				}
+			case 3:
+				cancel()
+			}
+
+			return nil
+		})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("WatchOrderHistory() error = %v, want %v", err, context.Canceled)
+	}
+
+	if want := []int64{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("WatchOrderHistory() ids = %v, want %v", got, want)
+	}
+
+	if want := []int64{0, 1, 2}; !reflect.DeepEqual(repo.afterIDs, want) {
+		t.Errorf("ListOrderHistoryAfter() after ids = %v, want %v", repo.afterIDs, want)
+	}
+}
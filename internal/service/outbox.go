@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leetm4n/orders-service/internal/model"
+	"github.com/leetm4n/orders-service/pkg/cloudevents"
+	"github.com/leetm4n/orders-service/pkg/tracing"
+)
+
+// emitEvent stores data as a CloudEvent in the outbox using repo, which may be bound to a transaction, the relay
+// worker takes care of publishing it. The id of the event is the id of the outbox row, its subject the aggregate id.
+// The event carries the trace context of a dedicated span so consumers can continue the trace.
+func (s *OrderService) emitEvent(
+	ctx context.Context,
+	repo model.OrderRepository,
+	aggregateID uuid.UUID,
+	eventType string,
+	data any,
+) error {
+	ctx, span := s.tracer.Start(ctx, "emitEvent "+eventType)
+	defer span.End()
+
+	id := uuid.New()
+
+	event, err := cloudevents.New(id.String(), s.eventSource, eventType, aggregateID.String(), time.Now(), data)
+	if err != nil {
+		return err
+	}
+
+	event.SetTraceContext(tracing.SerializeTraceCtx(ctx))
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return repo.CreateOutboxEvent(ctx, model.OutboxEvent{
+		ID:          id,
+		AggregateID: aggregateID,
+		EventType:   eventType,
+		Payload:     payload,
+	})
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/leetm4n/orders-service/internal/model"
+	"github.com/leetm4n/orders-service/pkg/money"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	ErrOrderNotFound          = errors.New("order not found")
+	ErrInvalidCursor          = errors.New("invalid cursor")
+	ErrIdempotencyKeyReused   = errors.New("idempotency key was already used with a different request")
+	ErrConcurrentModification = errors.New("order was modified concurrently")
+)
+
+// ValidationError is returned for input the service rejects, its message is meant for the caller.
+type ValidationError struct {
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// OrderService holds the order use cases shared by the REST and gRPC APIs. Every change of an order records its
+// history and writes its event to the outbox in the same transaction of the repository.
+type OrderService struct {
+	orders            model.OrderRepository
+	taxRatePercent    money.Amount
+	idempotencyKeyTTL time.Duration
+	eventSource       string
+	watchPollInterval time.Duration
+	tracer            trace.Tracer
+	metrics           orderMetrics
+}
+
+type OrderServiceOptions struct {
+	Repository        model.OrderRepository
+	TaxRatePercent    money.Amount
+	IdempotencyKeyTTL time.Duration
+	EventSource       string
+	WatchPollInterval time.Duration
+}
+
+func NewOrderService(opts OrderServiceOptions) *OrderService {
+	return &OrderService{
+		orders:            opts.Repository,
+		taxRatePercent:    opts.TaxRatePercent,
+		idempotencyKeyTTL: opts.IdempotencyKeyTTL,
+		eventSource:       opts.EventSource,
+		watchPollInterval: opts.WatchPollInterval,
+		tracer:            otel.Tracer("orders-service"),
+		metrics:           newOrderMetrics(otel.Meter("orders-service")),
+	}
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leetm4n/orders-service/internal/domain"
+	"github.com/leetm4n/orders-service/internal/model"
+	"github.com/leetm4n/orders-service/internal/repo/memory"
+	"github.com/leetm4n/orders-service/pkg/money"
+)
+
+// failingRepository wraps the in-memory repository, errs makes the method with the given name fail, also within
+// transactions.
+type failingRepository struct {
+	model.OrderRepository
+	errs map[string]error
+}
+
+func (r *failingRepository) InTx(ctx context.Context, fn func(repo model.OrderRepository) error) error {
+	return r.OrderRepository.InTx(ctx, func(repo model.OrderRepository) error {
+		return fn(&failingRepository{OrderRepository: repo, errs: r.errs})
+	})
+}
+
+func (r *failingRepository) UpdateOrderStatus(
+	ctx context.Context,
+	order model.Order,
+	status domain.OrderStatus,
+) (model.Order, error) {
+	if err := r.errs["UpdateOrderStatus"]; err != nil {
+		return model.Order{}, err
+	}
+
+	return r.OrderRepository.UpdateOrderStatus(ctx, order, status)
+}
+
+func (r *failingRepository) CreateOutboxEvent(ctx context.Context, event model.OutboxEvent) error {
+	if err := r.errs["CreateOutboxEvent"]; err != nil {
+		return err
+	}
+
+	return r.OrderRepository.CreateOutboxEvent(ctx, event)
+}
+
+// stored returns how many orders, history entries and outbox events repo holds.
+func stored(t *testing.T, repo *memory.OrderRepository) (orders, history, events int) {
+	t.Helper()
+
+	storedOrders, err := repo.ListOrders(t.Context(), model.OrderFilter{Limit: 1000})
+	if err != nil {
+		t.Fatalf("ListOrders() error = %v", err)
+	}
+
+	entries, err := repo.ListOrderHistoryAfter(t.Context(), 0, nil, 1000)
+	if err != nil {
+		t.Fatalf("ListOrderHistoryAfter() error = %v", err)
+	}
+
+	// nothing consumes the outbox in these tests
+	ids, err := repo.ListUnreceivedOutboxEventIDs(t.Context(), 1000)
+	if err != nil {
+		t.Fatalf("ListUnreceivedOutboxEventIDs() error = %v", err)
+	}
+
+	return len(storedOrders), len(entries), len(ids)
+}
+
+func newTestService(t *testing.T, repo model.OrderRepository) *OrderService {
+	t.Helper()
+
+	taxRatePercent, err := money.ParseAmount("27")
+	if err != nil {
+		t.Fatalf("ParseAmount() error = %v", err)
+	}
+
+	return NewOrderService(OrderServiceOptions{
+		Repository:        repo,
+		TaxRatePercent:    taxRatePercent,
+		IdempotencyKeyTTL: time.Hour,
+		EventSource:       "/orders-service",
+		WatchPollInterval: time.Millisecond,
+	})
+}
+
+// seedOrder stores a pending order created by the service, so it comes with its history and outbox event.
+func seedOrder(t *testing.T, s *OrderService) model.Order {
+	t.Helper()
+
+	order, _, err := s.CreateOrder(t.Context(), newCreateOrderInput("19.99", ""))
+	if err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+
+	return order
+}
+
+func newCreateOrderInput(unitPrice, idempotencyKey string) CreateOrderInput {
+	return CreateOrderInput{
+		Items: []OrderItemInput{
+			{Sku: uuid.MustParse("3deb76e4-cd89-4aa3-b143-89e9c0ed11ad"), Quantity: 4, UnitPrice: unitPrice},
+		},
+		Currency: "EUR",
+		ShippingAddress: AddressInput{
+			Name:       "John Doe",
+			Lines:      []string{"Andrassy ut 1."},
+			City:       "Budapest",
+			PostalCode: "1061",
+			Country:    "HU",
+		},
+		IdempotencyKey: idempotencyKey,
+	}
+}
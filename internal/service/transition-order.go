@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/leetm4n/orders-service/internal/domain"
+	"github.com/leetm4n/orders-service/internal/model"
+)
+
+type TransitionOrderInput struct {
+	ID     uuid.UUID
+	Status domain.OrderStatus
+	// Version the change is based on, the change fails with ErrConcurrentModification when the order moved on.
+	Version int32
+	Actor   *string
+}
+
+// TransitionOrder moves the order to the next status of its lifecycle, illegal transitions fail with
+// domain.ErrIllegalStatusTransition.
+func (s *OrderService) TransitionOrder(ctx context.Context, in TransitionOrderInput) (model.Order, error) {
+	order, err := getOrder(ctx, s.orders, in.ID)
+	if err != nil {
+		return model.Order{}, err
+	}
+
+	if order.Version != in.Version {
+		return model.Order{}, ErrConcurrentModification
+	}
+
+	nextStatus, err := order.Status.TransitionTo(in.Status)
+	if err != nil {
+		return model.Order{}, err
+	}
+
+	var updatedOrder model.Order
+	err = s.orders.InTx(ctx, func(repo model.OrderRepository) error {
+		// status and version are compared again in the update, so a concurrent change results in a conflict instead
+		// of a lost update
+		updatedOrder, err = repo.UpdateOrderStatus(ctx, order, nextStatus)
+		if err != nil {
+			if errors.Is(err, model.ErrConflict) {
+				return ErrConcurrentModification
+			}
+
+			return fmt.Errorf("update order status: %w", err)
+		}
+
+		if err := recordHistory(ctx, repo, updatedOrder.ID, model.HistoryActionStatusChanged, in.Actor,
+			map[string]any{"status": order.Status},
+			map[string]any{"status": updatedOrder.Status},
+		); err != nil {
+			return fmt.Errorf("record order history: %w", err)
+		}
+
+		if err := s.emitEvent(ctx, repo, updatedOrder.ID, model.OrderStatusChangedEventType, model.OrderStatusChangedEvent{
+			Order:          updatedOrder,
+			PreviousStatus: string(order.Status),
+		}); err != nil {
+			return fmt.Errorf("write order status changed event to outbox: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return model.Order{}, err
+	}
+
+	s.metrics.statusChanged(ctx, order.Status, updatedOrder.Status)
+
+	return updatedOrder, nil
+}
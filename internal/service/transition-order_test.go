@@ -0,0 +1,86 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/leetm4n/orders-service/internal/domain"
+	"github.com/leetm4n/orders-service/internal/model"
+	"github.com/leetm4n/orders-service/internal/repo/memory"
+)
+
+func TestTransitionOrder(t *testing.T) {
+	type args struct {
+		// modify changes the input made for the seeded pending order
+		modify func(in *TransitionOrderInput)
+		errs   map[string]error
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantStatus  domain.OrderStatus
+		wantVersion int32
+		wantErr     error
+	}{
+		{
+			name:        "should ship pending order",
+			args:        args{modify: func(in *TransitionOrderInput) {}},
+			wantStatus:  domain.OrderStatusShipped,
+			wantVersion: 2,
+		},
+		{
+			name:    "should fail for unknown order",
+			args:    args{modify: func(in *TransitionOrderInput) { in.ID = uuid.New() }},
+			wantErr: ErrOrderNotFound,
+		},
+		{
+			name:    "should fail for outdated version",
+			args:    args{modify: func(in *TransitionOrderInput) { in.Version = 0 }},
+			wantErr: ErrConcurrentModification,
+		},
+		{
+			name:    "should reject illegal transition",
+			args:    args{modify: func(in *TransitionOrderInput) { in.Status = domain.OrderStatusDelivered }},
+			wantErr: domain.ErrIllegalStatusTransition,
+		},
+		{
+			name: "should fail when order changes during update",
+			args: args{
+				modify: func(in *TransitionOrderInput) {},
+				errs:   map[string]error{"UpdateOrderStatus": model.ErrConflict},
+			},
+			wantErr: ErrConcurrentModification,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := memory.NewOrderRepository()
+			repo := &failingRepository{OrderRepository: store}
+			s := newTestService(t, repo)
+			order := seedOrder(t, s)
+
+			in := TransitionOrderInput{ID: order.ID, Status: domain.OrderStatusShipped, Version: order.Version}
+			tt.args.modify(&in)
+			repo.errs = tt.args.errs
+
+			got, err := s.TransitionOrder(t.Context(), in)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("TransitionOrder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got.Status != tt.wantStatus || got.Version != tt.wantVersion {
+				t.Errorf("TransitionOrder() = %s v%d, want %s v%d", got.Status, got.Version, tt.wantStatus, tt.wantVersion)
+			}
+
+			wantEvents := 1
+			if tt.wantErr == nil {
+				wantEvents = 2
+			}
+			_, history, events := stored(t, store)
+			if history != wantEvents || events != wantEvents {
+				t.Errorf("TransitionOrder() stored %d history entries and %d events, want %d of each",
+					history, events, wantEvents)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/leetm4n/orders-service/internal/model"
+	"github.com/leetm4n/orders-service/pkg/cloudevents"
+	"github.com/leetm4n/orders-service/pkg/events"
+	"github.com/leetm4n/orders-service/pkg/tracing"
+)
+
+var errNoDeadLetterStorage = errors.New("no dead letter storage")
+
+// attemptsError is returned once the handler of an event ran out of attempts.
+type attemptsError struct {
+	attempts int
+	err      error
+}
+
+func (e *attemptsError) Error() string {
+	return fmt.Sprintf("giving up after %d attempts: %s", e.attempts, e.err)
+}
+
+func (e *attemptsError) Unwrap() error {
+	return e.err
+}
+
+// deadLetter stores an event that could not be processed, so it can be inspected and replayed or discarded
+// through the admin API.
+func (w *Worker) deadLetter(ctx context.Context, msg events.Message, processErr error) error {
+	if w.deadLetters == nil {
+		return errNoDeadLetterStorage
+	}
+
+	attempts := 1
+
+	var e *attemptsError
+	if errors.As(processErr, &e) {
+		attempts = e.attempts
+	}
+
+	// the payload of a malformed event is kept as a json string
+	payload := msg.Payload
+	if !json.Valid(payload) {
+		b, err := json.Marshal(string(payload))
+		if err != nil {
+			return fmt.Errorf("err marshalling payload: %w", err)
+		}
+
+		payload = b
+	}
+
+	envelope := tracing.TraceEnvelope{}
+	if event, err := cloudevents.Parse(msg.Payload); err == nil {
+		envelope = tracing.TraceEnvelope(event.TraceContext(tracing.Fields()))
+	}
+
+	trace, err := json.Marshal(envelope)
+	if err != nil {
+		return fmt.Errorf("err marshalling trace envelope: %w", err)
+	}
+
+	event := model.DeadLetterEvent{
+		EventID:   msg.ID,
+		EventType: msg.Type,
+		Payload:   payload,
+		Error:     processErr.Error(),
+		Attempts:  attempts,
+		Trace:     trace,
+	}
+
+	// events keyed by something else than an order id cannot be replayed
+	if aggregateID, err := uuid.Parse(msg.Key); err == nil {
+		event.AggregateID = &aggregateID
+	}
+
+	if err := w.deadLetters.CreateDeadLetterEvent(ctx, event); err != nil {
+		return fmt.Errorf("err creating dead letter event: %w", err)
+	}
+
+	return nil
+}
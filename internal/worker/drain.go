@@ -0,0 +1,122 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leetm4n/orders-service/pkg/events"
+)
+
+// persistTimeout bounds writing back the events that could not be processed during shutdown.
+const persistTimeout = 5 * time.Second
+
+var errNotProcessedBeforeShutdown = errors.New("event was not processed before shutdown")
+
+type drainReport struct {
+	draining  atomic.Bool
+	drained   atomic.Int64
+	persisted atomic.Int64
+}
+
+// drain closes the consumer so it stops taking new events from the broker and hands what it still buffers to the
+// pool, it stops when the consumer is empty or processCtx is canceled by the drain timeout. Messages that could not
+// be handed over are returned. Closing a consumer keeps the messages it holds, for the amqp transport it cancels the
+// subscription but leaves the channel open, so the buffered deliveries can still be received and settled.
+func (w *Worker) drain(processCtx context.Context, messages chan<- events.Message, pending []events.Message) []events.Message {
+	slog.Info("worker draining events", "timeout", w.drainTimeout)
+
+	if err := w.consumer.Close(); err != nil {
+		slog.Error("failed to close event consumer", "error", err)
+	}
+
+	for {
+		var msg events.Message
+		if len(pending) > 0 {
+			msg, pending = pending[0], pending[1:]
+		} else {
+			received, err := w.consumer.Receive(processCtx)
+			if err != nil {
+				if processCtx.Err() == nil && !errors.Is(err, events.ErrClosed) {
+					slog.Error("failed to receive event while draining", "error", err)
+				}
+
+				return nil
+			}
+
+			msg = received
+		}
+
+		select {
+		case <-processCtx.Done():
+			return append([]events.Message{msg}, pending...)
+		case messages <- msg:
+		}
+	}
+}
+
+// persistRemaining persists pending and whatever the closed consumer still buffers, a message is acked once it is
+// persisted and nacked otherwise. Messages still buffered when the persist timeout passes are left unsettled, the
+// amqp broker requeues them when the channel closes.
+func (w *Worker) persistRemaining(ctx context.Context, pending []events.Message, report *drainReport) {
+	persistCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), persistTimeout)
+	defer cancel()
+
+	for _, msg := range pending {
+		w.settle(msg, w.persist(persistCtx, msg, report))
+	}
+
+	for {
+		msg, err := w.consumer.Receive(persistCtx)
+		if err != nil {
+			return
+		}
+
+		w.settle(msg, w.persist(persistCtx, msg, report))
+	}
+}
+
+// persist moves the outbox row of msg back to pending so the relay publishes it again after restart, messages that
+// did not come from the outbox are dead-lettered instead. It reports whether msg was persisted.
+func (w *Worker) persist(ctx context.Context, msg events.Message, report *drainReport) bool {
+	if outboxID, err := uuid.Parse(msg.ID); err == nil && w.outbox != nil {
+		requeued, err := w.outbox.RequeueOutboxEvent(ctx, outboxID)
+		if err != nil {
+			slog.Error("failed to requeue outbox event", "error", err, "eventId", msg.ID, "eventType", msg.Type)
+		}
+
+		if requeued {
+			report.persisted.Add(1)
+
+			return true
+		}
+	}
+
+	if err := w.deadLetter(ctx, msg, errNotProcessedBeforeShutdown); err != nil {
+		slog.Error("failed to persist event", "error", err, "eventId", msg.ID, "eventType", msg.Type)
+
+		return false
+	}
+
+	report.persisted.Add(1)
+
+	return true
+}
+
+// settle acks msg when it is done with, otherwise it nacks it so the transport delivers it again.
+func (w *Worker) settle(msg events.Message, done bool) {
+	if done {
+		if err := w.consumer.Ack(msg); err != nil {
+			slog.Error("failed to ack event", "error", err, "eventId", msg.ID, "eventType", msg.Type)
+		}
+
+		return
+	}
+
+	if err := w.consumer.Nack(msg); err != nil {
+		slog.Error("failed to nack event", "error", err, "eventId", msg.ID, "eventType", msg.Type)
+	}
+}
@@ -0,0 +1,36 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// the receive loop beats at least every heartbeatInterval, while idle as well as while it waits for a busy pool to
+// take a message. A heartbeat older than heartbeatTimeout means the loop itself hangs.
+const (
+	heartbeatInterval = time.Second
+	heartbeatTimeout  = 5 * heartbeatInterval
+)
+
+var ErrWorkerNotRunning = errors.New("worker is not running")
+
+func (w *Worker) beat() {
+	w.lastBeat.Store(time.Now().UnixNano())
+}
+
+// CheckHeartbeat fails when the worker is not running or its last heartbeat is stale, it is meant as a readiness
+// check.
+func (w *Worker) CheckHeartbeat(_ context.Context) error {
+	last := w.lastBeat.Load()
+	if last == 0 {
+		return ErrWorkerNotRunning
+	}
+
+	if age := time.Since(time.Unix(0, last)); age > w.heartbeatTimeout {
+		return fmt.Errorf("last worker heartbeat %s ago", age.Round(time.Millisecond))
+	}
+
+	return nil
+}
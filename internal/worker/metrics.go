@@ -0,0 +1,70 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/leetm4n/orders-service/pkg/events"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	outcomeProcessed   = "processed"
+	outcomeFailed      = "failed"
+	outcomeInterrupted = "interrupted"
+)
+
+// queue is implemented by consumers that buffer received events in process, like the channel broker.
+type queue interface {
+	Len() int
+}
+
+// workerMetrics records how long handling an event took with its outcome, and the failed events, by event type.
+type workerMetrics struct {
+	duration metric.Float64Histogram
+	failures metric.Int64Counter
+}
+
+// newWorkerMetrics hands errors to the otel error handler, the instruments are usable regardless.
+func newWorkerMetrics(consumer events.Consumer) workerMetrics {
+	meter := otel.Meter("orders-ms-worker")
+
+	duration, err := meter.Float64Histogram("worker.event.duration", metric.WithUnit("s"),
+		metric.WithDescription("Time it took to handle an event, including its retries."))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	failures, err := meter.Int64Counter("worker.event.failures",
+		metric.WithDescription("Events that could not be processed and were dead-lettered."))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	if q, ok := consumer.(queue); ok {
+		if _, err := meter.Int64ObservableGauge("worker.queue.depth",
+			metric.WithDescription("Events received by the consumer and waiting for the worker."),
+			metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+				o.Observe(int64(q.Len()))
+				return nil
+			}),
+		); err != nil {
+			otel.Handle(err)
+		}
+	}
+
+	return workerMetrics{duration: duration, failures: failures}
+}
+
+func (m workerMetrics) record(ctx context.Context, msg events.Message, outcome string, start time.Time) {
+	eventType := attribute.String("event.type", msg.Type)
+
+	m.duration.Record(ctx, time.Since(start).Seconds(),
+		metric.WithAttributes(eventType, attribute.String("outcome", outcome)))
+
+	if outcome == outcomeFailed {
+		m.failures.Add(ctx, 1, metric.WithAttributes(eventType))
+	}
+}
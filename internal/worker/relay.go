@@ -0,0 +1,190 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leetm4n/orders-service/internal/model"
+	"github.com/leetm4n/orders-service/pkg/cloudevents"
+	"github.com/leetm4n/orders-service/pkg/events"
+	"github.com/leetm4n/orders-service/pkg/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Relay publishes events written to the outbox, an event is only marked as dispatched after it was published, which
+// gives at-least-once delivery. Pending events are leased, published without holding a transaction and every event is
+// marked on its own, so a slow broker holds no locks and an event that was published is not sent again because
+// another one failed. A leased event the relay does not mark, because publishing failed or the relay stopped, is
+// published again once the lease ends.
+type Relay struct {
+	outbox       model.OutboxRepository
+	producer     events.Producer
+	pollInterval time.Duration
+	batchSize    int
+	lease        time.Duration
+	tracer       trace.Tracer
+}
+
+// RelayOptions configures the relay, publishing a whole batch is bounded by Lease.
+type RelayOptions struct {
+	Outbox       model.OutboxRepository
+	Producer     events.Producer
+	PollInterval time.Duration
+	BatchSize    int
+	Lease        time.Duration
+}
+
+func NewRelay(opts RelayOptions) *Relay {
+	return &Relay{
+		outbox:       opts.Outbox,
+		producer:     opts.Producer,
+		pollInterval: opts.PollInterval,
+		batchSize:    opts.BatchSize,
+		lease:        opts.Lease,
+		tracer:       otel.Tracer("orders-ms-relay"),
+	}
+}
+
+func (r *Relay) Start(ctx context.Context) error {
+	slog.Info("outbox relay starting")
+
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("outbox relay stopping due to context cancellation")
+
+			return nil
+		case <-ticker.C:
+			dispatched, err := r.dispatchBatch(ctx)
+			if err != nil {
+				slog.Error("failed to dispatch outbox events", "error", err)
+				continue
+			}
+
+			if dispatched > 0 {
+				slog.Info("outbox events dispatched", "count", dispatched)
+			}
+		}
+	}
+}
+
+// dispatchBatch publishes the events it leased in the order they were written and stops at the first one that fails,
+// so later events are not published ahead of it. Publishing stops when the lease ends, another relay may lease the
+// events by then.
+func (r *Relay) dispatchBatch(ctx context.Context) (int, error) {
+	pending, err := r.outbox.LeasePendingOutboxEvents(ctx, r.batchSize, r.lease)
+	if err != nil {
+		return 0, fmt.Errorf("lease outbox events: %w", err)
+	}
+
+	publishCtx, cancel := context.WithTimeout(ctx, r.lease)
+	defer cancel()
+
+	dispatched := 0
+	errs := []error{}
+	for _, event := range pending {
+		if err := r.publish(publishCtx, event); err != nil {
+			errs = append(errs, fmt.Errorf("publish outbox event %s: %w", event.ID, err))
+			break
+		}
+
+		// an event that was published is marked even when ctx is canceled meanwhile, so it is not sent again
+		if err := r.outbox.MarkOutboxEventDispatched(context.WithoutCancel(ctx), event.ID); err != nil {
+			errs = append(errs, fmt.Errorf("mark outbox event %s dispatched: %w", event.ID, err))
+			continue
+		}
+
+		dispatched++
+	}
+
+	return dispatched, errors.Join(errs...)
+}
+
+// publish sends event in a span linked to the span that emitted it, the relay runs outside of any request so the
+// link is what leads from the request to the publishing.
+func (r *Relay) publish(ctx context.Context, event model.OutboxEvent) error {
+	msg := toMessage(event)
+
+	opts := []trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			attribute.String("event.id", msg.ID),
+			attribute.String("event.type", msg.Type),
+		),
+	}
+
+	if ce, err := cloudevents.Parse(event.Payload); err == nil {
+		emitterCtx := tracing.DeserializeTraceCtx(ctx, tracing.TraceEnvelope(ce.TraceContext(tracing.Fields())))
+		if emitter := trace.SpanContextFromContext(emitterCtx); emitter.IsValid() {
+			opts = append(opts, trace.WithLinks(trace.Link{SpanContext: emitter}))
+		}
+	}
+
+	ctx, span := r.tracer.Start(ctx, "publishOutboxEvent", opts...)
+	defer span.End()
+
+	if err := r.producer.Publish(ctx, msg); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	return nil
+}
+
+// OutboxMessageStore gives transports that only carry ids access to the events the relay published from the outbox.
+func OutboxMessageStore(outbox model.OutboxRepository) events.MessageStore {
+	return outboxMessageStore{outbox: outbox}
+}
+
+type outboxMessageStore struct {
+	outbox model.OutboxRepository
+}
+
+func (s outboxMessageStore) ReceiveMessage(ctx context.Context, id string) (events.Message, bool, error) {
+	eventID, err := uuid.Parse(id)
+	if err != nil {
+		return events.Message{}, false, fmt.Errorf("parse outbox event id: %w", err)
+	}
+
+	event, received, err := s.outbox.ReceiveOutboxEvent(ctx, eventID)
+	if err != nil {
+		return events.Message{}, false, fmt.Errorf("receive outbox event: %w", err)
+	}
+
+	return toMessage(event), received, nil
+}
+
+func (s outboxMessageStore) UnreceivedMessageIDs(ctx context.Context, limit int) ([]string, error) {
+	eventIDs, err := s.outbox.ListUnreceivedOutboxEventIDs(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list unreceived outbox events: %w", err)
+	}
+
+	ids := make([]string, 0, len(eventIDs))
+	for _, id := range eventIDs {
+		ids = append(ids, id.String())
+	}
+
+	return ids, nil
+}
+
+func toMessage(event model.OutboxEvent) events.Message {
+	return events.Message{
+		ID:      event.ID.String(),
+		Type:    event.EventType,
+		Key:     event.AggregateID.String(),
+		Payload: event.Payload,
+	}
+}
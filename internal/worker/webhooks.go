@@ -0,0 +1,200 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/leetm4n/orders-service/internal/model"
+	"github.com/leetm4n/orders-service/pkg/cloudevents"
+	"github.com/leetm4n/orders-service/pkg/events"
+	"github.com/leetm4n/orders-service/pkg/webhooks"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// enqueueWebhookDeliveries creates a pending delivery of msg for every active subscription of its type, the body of
+// a delivery is the CloudEvent in structured mode. Deliveries are unique per subscription and event, so a redelivered
+// or retried event is not sent twice. Without webhooks there are no subscriptions to deliver to.
+func (w *Worker) enqueueWebhookDeliveries(ctx context.Context, msg events.Message) error {
+	if w.webhooks == nil {
+		return nil
+	}
+
+	if _, err := w.webhooks.CreateWebhookDeliveries(ctx, msg.ID, msg.Type, msg.Payload); err != nil {
+		return fmt.Errorf("create webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+// WebhookDispatcher posts pending webhook deliveries. Due deliveries are leased, posted without holding a transaction
+// and every outcome is recorded on its own, so a slow endpoint holds no locks and a delivery that succeeded is not
+// sent again because another one failed. A leased delivery the dispatcher
+// does not record, because it stopped or recording failed, is retried once the lease ends. A failed delivery is
+// retried with backoff until it runs out of attempts and a subscription failing too many times in a row is disabled.
+type WebhookDispatcher struct {
+	webhooks             model.WebhookRepository
+	client               *webhooks.Client
+	pollInterval         time.Duration
+	batchSize            int
+	lease                time.Duration
+	maxAttempts          int
+	retryBaseDelay       time.Duration
+	retryMaxDelay        time.Duration
+	disableAfterFailures int
+	tracer               trace.Tracer
+}
+
+// WebhookDispatcherOptions configures the dispatcher, Lease has to cover posting a whole batch.
+type WebhookDispatcherOptions struct {
+	Webhooks             model.WebhookRepository
+	Client               *webhooks.Client
+	PollInterval         time.Duration
+	BatchSize            int
+	Lease                time.Duration
+	MaxAttempts          int
+	RetryBaseDelay       time.Duration
+	RetryMaxDelay        time.Duration
+	DisableAfterFailures int
+}
+
+func NewWebhookDispatcher(opts WebhookDispatcherOptions) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		webhooks:             opts.Webhooks,
+		client:               opts.Client,
+		pollInterval:         opts.PollInterval,
+		batchSize:            opts.BatchSize,
+		lease:                opts.Lease,
+		maxAttempts:          max(opts.MaxAttempts, 1),
+		retryBaseDelay:       opts.RetryBaseDelay,
+		retryMaxDelay:        opts.RetryMaxDelay,
+		disableAfterFailures: max(opts.DisableAfterFailures, 1),
+		tracer:               otel.Tracer("orders-ms-webhooks"),
+	}
+}
+
+func (d *WebhookDispatcher) Start(ctx context.Context) error {
+	slog.Info("webhook dispatcher starting")
+
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("webhook dispatcher stopping due to context cancellation")
+
+			return nil
+		case <-ticker.C:
+			delivered, err := d.deliverBatch(ctx)
+			if err != nil {
+				slog.Error("failed to deliver webhooks", "error", err)
+			}
+
+			if delivered > 0 {
+				slog.Info("webhooks delivered", "count", delivered)
+			}
+		}
+	}
+}
+
+// deliverBatch posts the deliveries it leased one after the other, a delivery whose outcome could not be recorded
+// does not keep the others from being posted.
+func (d *WebhookDispatcher) deliverBatch(ctx context.Context) (int, error) {
+	deliveries, err := d.webhooks.LeaseDueWebhookDeliveries(ctx, d.batchSize, d.lease)
+	if err != nil {
+		return 0, fmt.Errorf("lease webhook deliveries: %w", err)
+	}
+
+	delivered := 0
+	errs := []error{}
+	for _, delivery := range deliveries {
+		// the remaining deliveries are posted once their lease ends
+		if ctx.Err() != nil {
+			break
+		}
+
+		ok, err := d.deliver(ctx, delivery)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("deliver webhook %s: %w", delivery.ID.String(), err))
+			continue
+		}
+
+		if ok {
+			delivered++
+		}
+	}
+
+	return delivered, errors.Join(errs...)
+}
+
+// deliver posts a single delivery and records the outcome, it only returns an error when recording fails. A post
+// interrupted by ctx is not an attempt and is left to the lease.
+func (d *WebhookDispatcher) deliver(ctx context.Context, delivery model.LeasedWebhookDelivery) (bool, error) {
+	attempt := delivery.Attempts + 1
+
+	ctx, span := d.tracer.Start(ctx, "deliverWebhook", trace.WithAttributes(
+		attribute.String("webhook.delivery_id", delivery.ID.String()),
+		attribute.String("webhook.subscription_id", delivery.SubscriptionID.String()),
+		attribute.String("event.type", delivery.EventType),
+		attribute.Int("webhook.attempt", attempt),
+	))
+	defer span.End()
+
+	start := time.Now()
+	statusCode, deliverErr := d.client.Deliver(ctx, webhooks.Delivery{
+		ID:          delivery.ID.String(),
+		URL:         delivery.URL,
+		Secret:      delivery.Secret,
+		EventType:   delivery.EventType,
+		ContentType: cloudevents.ContentType,
+		Body:        delivery.Payload,
+	})
+	duration := time.Since(start)
+
+	if deliverErr != nil && ctx.Err() != nil {
+		return false, nil
+	}
+
+	outcome := model.WebhookDeliveryAttempt{
+		DeliveryID:     delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		Attempt:        attempt,
+		Duration:       duration,
+	}
+	if statusCode != 0 {
+		outcome.StatusCode = &statusCode
+	}
+	if deliverErr != nil {
+		errMessage := deliverErr.Error()
+		outcome.Error = &errMessage
+
+		span.RecordError(deliverErr)
+		span.SetStatus(codes.Error, deliverErr.Error())
+
+		slog.Warn("webhook delivery failed", "error", deliverErr, "deliveryId", delivery.ID.String(),
+			"subscriptionId", delivery.SubscriptionID.String(), "attempt", attempt)
+	}
+
+	// a delivery that was sent is recorded even when ctx is canceled meanwhile, so it is not sent again
+	disabled, err := d.webhooks.RecordWebhookDeliveryAttempt(context.WithoutCancel(ctx), outcome, model.WebhookRetryPolicy{
+		MaxAttempts:          d.maxAttempts,
+		RetryIn:              backoff(d.retryBaseDelay, d.retryMaxDelay, attempt),
+		DisableAfterFailures: d.disableAfterFailures,
+	})
+	if err != nil {
+		return false, fmt.Errorf("record delivery attempt: %w", err)
+	}
+
+	if disabled {
+		slog.Warn("webhook subscription disabled after repeated failures", "subscriptionId", delivery.SubscriptionID.String(),
+			"consecutiveFailures", d.disableAfterFailures)
+	}
+
+	return deliverErr == nil, nil
+}
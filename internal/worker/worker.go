@@ -3,62 +3,395 @@ package worker
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/leetm4n/orders-service/internal/model"
+	"github.com/leetm4n/orders-service/pkg/cloudevents"
+	"github.com/leetm4n/orders-service/pkg/events"
 	"github.com/leetm4n/orders-service/pkg/tracing"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 )
 
+const receiveRetryInterval = time.Second
+
+// Worker receives events on a single loop and hands them to a pool of goroutines, a failing handler is retried
+// with exponential backoff until the attempts run out, then the event is dead-lettered. Without DeadLetters events
+// cannot be dead-lettered and without Webhooks no webhook deliveries are created.
 type Worker struct {
-	orderCreatedCh <-chan model.OrderCreatedEvent
+	consumer       events.Consumer
+	outbox         model.OutboxRepository
+	webhooks       model.WebhookRepository
+	deadLetters    model.DeadLetterRepository
+	concurrency    int
+	maxAttempts    int
+	retryBaseDelay time.Duration
+	retryMaxDelay  time.Duration
+	drainTimeout   time.Duration
 	tracer         trace.Tracer
+	metrics        workerMetrics
+	lastBeat       atomic.Int64
+	// heartbeatInterval and heartbeatTimeout are the package defaults, tests shorten them
+	heartbeatInterval time.Duration
+	heartbeatTimeout  time.Duration
+}
+
+type WorkerOptions struct {
+	Consumer       events.Consumer
+	Outbox         model.OutboxRepository
+	Webhooks       model.WebhookRepository
+	DeadLetters    model.DeadLetterRepository
+	Concurrency    int
+	MaxAttempts    int
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+	DrainTimeout   time.Duration
 }
 
-func New(orderCreatedCh <-chan model.OrderCreatedEvent) *Worker {
+func New(opts WorkerOptions) *Worker {
 	return &Worker{
-		orderCreatedCh: orderCreatedCh,
+		consumer:       opts.Consumer,
+		outbox:         opts.Outbox,
+		webhooks:       opts.Webhooks,
+		deadLetters:    opts.DeadLetters,
+		concurrency:    max(opts.Concurrency, 1),
+		maxAttempts:    max(opts.MaxAttempts, 1),
+		retryBaseDelay: opts.RetryBaseDelay,
+		retryMaxDelay:  opts.RetryMaxDelay,
+		drainTimeout:   opts.DrainTimeout,
 		tracer:         otel.Tracer("orders-ms-worker"),
+		metrics:        newWorkerMetrics(opts.Consumer),
+
+		heartbeatInterval: heartbeatInterval,
+		heartbeatTimeout:  heartbeatTimeout,
 	}
 }
 
-var ErrChannelClosed = errors.New("event emitter channel closed")
+var ErrConsumerClosed = errors.New("event consumer closed")
 
+// Start processes events until ctx is canceled, then drains the events the consumer still holds. Events that are not
+// processed within the drain timeout are persisted so they are delivered again after restart.
 func (w *Worker) Start(ctx context.Context) error {
-	slog.Info("worker starting")
+	slog.Info("worker starting", "concurrency", w.concurrency)
+
+	w.beat()
+	defer w.lastBeat.Store(0)
+
+	// handlers outlive ctx, they are only interrupted once the drain timeout passes
+	processCtx, cancelProcessing := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelProcessing()
+
+	messages := make(chan events.Message)
+	report := &drainReport{}
+
+	wg := sync.WaitGroup{}
+	for range w.concurrency {
+		wg.Go(func() {
+			for msg := range messages {
+				w.handle(processCtx, msg, report)
+			}
+		})
+	}
+
+	pending, err := w.receive(ctx, messages)
+	if err == nil {
+		report.draining.Store(true)
+
+		timer := time.AfterFunc(w.drainTimeout, cancelProcessing)
+		defer timer.Stop()
+
+		pending = w.drain(processCtx, messages, pending)
+	}
+
+	close(messages)
+	wg.Wait()
+
+	w.persistRemaining(ctx, pending, report)
+
+	slog.Info("worker stopped", "drained", report.drained.Load(), "persisted", report.persisted.Load())
+
+	return err
+}
+
+// receive feeds messages to the pool, it blocks while every goroutine of the pool is busy. A message received right
+// before ctx was canceled is returned as pending. It beats on every message, on every poll without one and while it
+// waits for the pool.
+func (w *Worker) receive(ctx context.Context, messages chan<- events.Message) ([]events.Message, error) {
+	heartbeat := time.NewTicker(w.heartbeatInterval)
+	defer heartbeat.Stop()
 
+	for {
+		w.beat()
+
+		msg, err := w.poll(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				slog.Info("worker stopping due to context cancellation")
+
+				return nil, nil
+			}
+
+			if errors.Is(err, context.DeadlineExceeded) {
+				continue
+			}
+
+			if errors.Is(err, events.ErrClosed) {
+				return nil, ErrConsumerClosed
+			}
+
+			slog.Error("failed to receive event", "error", err)
+
+			select {
+			case <-ctx.Done():
+			case <-time.After(receiveRetryInterval):
+			}
+
+			continue
+		}
+
+		if !w.handOver(ctx, messages, msg, heartbeat.C) {
+			slog.Info("worker stopping due to context cancellation")
+
+			return []events.Message{msg}, nil
+		}
+	}
+}
+
+// handOver waits for a goroutine of the pool to take msg and beats meanwhile, a busy pool does not make the worker
+// unready. It reports false when ctx was canceled first.
+func (w *Worker) handOver(ctx context.Context, messages chan<- events.Message, msg events.Message, heartbeat <-chan time.Time) bool {
 	for {
 		select {
 		case <-ctx.Done():
-			slog.Info("worker stopping due to context cancellation")
+			return false
+		case messages <- msg:
+			return true
+		case <-heartbeat:
+			w.beat()
+		}
+	}
+}
+
+// poll waits at most one heartbeat interval for a message, so an idle worker keeps beating.
+func (w *Worker) poll(ctx context.Context) (events.Message, error) {
+	pollCtx, cancel := context.WithTimeout(ctx, w.heartbeatInterval)
+	defer cancel()
+
+	return w.consumer.Receive(pollCtx)
+}
+
+// handle processes msg and dead-letters it when processing fails, an event interrupted by the drain timeout did not
+// fail and is persisted instead. msg is acked once it was processed, dead-lettered or persisted, otherwise it is
+// nacked so the transport delivers it again.
+func (w *Worker) handle(processCtx context.Context, msg events.Message, report *drainReport) {
+	start := time.Now()
+	err := w.processEvent(processCtx, msg)
+
+	switch {
+	case err == nil:
+		w.metrics.record(processCtx, msg, outcomeProcessed, start)
+		w.settle(msg, true)
+	case processCtx.Err() != nil:
+		w.metrics.record(context.WithoutCancel(processCtx), msg, outcomeInterrupted, start)
+
+		persistCtx, cancel := context.WithTimeout(context.WithoutCancel(processCtx), persistTimeout)
+		defer cancel()
+
+		w.settle(msg, w.persist(persistCtx, msg, report))
+
+		return
+	default:
+		w.metrics.record(processCtx, msg, outcomeFailed, start)
+		slog.Error("failed to process event", "error", err, "eventId", msg.ID, "eventType", msg.Type)
+
+		if err := w.deadLetter(processCtx, msg, err); err != nil {
+			slog.Error("failed to dead-letter event", "error", err, "eventId", msg.ID, "eventType", msg.Type)
+			w.settle(msg, false)
+		} else {
+			w.settle(msg, true)
+		}
+	}
+
+	if report.draining.Load() {
+		report.drained.Add(1)
+	}
+}
+
+func (w *Worker) processEvent(ctx context.Context, msg events.Message) error {
+	switch msg.Type {
+	case model.OrderCreatedEventType:
+		event := model.OrderCreatedEvent{}
+		trace, err := decodeEvent(msg, &event)
+		if err != nil {
+			return fmt.Errorf("decode order created event: %w", err)
+		}
+
+		if err := w.handleWithRetries(ctx, msg, trace, "processOrderEvent", func(ctx context.Context) error {
+			return w.processOrderEvent(ctx, event)
+		}); err != nil {
+			return err
+		}
+
+		return w.enqueueWithRetries(ctx, msg, trace)
+	case model.OrderStatusChangedEventType:
+		event := model.OrderStatusChangedEvent{}
+		trace, err := decodeEvent(msg, &event)
+		if err != nil {
+			return fmt.Errorf("decode order status changed event: %w", err)
+		}
+
+		if err := w.handleWithRetries(ctx, msg, trace, "processOrderStatusChangedEvent", func(context.Context) error {
+			slog.Info("order status changed", "orderId", event.Order.ID, "from", event.PreviousStatus, "to", event.Order.Status)
 
 			return nil
-		case order, ok := <-w.orderCreatedCh:
-			if !ok {
-				return ErrChannelClosed
-			}
+		}); err != nil {
+			return err
+		}
 
-			if err := w.processOrderEvent(ctx, order); err != nil {
-				slog.Error("failed to process order event", "error", err)
-			}
+		return w.enqueueWithRetries(ctx, msg, trace)
+	case model.OrderCanceledEventType:
+		event := model.OrderCanceledEvent{}
+		trace, err := decodeEvent(msg, &event)
+		if err != nil {
+			return fmt.Errorf("decode order canceled event: %w", err)
+		}
+
+		if err := w.handleWithRetries(ctx, msg, trace, "processOrderCanceledEvent", func(ctx context.Context) error {
+			return w.processOrderCanceledEvent(ctx, event)
+		}); err != nil {
+			return err
 		}
+
+		return w.enqueueWithRetries(ctx, msg, trace)
+	default:
+		slog.Warn("skipping event of unknown type", "eventId", msg.ID, "eventType", msg.Type)
+
+		return nil
 	}
 }
 
-func (w *Worker) processOrderEvent(ctx context.Context, event model.OrderCreatedEvent) error {
-	slog.Info("processing order event", "orderId", event.Order.ID)
+// decodeEvent decodes the CloudEvent in msg, its data into data, and returns its trace context.
+func decodeEvent(msg events.Message, data any) (tracing.TraceEnvelope, error) {
+	event, err := cloudevents.Parse(msg.Payload)
+	if err != nil {
+		return tracing.TraceEnvelope{}, err
+	}
 
-	eventCtx := tracing.DeserializeTraceCtx(event.Trace)
+	if err := event.DecodeData(data); err != nil {
+		return tracing.TraceEnvelope{}, err
+	}
 
-	_, span := w.tracer.Start(eventCtx, "processOrderEvent")
+	return tracing.TraceEnvelope(event.TraceContext(tracing.Fields())), nil
+}
+
+// enqueueWithRetries creates the webhook deliveries of an event that was processed, it is retried on its own, so a
+// failing enqueue does not process the event again.
+func (w *Worker) enqueueWithRetries(ctx context.Context, msg events.Message, env tracing.TraceEnvelope) error {
+	return w.handleWithRetries(ctx, msg, env, "enqueueWebhookDeliveries", func(ctx context.Context) error {
+		return w.enqueueWebhookDeliveries(ctx, msg)
+	})
+}
+
+// handleWithRetries runs handle in a span continuing the trace of the event, once per attempt, with the baggage of
+// the event. Malformed events never get here, retrying them would not help.
+func (w *Worker) handleWithRetries(
+	ctx context.Context,
+	msg events.Message,
+	env tracing.TraceEnvelope,
+	spanName string,
+	handle func(ctx context.Context) error,
+) error {
+	parentCtx := tracing.DeserializeTraceCtx(ctx, env)
+
+	for attempt := 1; ; attempt++ {
+		err := w.attempt(parentCtx, msg, spanName, attempt, handle)
+		if err == nil {
+			return nil
+		}
+
+		if attempt >= w.maxAttempts {
+			return &attemptsError{attempts: attempt, err: err}
+		}
+
+		delay := backoff(w.retryBaseDelay, w.retryMaxDelay, attempt)
+
+		slog.Warn("event processing failed, retrying", "error", err, "eventId", msg.ID, "eventType", msg.Type,
+			"attempt", attempt, "retryIn", delay)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("retry canceled after %d attempts: %w", attempt, err)
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (w *Worker) attempt(
+	ctx context.Context,
+	msg events.Message,
+	spanName string,
+	attempt int,
+	handle func(ctx context.Context) error,
+) error {
+	opts := []trace.SpanStartOption{trace.WithAttributes(
+		attribute.String("event.id", msg.ID),
+		attribute.String("event.type", msg.Type),
+		attribute.Int("event.attempt", attempt),
+	)}
+
+	// the producer span is the parent and, as the messaging conventions suggest, linked as the creation context of
+	// the event, so backends showing consumer spans on their own still lead back to it
+	if producer := trace.SpanContextFromContext(ctx); producer.IsValid() {
+		opts = append(opts, trace.WithLinks(trace.Link{SpanContext: producer}))
+	}
+
+	ctx, span := w.tracer.Start(ctx, spanName, opts...)
 	defer span.End()
-	// Simulate processing the order event
 
+	if err := handle(ctx); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return err
+	}
+
+	return nil
+}
+
+// backoff doubles base for every attempt up to maxDelay and picks a random delay from the upper half of it, so
+// events failing together do not retry in lockstep.
+func backoff(base, maxDelay time.Duration, attempt int) time.Duration {
+	delay := base
+	for i := 1; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+
+	delay = min(delay, maxDelay)
+	if delay <= 0 {
+		return 0
+	}
+
+	half := delay / 2
+
+	return half + rand.N(delay-half+1)
+}
+
+func (w *Worker) processOrderEvent(ctx context.Context, event model.OrderCreatedEvent) error {
+	slog.Info("processing order event", "orderId", event.Order.ID)
+
+	// Simulate processing the order event
 	select {
 	case <-ctx.Done():
 		slog.Info("worker stopping processing due to context cancellation")
+
+		return ctx.Err()
 	case <-time.After(2 * time.Second):
 		// Simulated processing time
 		slog.Info("order processed", "orderId", event.Order.ID)
@@ -66,3 +399,14 @@ func (w *Worker) processOrderEvent(ctx context.Context, event model.OrderCreated
 
 	return nil
 }
+
+func (w *Worker) processOrderCanceledEvent(_ context.Context, event model.OrderCanceledEvent) error {
+	reason := ""
+	if event.Order.Cancellation != nil {
+		reason = event.Order.Cancellation.Reason
+	}
+
+	slog.Info("order canceled", "orderId", event.Order.ID, "reason", reason)
+
+	return nil
+}
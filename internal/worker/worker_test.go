@@ -0,0 +1,753 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leetm4n/orders-service/internal/model"
+	"github.com/leetm4n/orders-service/internal/repo/memory"
+	"github.com/leetm4n/orders-service/pkg/cloudevents"
+	"github.com/leetm4n/orders-service/pkg/events"
+	"github.com/leetm4n/orders-service/pkg/tracing"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestBackoff(t *testing.T) {
+	type args struct {
+		base     time.Duration
+		maxDelay time.Duration
+		attempt  int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantMin time.Duration
+		wantMax time.Duration
+	}{
+		{
+			name:    "should stay around base on first attempt",
+			args:    args{base: 100 * time.Millisecond, maxDelay: time.Second, attempt: 1},
+			wantMin: 50 * time.Millisecond,
+			wantMax: 100 * time.Millisecond,
+		},
+		{
+			name:    "should double for every attempt",
+			args:    args{base: 100 * time.Millisecond, maxDelay: time.Second, attempt: 3},
+			wantMin: 200 * time.Millisecond,
+			wantMax: 400 * time.Millisecond,
+		},
+		{
+			name:    "should be capped at max delay",
+			args:    args{base: 100 * time.Millisecond, maxDelay: time.Second, attempt: 100},
+			wantMin: 500 * time.Millisecond,
+			wantMax: time.Second,
+		},
+		{
+			name:    "should not wait without base delay",
+			args:    args{base: 0, maxDelay: time.Second, attempt: 2},
+			wantMin: 0,
+			wantMax: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for range 100 {
+				got := backoff(tt.args.base, tt.args.maxDelay, tt.args.attempt)
+				if got < tt.wantMin || got > tt.wantMax {
+					t.Fatalf("backoff() = %v, want between %v and %v", got, tt.wantMin, tt.wantMax)
+				}
+			}
+		})
+	}
+}
+
+func TestHandleWithRetries(t *testing.T) {
+	errHandler := errors.New("handler failed")
+
+	type args struct {
+		failures int
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantAttempts int
+		wantErr      bool
+	}{
+		{
+			name:         "should not retry successful handler",
+			args:         args{failures: 0},
+			wantAttempts: 1,
+			wantErr:      false,
+		},
+		{
+			name:         "should retry until handler succeeds",
+			args:         args{failures: 2},
+			wantAttempts: 3,
+			wantErr:      false,
+		},
+		{
+			name:         "should give up after max attempts",
+			args:         args{failures: 10},
+			wantAttempts: 3,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := New(WorkerOptions{
+				MaxAttempts:    3,
+				RetryBaseDelay: time.Millisecond,
+				RetryMaxDelay:  time.Millisecond,
+			})
+
+			attempts := 0
+			err := w.handleWithRetries(context.Background(), events.Message{ID: "1"}, tracing.TraceEnvelope{}, "test",
+				func(context.Context) error {
+					attempts++
+					if attempts <= tt.args.failures {
+						return errHandler
+					}
+
+					return nil
+				})
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("handleWithRetries() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil && !errors.Is(err, errHandler) {
+				t.Errorf("handleWithRetries() error = %v, want wrapped %v", err, errHandler)
+			}
+
+			var e *attemptsError
+			if err != nil && (!errors.As(err, &e) || e.attempts != tt.wantAttempts) {
+				t.Errorf("handleWithRetries() error = %v, want attempts error with %d attempts", err, tt.wantAttempts)
+			}
+
+			if attempts != tt.wantAttempts {
+				t.Errorf("handleWithRetries() attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+// flakyWebhookRepository fails to create webhook deliveries the first failures times.
+type flakyWebhookRepository struct {
+	*memory.WebhookRepository
+	failures int
+}
+
+func (r *flakyWebhookRepository) CreateWebhookDeliveries(ctx context.Context, eventID, eventType string, payload []byte) (int, error) {
+	if r.failures > 0 {
+		r.failures--
+
+		return 0, errors.New("webhooks unavailable")
+	}
+
+	return r.WebhookRepository.CreateWebhookDeliveries(ctx, eventID, eventType, payload)
+}
+
+func TestProcessEventRetriesEnqueueOnItsOwn(t *testing.T) {
+	webhookRepository := &flakyWebhookRepository{WebhookRepository: memory.NewWebhookRepository(), failures: 1}
+	if _, err := webhookRepository.CreateWebhookSubscription(t.Context(), model.NewWebhookSubscription{
+		URL:    "https://hooks.example.com",
+		Secret: "secret",
+	}); err != nil {
+		t.Fatalf("CreateWebhookSubscription() error = %v", err)
+	}
+
+	event, err := cloudevents.New(uuid.NewString(), "/orders-service", model.OrderStatusChangedEventType, "", time.Now(),
+		model.OrderStatusChangedEvent{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	recorder := tracetest.NewSpanRecorder()
+	w := New(WorkerOptions{Webhooks: webhookRepository, MaxAttempts: 3})
+	w.tracer = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	if err := w.processEvent(t.Context(), events.Message{ID: event.ID, Type: event.Type, Payload: payload}); err != nil {
+		t.Fatalf("processEvent() error = %v", err)
+	}
+
+	spans := map[string]int{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()]++
+	}
+
+	want := map[string]int{"processOrderStatusChangedEvent": 1, "enqueueWebhookDeliveries": 2}
+	if !reflect.DeepEqual(spans, want) {
+		t.Errorf("processEvent() ended spans %v, want %v", spans, want)
+	}
+
+	leased, err := webhookRepository.LeaseDueWebhookDeliveries(t.Context(), 10, time.Minute)
+	if err != nil {
+		t.Fatalf("LeaseDueWebhookDeliveries() error = %v", err)
+	}
+
+	if len(leased) != 1 {
+		t.Errorf("processEvent() created %d webhook deliveries, want 1", len(leased))
+	}
+}
+
+func TestHandleWithRetriesContinuesTrace(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	recorder := tracetest.NewSpanRecorder()
+	w := New(WorkerOptions{MaxAttempts: 2})
+	w.tracer = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	env := tracing.TraceEnvelope{
+		"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"tracestate":  "vendor=value",
+		"baggage":     "tenant=acme",
+	}
+
+	tenant := ""
+	err := w.handleWithRetries(t.Context(), events.Message{ID: "1"}, env, "test", func(ctx context.Context) error {
+		tenant = baggage.FromContext(ctx).Member("tenant").Value()
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("handleWithRetries() error = %v", err)
+	}
+
+	if tenant != "acme" {
+		t.Errorf("handler baggage tenant = %q, want %q", tenant, "acme")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("handleWithRetries() ended %d spans, want 1", len(spans))
+	}
+
+	span := spans[0]
+	if span.Parent().SpanID().String() != "00f067aa0ba902b7" || span.SpanContext().TraceState().String() != "vendor=value" {
+		t.Errorf("span parent = %+v, want the producer span", span.Parent())
+	}
+
+	if links := span.Links(); len(links) != 1 || !links[0].SpanContext.Equal(span.Parent()) {
+		t.Errorf("span links = %+v, want a link to the producer span", links)
+	}
+}
+
+func TestStartDrainsBufferedEvents(t *testing.T) {
+	broker := events.NewChannelBroker(10)
+	for i := range 5 {
+		if err := broker.Publish(context.Background(), events.Message{ID: strconv.Itoa(i), Type: "unknown"}); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+
+	w := New(WorkerOptions{
+		Consumer:     broker,
+		Concurrency:  2,
+		DrainTimeout: time.Second,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := w.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	if broker.Len() != 0 {
+		t.Errorf("Start() left %d events in the broker", broker.Len())
+	}
+
+	if _, err := broker.Receive(context.Background()); !errors.Is(err, events.ErrClosed) {
+		t.Errorf("Receive() error = %v, want %v", err, events.ErrClosed)
+	}
+}
+
+// standInAMQPChannel is a stand-in for a broker channel holding deliveries the client buffered, it records how they
+// were settled.
+type standInAMQPChannel struct {
+	mu         sync.Mutex
+	deliveries chan amqp.Delivery
+	acked      []uint64
+	nacked     []uint64
+	canceled   bool
+	closed     bool
+}
+
+func (c *standInAMQPChannel) Confirm(bool) error {
+	return nil
+}
+
+// PublishWithDeferredConfirmWithContext is not used, the worker only consumes.
+func (c *standInAMQPChannel) PublishWithDeferredConfirmWithContext(
+	context.Context,
+	string, string,
+	bool, bool,
+	amqp.Publishing,
+) (*amqp.DeferredConfirmation, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *standInAMQPChannel) Qos(int, int, bool) error {
+	return nil
+}
+
+func (c *standInAMQPChannel) Consume(string, string, bool, bool, bool, bool, amqp.Table) (<-chan amqp.Delivery, error) {
+	return c.deliveries, nil
+}
+
+func (c *standInAMQPChannel) Cancel(string, bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.canceled = true
+	close(c.deliveries)
+
+	return nil
+}
+
+func (c *standInAMQPChannel) Ack(tag uint64, _ bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.acked = append(c.acked, tag)
+
+	return nil
+}
+
+func (c *standInAMQPChannel) Nack(tag uint64, _, _ bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.nacked = append(c.nacked, tag)
+
+	return nil
+}
+
+func (c *standInAMQPChannel) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closed = true
+
+	return nil
+}
+
+func TestStartSettlesInFlightEventsOnShutdown(t *testing.T) {
+	type args struct {
+		// withOutbox lets the worker persist the events it could not process, without it persisting fails
+		withOutbox bool
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantAcked  int
+		wantNacked int
+	}{
+		{
+			name:      "should ack in-flight events once persisted",
+			args:      args{withOutbox: true},
+			wantAcked: 4,
+		},
+		{
+			name:       "should nack in-flight events that could not be persisted",
+			args:       args{withOutbox: false},
+			wantNacked: 4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outbox := memory.NewOrderRepository()
+			ch := &standInAMQPChannel{deliveries: make(chan amqp.Delivery, 4)}
+
+			for i := range 4 {
+				event, err := cloudevents.New(uuid.NewString(), "/orders-service", model.OrderCreatedEventType, "",
+					time.Now(), model.OrderCreatedEvent{})
+				if err != nil {
+					t.Fatalf("New() error = %v", err)
+				}
+
+				payload, err := json.Marshal(event)
+				if err != nil {
+					t.Fatalf("Marshal() error = %v", err)
+				}
+
+				if err := outbox.CreateOutboxEvent(t.Context(), model.OutboxEvent{
+					ID:        uuid.MustParse(event.ID),
+					EventType: event.Type,
+					Payload:   payload,
+				}); err != nil {
+					t.Fatalf("CreateOutboxEvent() error = %v", err)
+				}
+
+				ch.deliveries <- amqp.Delivery{DeliveryTag: uint64(i + 1), MessageId: event.ID, Type: event.Type, Body: payload}
+			}
+
+			dispatchAll(t, outbox)
+
+			opts := WorkerOptions{
+				Consumer:     events.NewAMQPConsumer(ch, "orders-service", 4),
+				Concurrency:  1,
+				DrainTimeout: 50 * time.Millisecond,
+			}
+			if tt.args.withOutbox {
+				opts.Outbox = outbox
+			}
+
+			w := New(opts)
+
+			ctx, cancel := context.WithCancel(t.Context())
+			stopped := make(chan error)
+			go func() {
+				stopped <- w.Start(ctx)
+			}()
+
+			// processing an order created event takes seconds, one event is processed and one waits for the pool
+			for len(ch.deliveries) > 2 {
+				time.Sleep(time.Millisecond)
+			}
+
+			cancel()
+			if err := <-stopped; err != nil {
+				t.Fatalf("Start() error = %v", err)
+			}
+
+			if !ch.canceled || ch.closed {
+				t.Errorf("Start() canceled %v closed %v the channel, want it canceled and open", ch.canceled, ch.closed)
+			}
+
+			if len(ch.acked) != tt.wantAcked || len(ch.nacked) != tt.wantNacked {
+				t.Errorf("Start() acked %v nacked %v, want %d acked and %d nacked", ch.acked, ch.nacked,
+					tt.wantAcked, tt.wantNacked)
+			}
+
+			if !tt.args.withOutbox {
+				return
+			}
+
+			if requeued := dispatchAll(t, outbox); requeued != 4 {
+				t.Errorf("dispatchAll() = %d after shutdown, want the 4 persisted events", requeued)
+			}
+		})
+	}
+}
+
+func TestStartDeadLettersUndecodableDelivery(t *testing.T) {
+	ch := &standInAMQPChannel{deliveries: make(chan amqp.Delivery, 1)}
+	ch.deliveries <- amqp.Delivery{
+		DeliveryTag: 1,
+		MessageId:   uuid.NewString(),
+		Type:        model.OrderCreatedEventType,
+		Headers:     amqp.Table{cloudevents.AMQPHeaderPrefix + "specversion": "1.0"},
+		Body:        []byte(`{"order":{}}`),
+	}
+
+	deadLetters := memory.NewDeadLetterRepository(memory.NewOrderRepository())
+	w := New(WorkerOptions{
+		Consumer:    events.NewAMQPConsumer(ch, "orders-service", 1),
+		DeadLetters: deadLetters,
+	})
+
+	ctx, cancel := context.WithCancel(t.Context())
+	stopped := make(chan error)
+	go func() {
+		stopped <- w.Start(ctx)
+	}()
+
+	for {
+		ch.mu.Lock()
+		settled := len(ch.acked) + len(ch.nacked)
+		ch.mu.Unlock()
+
+		if settled > 0 {
+			break
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	cancel()
+	if err := <-stopped; err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(ch.acked, []uint64{1}) || len(ch.nacked) != 0 {
+		t.Errorf("Start() acked %v nacked %v, want the delivery acked", ch.acked, ch.nacked)
+	}
+
+	deadLettered, err := deadLetters.ListDeadLetterEvents(t.Context(), model.DeadLetterFilter{Limit: 10})
+	if err != nil {
+		t.Fatalf("ListDeadLetterEvents() error = %v", err)
+	}
+
+	if len(deadLettered) != 1 || string(deadLettered[0].Payload) != `{"order":{}}` {
+		t.Errorf("ListDeadLetterEvents() = %+v, want the raw body dead-lettered", deadLettered)
+	}
+}
+
+func TestRelayPublishesOutboxEventsOnce(t *testing.T) {
+	outbox := memory.NewOrderRepository()
+	for range 3 {
+		if err := outbox.CreateOutboxEvent(t.Context(), model.OutboxEvent{
+			ID:          uuid.New(),
+			AggregateID: uuid.New(),
+			EventType:   "order.created",
+			Payload:     []byte(`{}`),
+		}); err != nil {
+			t.Fatalf("CreateOutboxEvent() error = %v", err)
+		}
+	}
+
+	broker := events.NewChannelBroker(10)
+	relay := NewRelay(RelayOptions{Outbox: outbox, Producer: broker, BatchSize: 2, Lease: time.Minute})
+
+	for _, want := range []int{2, 1, 0} {
+		dispatched, err := relay.dispatchBatch(t.Context())
+		if err != nil {
+			t.Fatalf("dispatchBatch() error = %v", err)
+		}
+
+		if dispatched != want {
+			t.Errorf("dispatchBatch() = %d, want %d", dispatched, want)
+		}
+	}
+
+	if broker.Len() != 3 {
+		t.Errorf("broker has %d events, want 3", broker.Len())
+	}
+}
+
+// failingProducer fails to publish the events with the given ids.
+type failingProducer struct {
+	*events.ChannelBroker
+	fail map[string]bool
+}
+
+func (p failingProducer) Publish(ctx context.Context, msg events.Message) error {
+	if p.fail[msg.ID] {
+		return errors.New("broker unavailable")
+	}
+
+	return p.ChannelBroker.Publish(ctx, msg)
+}
+
+func TestRelayKeepsPublishedEventsWhenPublishFails(t *testing.T) {
+	outbox := memory.NewOrderRepository()
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	for _, id := range ids {
+		if err := outbox.CreateOutboxEvent(t.Context(), model.OutboxEvent{
+			ID:          id,
+			AggregateID: uuid.New(),
+			EventType:   "order.created",
+			Payload:     []byte(`{}`),
+		}); err != nil {
+			t.Fatalf("CreateOutboxEvent() error = %v", err)
+		}
+	}
+
+	broker := events.NewChannelBroker(10)
+	relay := NewRelay(RelayOptions{
+		Outbox:    outbox,
+		Producer:  failingProducer{ChannelBroker: broker, fail: map[string]bool{ids[1].String(): true}},
+		BatchSize: 10,
+		Lease:     time.Minute,
+	})
+
+	dispatched, err := relay.dispatchBatch(t.Context())
+	if err == nil || dispatched != 1 {
+		t.Fatalf("dispatchBatch() = %d, %v, want 1 event and an error", dispatched, err)
+	}
+
+	// the leased events are not due again before their lease ends
+	pending, err := outbox.LeasePendingOutboxEvents(t.Context(), 10, 0)
+	if err != nil {
+		t.Fatalf("LeasePendingOutboxEvents() error = %v", err)
+	}
+
+	if len(pending) != 0 {
+		t.Errorf("LeasePendingOutboxEvents() = %d events during the lease, want 0", len(pending))
+	}
+
+	if broker.Len() != 1 {
+		t.Errorf("broker has %d events, want the one published before the failure", broker.Len())
+	}
+}
+
+// dispatchAll marks every pending event of the outbox as dispatched and returns how many there were.
+func dispatchAll(t *testing.T, outbox model.OutboxRepository) int {
+	t.Helper()
+
+	relay := NewRelay(RelayOptions{Outbox: outbox, Producer: events.NewChannelBroker(100), BatchSize: 100, Lease: time.Minute})
+	dispatched, err := relay.dispatchBatch(t.Context())
+	if err != nil {
+		t.Fatalf("dispatchBatch() error = %v", err)
+	}
+
+	return dispatched
+}
+
+func TestRelayPublishSpanLinksEmitter(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	event, err := cloudevents.New(uuid.NewString(), "/orders-service", model.OrderCreatedEventType, "", time.Now(), model.OrderCreatedEvent{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	event.SetTraceContext(map[string]string{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"})
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	recorder := tracetest.NewSpanRecorder()
+	relay := NewRelay(RelayOptions{Producer: events.NewChannelBroker(1)})
+	relay.tracer = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	if err := relay.publish(t.Context(), model.OutboxEvent{ID: uuid.MustParse(event.ID), EventType: event.Type, Payload: payload}); err != nil {
+		t.Fatalf("publish() error = %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("publish() ended %d spans, want 1", len(spans))
+	}
+
+	if links := spans[0].Links(); len(links) != 1 || links[0].SpanContext.SpanID().String() != "00f067aa0ba902b7" {
+		t.Errorf("span links = %+v, want a link to the emitting span", links)
+	}
+}
+
+func TestPersistRequeuesOutboxEvent(t *testing.T) {
+	outbox := memory.NewOrderRepository()
+	event := model.OutboxEvent{ID: uuid.New(), AggregateID: uuid.New(), EventType: "order.created"}
+	if err := outbox.CreateOutboxEvent(t.Context(), event); err != nil {
+		t.Fatalf("CreateOutboxEvent() error = %v", err)
+	}
+
+	dispatchAll(t, outbox)
+
+	w := New(WorkerOptions{Outbox: outbox})
+	report := &drainReport{}
+	w.persist(t.Context(), events.Message{ID: event.ID.String(), Type: event.EventType}, report)
+
+	if report.persisted.Load() != 1 {
+		t.Errorf("persist() persisted = %d, want 1", report.persisted.Load())
+	}
+
+	if dispatched := dispatchAll(t, outbox); dispatched != 1 {
+		t.Errorf("dispatchAll() = %d after requeue, want 1", dispatched)
+	}
+}
+
+func TestCheckHeartbeat(t *testing.T) {
+	w := New(WorkerOptions{Consumer: events.NewChannelBroker(1)})
+
+	if err := w.CheckHeartbeat(t.Context()); !errors.Is(err, ErrWorkerNotRunning) {
+		t.Errorf("CheckHeartbeat() before Start error = %v, want %v", err, ErrWorkerNotRunning)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	stopped := make(chan error)
+	go func() {
+		stopped <- w.Start(ctx)
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for w.CheckHeartbeat(t.Context()) != nil {
+		if time.Now().After(deadline) {
+			t.Fatalf("CheckHeartbeat() error = %v after Start", w.CheckHeartbeat(t.Context()))
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	// an idle worker beats on every poll that received nothing
+	w.lastBeat.Store(time.Now().Add(-2 * heartbeatTimeout).UnixNano())
+
+	deadline = time.Now().Add(2 * heartbeatInterval)
+	for w.CheckHeartbeat(t.Context()) != nil {
+		if time.Now().After(deadline) {
+			t.Fatalf("CheckHeartbeat() error = %v while idle", w.CheckHeartbeat(t.Context()))
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancel()
+	if err := <-stopped; err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	if err := w.CheckHeartbeat(t.Context()); !errors.Is(err, ErrWorkerNotRunning) {
+		t.Errorf("CheckHeartbeat() after stop error = %v, want %v", err, ErrWorkerNotRunning)
+	}
+
+	w.lastBeat.Store(time.Now().Add(-2 * heartbeatTimeout).UnixNano())
+	if err := w.CheckHeartbeat(t.Context()); err == nil {
+		t.Error("CheckHeartbeat() with a stale heartbeat error = nil")
+	}
+}
+
+func TestCheckHeartbeatWhilePoolIsBusy(t *testing.T) {
+	broker := events.NewChannelBroker(10)
+	for i := range 3 {
+		event, err := cloudevents.New(strconv.Itoa(i), "/orders-service", model.OrderCreatedEventType, "", time.Now(), model.OrderCreatedEvent{})
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+
+		payload, err := json.Marshal(event)
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+
+		if err := broker.Publish(t.Context(), events.Message{ID: event.ID, Type: event.Type, Payload: payload}); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+
+	w := New(WorkerOptions{Consumer: broker, Concurrency: 1, DrainTimeout: 10 * time.Millisecond})
+	w.heartbeatInterval = 10 * time.Millisecond
+	w.heartbeatTimeout = 50 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(t.Context())
+	stopped := make(chan error)
+	go func() {
+		stopped <- w.Start(ctx)
+	}()
+
+	for w.lastBeat.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// processing an order created event takes seconds, the loop waits for the pool far longer than the timeout
+	for deadline := time.Now().Add(10 * w.heartbeatTimeout); time.Now().Before(deadline); {
+		if err := w.CheckHeartbeat(t.Context()); err != nil {
+			t.Fatalf("CheckHeartbeat() while the pool is busy error = %v", err)
+		}
+
+		time.Sleep(w.heartbeatInterval)
+	}
+
+	cancel()
+	if err := <-stopped; err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package cloudevents
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ToBinary maps the attributes of the event to headers named prefix+attribute, the data becomes the message body
+// and datacontenttype its content type.
+func ToBinary(e Event, prefix string) (headers map[string]string, contentType string, body []byte) {
+	headers = map[string]string{
+		prefix + "specversion": e.SpecVersion,
+		prefix + "id":          e.ID,
+		prefix + "source":      e.Source,
+		prefix + "type":        e.Type,
+		prefix + "time":        e.Time.Format(time.RFC3339Nano),
+	}
+
+	if e.Subject != "" {
+		headers[prefix+"subject"] = e.Subject
+	}
+
+	for name, value := range e.Extensions {
+		headers[prefix+name] = value
+	}
+
+	return headers, e.DataContentType, e.Data
+}
+
+// FromBinary is the inverse of ToBinary, headers not starting with prefix are ignored.
+func FromBinary(headers map[string]string, prefix, contentType string, body []byte) (Event, error) {
+	e := Event{
+		SpecVersion:     headers[prefix+"specversion"],
+		ID:              headers[prefix+"id"],
+		Source:          headers[prefix+"source"],
+		Type:            headers[prefix+"type"],
+		Subject:         headers[prefix+"subject"],
+		DataContentType: contentType,
+	}
+
+	for header, value := range headers {
+		name, ok := strings.CutPrefix(header, prefix)
+		if !ok || contextAttributes[name] {
+			continue
+		}
+
+		if e.Extensions == nil {
+			e.Extensions = map[string]string{}
+		}
+
+		e.Extensions[name] = value
+	}
+
+	if len(body) > 0 {
+		e.Data = body
+	}
+
+	if ts := headers[prefix+"time"]; ts != "" {
+		t, err := time.Parse(time.RFC3339Nano, ts)
+		if err != nil {
+			return Event{}, fmt.Errorf("%w: invalid time %q", ErrInvalidEvent, ts)
+		}
+
+		e.Time = t
+	}
+
+	if err := e.Validate(); err != nil {
+		return Event{}, err
+	}
+
+	return e, nil
+}
@@ -0,0 +1,217 @@
+package cloudevents
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	SpecVersion = "1.0"
+
+	// ContentType is the content type of an event in structured mode.
+	ContentType = "application/cloudevents+json"
+
+	// AMQPHeaderPrefix prefixes the attribute names of an event in binary mode.
+	AMQPHeaderPrefix = "cloudEvents:"
+)
+
+var ErrInvalidEvent = errors.New("invalid cloudevent")
+
+// Event is a CloudEvents 1.0 event with json data, it marshals into the structured json format. Extensions holds the
+// extension attributes, like traceparent and tracestate of the distributed tracing extension, at the top level of
+// the json next to the context attributes.
+type Event struct {
+	SpecVersion     string            `json:"specversion"`
+	ID              string            `json:"id"`
+	Source          string            `json:"source"`
+	Type            string            `json:"type"`
+	Subject         string            `json:"subject,omitempty"`
+	Time            time.Time         `json:"time"`
+	DataContentType string            `json:"datacontenttype,omitempty"`
+	Data            json.RawMessage   `json:"data,omitempty"`
+	Extensions      map[string]string `json:"-"`
+}
+
+// contextAttributes are the attributes defined by the spec, every other attribute is an extension.
+var contextAttributes = map[string]bool{
+	"specversion":     true,
+	"id":              true,
+	"source":          true,
+	"type":            true,
+	"subject":         true,
+	"time":            true,
+	"datacontenttype": true,
+	"dataschema":      true,
+	"data":            true,
+	"data_base64":     true,
+}
+
+// event is Event without its json methods.
+type event Event
+
+func (e Event) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(event(e))
+	if err != nil || len(e.Extensions) == 0 {
+		return b, err
+	}
+
+	attributes := map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &attributes); err != nil {
+		return nil, err
+	}
+
+	for name, value := range e.Extensions {
+		if contextAttributes[name] {
+			return nil, fmt.Errorf("extension %q collides with a context attribute", name)
+		}
+
+		v, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+
+		attributes[name] = v
+	}
+
+	return json.Marshal(attributes)
+}
+
+// UnmarshalJSON keeps extension attributes that are not strings in their json form.
+func (e *Event) UnmarshalJSON(b []byte) error {
+	decoded := event{}
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		return err
+	}
+
+	attributes := map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &attributes); err != nil {
+		return err
+	}
+
+	for name, raw := range attributes {
+		if contextAttributes[name] {
+			continue
+		}
+
+		value := ""
+		if err := json.Unmarshal(raw, &value); err != nil {
+			value = string(raw)
+		}
+
+		if decoded.Extensions == nil {
+			decoded.Extensions = map[string]string{}
+		}
+
+		decoded.Extensions[name] = value
+	}
+
+	*e = Event(decoded)
+
+	return nil
+}
+
+// New returns an event with data marshalled as json.
+func New(id, source, eventType, subject string, t time.Time, data any) (Event, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return Event{}, fmt.Errorf("marshal data: %w", err)
+	}
+
+	return Event{
+		SpecVersion:     SpecVersion,
+		ID:              id,
+		Source:          source,
+		Type:            eventType,
+		Subject:         subject,
+		Time:            t.UTC(),
+		DataContentType: "application/json",
+		Data:            b,
+	}, nil
+}
+
+// Parse decodes an event in structured mode and checks its required attributes.
+func Parse(b []byte) (Event, error) {
+	e := Event{}
+	if err := json.Unmarshal(b, &e); err != nil {
+		return Event{}, fmt.Errorf("%w: %w", ErrInvalidEvent, err)
+	}
+
+	if err := e.Validate(); err != nil {
+		return Event{}, err
+	}
+
+	return e, nil
+}
+
+func (e Event) Validate() error {
+	switch {
+	case e.SpecVersion != SpecVersion:
+		return fmt.Errorf("%w: unsupported specversion %q", ErrInvalidEvent, e.SpecVersion)
+	case e.ID == "":
+		return fmt.Errorf("%w: missing id", ErrInvalidEvent)
+	case e.Source == "":
+		return fmt.Errorf("%w: missing source", ErrInvalidEvent)
+	case e.Type == "":
+		return fmt.Errorf("%w: missing type", ErrInvalidEvent)
+	}
+
+	return nil
+}
+
+// ExtensionName turns a header name into an extension attribute name, which may only consist of lowercase letters
+// and digits.
+func ExtensionName(header string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return unicode.ToLower(r)
+		default:
+			return -1
+		}
+	}, header)
+}
+
+// SetTraceContext stores every propagation header as the extension attribute named by ExtensionName, so the W3C
+// traceparent and tracestate end up in the attributes of the distributed tracing extension.
+func (e *Event) SetTraceContext(headers map[string]string) {
+	for header, value := range headers {
+		name := ExtensionName(header)
+		if value == "" || name == "" || contextAttributes[name] {
+			continue
+		}
+
+		if e.Extensions == nil {
+			e.Extensions = map[string]string{}
+		}
+
+		e.Extensions[name] = value
+	}
+}
+
+// TraceContext returns the propagation headers of fields, the fields of the propagator, set on the event. Extension
+// attribute names lose the case and punctuation of header names, so the headers are looked up by their fields.
+func (e Event) TraceContext(fields []string) map[string]string {
+	headers := map[string]string{}
+	for _, field := range fields {
+		if value := e.Extensions[ExtensionName(field)]; value != "" {
+			headers[field] = value
+		}
+	}
+
+	return headers
+}
+
+// DecodeData unmarshals the json data of the event into v.
+func (e Event) DecodeData(v any) error {
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return fmt.Errorf("unmarshal data: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,208 @@
+package cloudevents
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newTestEvent(t *testing.T) Event {
+	t.Helper()
+
+	e, err := New(
+		"3deb76e4-cd89-4aa3-b143-89e9c0ed11db",
+		"/orders-service",
+		"order.created",
+		"3deb76e4-cd89-4aa3-b143-89e9c0ed11ad",
+		time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+		map[string]string{"status": "pending"},
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	e.Extensions = map[string]string{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}
+
+	return e
+}
+
+func TestParse(t *testing.T) {
+	type args struct {
+		b []byte
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "should parse structured event",
+			args: args{b: []byte(`{"specversion":"1.0","id":"1","source":"/orders-service","type":"order.created"}`)},
+		},
+		{
+			name:    "should reject other specversion",
+			args:    args{b: []byte(`{"specversion":"0.3","id":"1","source":"/orders-service","type":"order.created"}`)},
+			wantErr: ErrInvalidEvent,
+		},
+		{
+			name:    "should reject missing id",
+			args:    args{b: []byte(`{"specversion":"1.0","source":"/orders-service","type":"order.created"}`)},
+			wantErr: ErrInvalidEvent,
+		},
+		{
+			name:    "should reject bare data",
+			args:    args{b: []byte(`{"order":{}}`)},
+			wantErr: ErrInvalidEvent,
+		},
+		{
+			name:    "should reject invalid json",
+			args:    args{b: []byte(`{`)},
+			wantErr: ErrInvalidEvent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(tt.args.b); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStructuredRoundTrip(t *testing.T) {
+	want := newTestEvent(t)
+
+	b, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	got, err := Parse(b)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() = %+v, want %+v", got, want)
+	}
+
+	data := map[string]string{}
+	if err := got.DecodeData(&data); err != nil {
+		t.Fatalf("DecodeData() error = %v", err)
+	}
+	if data["status"] != "pending" {
+		t.Errorf("DecodeData() = %v, want status pending", data)
+	}
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	want := newTestEvent(t)
+
+	headers, contentType, body := ToBinary(want, AMQPHeaderPrefix)
+	if headers["cloudEvents:subject"] != want.Subject || headers["cloudEvents:traceparent"] != want.Extensions["traceparent"] {
+		t.Errorf("ToBinary() headers = %v", headers)
+	}
+	if _, ok := headers["cloudEvents:tracestate"]; ok {
+		t.Errorf("ToBinary() headers = %v, want no empty tracestate", headers)
+	}
+
+	got, err := FromBinary(headers, AMQPHeaderPrefix, contentType, body)
+	if err != nil {
+		t.Fatalf("FromBinary() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FromBinary() = %+v, want %+v", got, want)
+	}
+
+	delete(headers, "cloudEvents:source")
+	if _, err := FromBinary(headers, AMQPHeaderPrefix, contentType, body); !errors.Is(err, ErrInvalidEvent) {
+		t.Errorf("FromBinary() error = %v, wantErr %v", err, ErrInvalidEvent)
+	}
+}
+
+func TestTraceContext(t *testing.T) {
+	headers := map[string]string{
+		"traceparent":   "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"tracestate":    "vendor=value",
+		"baggage":       "tenant=acme",
+		"uber-trace-id": "4bf92f3577b34da6a3ce929d0e0e4736:00f067aa0ba902b7:0:1",
+	}
+	fields := []string{"traceparent", "tracestate", "baggage", "uber-trace-id", "X-B3-TraceId"}
+
+	e := newTestEvent(t)
+	e.SetTraceContext(headers)
+
+	if e.Extensions["ubertraceid"] != headers["uber-trace-id"] {
+		t.Errorf("SetTraceContext() extensions = %v, want uber-trace-id as ubertraceid", e.Extensions)
+	}
+
+	type args struct {
+		roundTrip func(t *testing.T, e Event) Event
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "should keep every propagation header in structured mode",
+			args: args{roundTrip: func(t *testing.T, e Event) Event {
+				b, err := json.Marshal(e)
+				if err != nil {
+					t.Fatalf("Marshal() error = %v", err)
+				}
+
+				got, err := Parse(b)
+				if err != nil {
+					t.Fatalf("Parse() error = %v", err)
+				}
+
+				return got
+			}},
+		},
+		{
+			name: "should keep every propagation header in binary mode",
+			args: args{roundTrip: func(t *testing.T, e Event) Event {
+				binary, contentType, body := ToBinary(e, AMQPHeaderPrefix)
+
+				got, err := FromBinary(binary, AMQPHeaderPrefix, contentType, body)
+				if err != nil {
+					t.Fatalf("FromBinary() error = %v", err)
+				}
+
+				return got
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.args.roundTrip(t, e)
+
+			if trace := got.TraceContext(fields); !reflect.DeepEqual(trace, headers) {
+				t.Errorf("TraceContext() = %v, want %v", trace, headers)
+			}
+		})
+	}
+}
+
+func TestMarshalExtensions(t *testing.T) {
+	e := newTestEvent(t)
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	attributes := map[string]any{}
+	if err := json.Unmarshal(b, &attributes); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if attributes["traceparent"] != e.Extensions["traceparent"] || attributes["Extensions"] != nil {
+		t.Errorf("Marshal() = %s, want traceparent as top level attribute", b)
+	}
+
+	e.Extensions["id"] = "2"
+	if _, err := json.Marshal(e); err == nil {
+		t.Error("Marshal() error = nil, want error for an extension named like a context attribute")
+	}
+}
@@ -0,0 +1,290 @@
+package events
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/leetm4n/orders-service/pkg/cloudevents"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+var (
+	_ Producer = (*AMQPProducer)(nil)
+	_ Consumer = (*AMQPConsumer)(nil)
+)
+
+const amqpKeyHeader = "x-message-key"
+
+// ErrPublishNacked is returned when the broker did not take over a published message.
+var ErrPublishNacked = errors.New("publish nacked by broker")
+
+// AMQPChannel is the subset of *amqp.Channel used by the adapters, it allows running them against a stand-in broker.
+type AMQPChannel interface {
+	Confirm(noWait bool) error
+	PublishWithDeferredConfirmWithContext(
+		ctx context.Context,
+		exchange, key string,
+		mandatory, immediate bool,
+		msg amqp.Publishing,
+	) (*amqp.DeferredConfirmation, error)
+	Qos(prefetchCount, prefetchSize int, global bool) error
+	Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error)
+	Cancel(consumer string, noWait bool) error
+	Ack(tag uint64, multiple bool) error
+	Nack(tag uint64, multiple, requeue bool) error
+	Close() error
+}
+
+// DeclareAMQPTopology declares a durable topic exchange with a durable queue bound to every message type.
+func DeclareAMQPTopology(ch *amqp.Channel, exchange, queue string) error {
+	if err := ch.ExchangeDeclare(exchange, amqp.ExchangeTopic, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("declare exchange: %w", err)
+	}
+
+	if _, err := ch.QueueDeclare(queue, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("declare queue: %w", err)
+	}
+
+	if err := ch.QueueBind(queue, "#", exchange, false, nil); err != nil {
+		return fmt.Errorf("bind queue: %w", err)
+	}
+
+	return nil
+}
+
+// AMQPProducer publishes persistent messages to an exchange using the message type as routing key. The channel is
+// put into confirm mode on the first Publish, so a message only counts as published once the broker confirmed it.
+type AMQPProducer struct {
+	ch       AMQPChannel
+	exchange string
+
+	mu        sync.Mutex
+	confirmed bool
+}
+
+func NewAMQPProducer(ch AMQPChannel, exchange string) *AMQPProducer {
+	return &AMQPProducer{
+		ch:       ch,
+		exchange: exchange,
+	}
+}
+
+// Publish sends payloads holding a CloudEvent in binary mode, the attributes become cloudEvents: headers and the
+// data the body. Other payloads are sent as they are.
+func (p *AMQPProducer) Publish(ctx context.Context, msg Message) error {
+	publishing := amqp.Publishing{
+		MessageId:    msg.ID,
+		Type:         msg.Type,
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		Headers:      amqp.Table{amqpKeyHeader: msg.Key},
+		Body:         msg.Payload,
+	}
+
+	if event, err := cloudevents.Parse(msg.Payload); err == nil {
+		headers, contentType, body := cloudevents.ToBinary(event, cloudevents.AMQPHeaderPrefix)
+		for name, value := range headers {
+			publishing.Headers[name] = value
+		}
+		publishing.ContentType = contentType
+		publishing.Body = body
+	}
+
+	if err := p.confirmMode(); err != nil {
+		return err
+	}
+
+	confirmation, err := p.ch.PublishWithDeferredConfirmWithContext(ctx, p.exchange, msg.Type, false, false, publishing)
+	if err != nil {
+		return fmt.Errorf("publish: %w", err)
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("wait for publish confirm: %w", err)
+	}
+
+	if !acked {
+		return ErrPublishNacked
+	}
+
+	return nil
+}
+
+// confirmMode puts the channel into confirm mode once.
+func (p *AMQPProducer) confirmMode() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.confirmed {
+		return nil
+	}
+
+	if err := p.ch.Confirm(false); err != nil {
+		return fmt.Errorf("confirm mode: %w", err)
+	}
+
+	p.confirmed = true
+
+	return nil
+}
+
+func (p *AMQPProducer) Close() error {
+	return p.ch.Close()
+}
+
+// AMQPConsumer consumes a queue with manual acknowledgement, the subscription is started on the first Receive with
+// at most prefetch unacknowledged deliveries. Deliveries that are neither acked nor nacked when the channel closes
+// are requeued by the broker.
+type AMQPConsumer struct {
+	ch       AMQPChannel
+	queue    string
+	prefetch int
+	tag      string
+
+	mu         sync.Mutex
+	started    bool
+	closed     bool
+	deliveries <-chan amqp.Delivery
+}
+
+func NewAMQPConsumer(ch AMQPChannel, queue string, prefetch int) *AMQPConsumer {
+	return &AMQPConsumer{
+		ch:       ch,
+		queue:    queue,
+		prefetch: max(prefetch, 1),
+		tag:      "orders-service-" + rand.Text(),
+	}
+}
+
+func (c *AMQPConsumer) Receive(ctx context.Context) (Message, error) {
+	deliveries, err := c.subscribe()
+	if err != nil {
+		return Message{}, err
+	}
+
+	select {
+	case delivery, ok := <-deliveries:
+		if !ok {
+			return Message{}, ErrClosed
+		}
+
+		key, _ := delivery.Headers[amqpKeyHeader].(string)
+
+		payload, err := structuredPayload(delivery)
+		if err != nil {
+			// the payload is not going to decode on a redelivery either, the raw body is handed out instead, so the
+			// consumer dead-letters the delivery before it acks it
+			payload = delivery.Body
+		}
+
+		return Message{
+			ID:          delivery.MessageId,
+			Type:        delivery.Type,
+			Key:         key,
+			Payload:     payload,
+			deliveryTag: delivery.DeliveryTag,
+		}, nil
+	case <-ctx.Done():
+		return Message{}, ctx.Err()
+	}
+}
+
+// subscribe starts consuming on the first call, a closed consumer does not start anymore.
+func (c *AMQPConsumer) subscribe() (<-chan amqp.Delivery, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.started {
+		return c.deliveries, nil
+	}
+
+	if c.closed {
+		return nil, ErrClosed
+	}
+
+	if err := c.ch.Qos(c.prefetch, 0, false); err != nil {
+		return nil, fmt.Errorf("set prefetch: %w", err)
+	}
+
+	deliveries, err := c.ch.Consume(c.queue, c.tag, false, false, false, false, nil)
+	if err != nil {
+		return nil, fmt.Errorf("consume: %w", err)
+	}
+
+	c.started = true
+	c.deliveries = deliveries
+
+	return deliveries, nil
+}
+
+func (c *AMQPConsumer) Ack(msg Message) error {
+	if err := c.ch.Ack(msg.deliveryTag, false); err != nil {
+		return fmt.Errorf("ack: %w", err)
+	}
+
+	return nil
+}
+
+// Nack requeues msg, so it is delivered again.
+func (c *AMQPConsumer) Nack(msg Message) error {
+	if err := c.ch.Nack(msg.deliveryTag, false, true); err != nil {
+		return fmt.Errorf("nack: %w", err)
+	}
+
+	return nil
+}
+
+// Close cancels the subscription but keeps the channel open, Receive hands out the deliveries the client still buffers
+// and they can still be settled. The channel is closed with its connection.
+func (c *AMQPConsumer) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil
+	}
+
+	c.closed = true
+	if !c.started {
+		return nil
+	}
+
+	if err := c.ch.Cancel(c.tag, false); err != nil {
+		return fmt.Errorf("cancel consumer: %w", err)
+	}
+
+	return nil
+}
+
+// structuredPayload turns a CloudEvent received in binary mode back into its structured form, so consumers see the
+// same payload whatever the transport. Other deliveries are returned as they are.
+func structuredPayload(delivery amqp.Delivery) ([]byte, error) {
+	if _, ok := delivery.Headers[cloudevents.AMQPHeaderPrefix+"specversion"]; !ok {
+		return delivery.Body, nil
+	}
+
+	headers := map[string]string{}
+	for name, value := range delivery.Headers {
+		if s, ok := value.(string); ok && strings.HasPrefix(name, cloudevents.AMQPHeaderPrefix) {
+			headers[name] = s
+		}
+	}
+
+	event, err := cloudevents.FromBinary(headers, cloudevents.AMQPHeaderPrefix, delivery.ContentType, delivery.Body)
+	if err != nil {
+		return nil, fmt.Errorf("decode cloudevent: %w", err)
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("marshal cloudevent: %w", err)
+	}
+
+	return payload, nil
+}
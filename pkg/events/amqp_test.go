@@ -0,0 +1,327 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/leetm4n/orders-service/pkg/cloudevents"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// standInChannel is a local stand-in for a broker channel routing every publishing into a single queue.
+type standInChannel struct {
+	mu         sync.Mutex
+	deliveries chan amqp.Delivery
+	exchanges  []string
+	keys       []string
+	tag        uint64
+	acked      []uint64
+	nacked     []uint64
+	canceled   bool
+	confirmed  bool
+	nack       bool
+}
+
+func newStandInChannel() *standInChannel {
+	return &standInChannel{
+		deliveries: make(chan amqp.Delivery, 10),
+	}
+}
+
+func (c *standInChannel) Confirm(bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.confirmed = true
+
+	return nil
+}
+
+// PublishWithDeferredConfirmWithContext confirms right away, a nacked publishing is not routed.
+func (c *standInChannel) PublishWithDeferredConfirmWithContext(
+	_ context.Context,
+	exchange, key string,
+	_, _ bool,
+	msg amqp.Publishing,
+) (*amqp.DeferredConfirmation, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.confirmed {
+		return nil, errors.New("channel is not in confirm mode")
+	}
+
+	if c.nack {
+		return deferredConfirmation(false), nil
+	}
+
+	c.exchanges = append(c.exchanges, exchange)
+	c.keys = append(c.keys, key)
+	c.tag++
+	c.deliveries <- amqp.Delivery{
+		DeliveryTag: c.tag,
+		MessageId:   msg.MessageId,
+		Type:        msg.Type,
+		ContentType: msg.ContentType,
+		Headers:     msg.Headers,
+		Body:        msg.Body,
+		Exchange:    exchange,
+		RoutingKey:  key,
+	}
+
+	return deferredConfirmation(true), nil
+}
+
+// deferredConfirmation returns a confirmation the broker already acked or nacked. The client only creates them on a
+// connection, so the unexported fields are set the way the client does when the confirm arrives.
+func deferredConfirmation(ack bool) *amqp.DeferredConfirmation {
+	confirmation := &amqp.DeferredConfirmation{}
+	fields := reflect.ValueOf(confirmation).Elem()
+
+	done := make(chan struct{})
+	close(done)
+
+	set := func(name string, value any) {
+		field := fields.FieldByName(name)
+		reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem().Set(reflect.ValueOf(value))
+	}
+	set("done", done)
+	set("ack", ack)
+
+	return confirmation
+}
+
+func (c *standInChannel) Qos(int, int, bool) error {
+	return nil
+}
+
+func (c *standInChannel) Consume(string, string, bool, bool, bool, bool, amqp.Table) (<-chan amqp.Delivery, error) {
+	return c.deliveries, nil
+}
+
+// Cancel closes the deliveries, the buffered ones can still be received like with the client.
+func (c *standInChannel) Cancel(string, bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.canceled = true
+	close(c.deliveries)
+
+	return nil
+}
+
+func (c *standInChannel) Ack(tag uint64, _ bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.acked = append(c.acked, tag)
+
+	return nil
+}
+
+func (c *standInChannel) Nack(tag uint64, _, _ bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.nacked = append(c.nacked, tag)
+
+	return nil
+}
+
+func (c *standInChannel) Close() error {
+	return nil
+}
+
+func TestAMQPProducerConsumer(t *testing.T) {
+	ch := newStandInChannel()
+	producer := NewAMQPProducer(ch, "orders")
+	consumer := NewAMQPConsumer(ch, "orders-service", 1)
+
+	msg := Message{
+		ID:      "3deb76e4-cd89-4aa3-b143-89e9c0ed11db",
+		Type:    "order.created",
+		Key:     "3deb76e4-cd89-4aa3-b143-89e9c0ed11ad",
+		Payload: []byte(`{"order":{}}`),
+	}
+
+	if err := producer.Publish(t.Context(), msg); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(ch.exchanges, []string{"orders"}) || !reflect.DeepEqual(ch.keys, []string{"order.created"}) {
+		t.Errorf("Publish() routed to %v %v, want [orders] [order.created]", ch.exchanges, ch.keys)
+	}
+
+	got, err := consumer.Receive(t.Context())
+	if err != nil {
+		t.Fatalf("Receive() error = %v", err)
+	}
+	if got.deliveryTag != 1 {
+		t.Errorf("Receive() delivery tag = %d, want 1", got.deliveryTag)
+	}
+	got.deliveryTag = 0
+	if !reflect.DeepEqual(got, msg) {
+		t.Errorf("Receive() = %v, want %v", got, msg)
+	}
+
+	_ = consumer.Close()
+	if _, err := consumer.Receive(t.Context()); err != ErrClosed {
+		t.Errorf("Receive() error = %v, wantErr %v", err, ErrClosed)
+	}
+}
+
+func TestAMQPProducerWaitsForConfirm(t *testing.T) {
+	tests := []struct {
+		name    string
+		nack    bool
+		wantErr error
+	}{
+		{
+			name: "should publish a message the broker acked",
+		},
+		{
+			name:    "should fail to publish a message the broker nacked",
+			nack:    true,
+			wantErr: ErrPublishNacked,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch := newStandInChannel()
+			ch.nack = tt.nack
+			producer := NewAMQPProducer(ch, "orders")
+
+			if err := producer.Publish(t.Context(), Message{ID: "1", Type: "order.created"}); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Publish() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAMQPConsumerReceivesUndecodableCloudEvent(t *testing.T) {
+	ch := newStandInChannel()
+	consumer := NewAMQPConsumer(ch, "orders-service", 1)
+
+	ch.deliveries <- amqp.Delivery{
+		DeliveryTag: 1,
+		MessageId:   "1",
+		Type:        "order.created",
+		ContentType: "application/json",
+		Headers: amqp.Table{
+			cloudevents.AMQPHeaderPrefix + "specversion": "1.0",
+			cloudevents.AMQPHeaderPrefix + "time":        "yesterday",
+		},
+		Body: []byte(`{"order":{}}`),
+	}
+
+	got, err := consumer.Receive(t.Context())
+	if err != nil {
+		t.Fatalf("Receive() error = %v", err)
+	}
+
+	if got.deliveryTag != 1 || string(got.Payload) != `{"order":{}}` {
+		t.Errorf("Receive() = %+v, want the raw body with its delivery tag", got)
+	}
+
+	if len(ch.nacked) != 0 {
+		t.Errorf("Receive() nacked %v, want the delivery left to the caller", ch.nacked)
+	}
+}
+
+func TestAMQPConsumerDrainsAfterClose(t *testing.T) {
+	ch := newStandInChannel()
+	producer := NewAMQPProducer(ch, "orders")
+	consumer := NewAMQPConsumer(ch, "orders-service", 2)
+
+	for _, id := range []string{"1", "2", "3"} {
+		if err := producer.Publish(t.Context(), Message{ID: id, Type: "order.created"}); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+
+	first, err := consumer.Receive(t.Context())
+	if err != nil {
+		t.Fatalf("Receive() error = %v", err)
+	}
+
+	// closing cancels the subscription, the buffered deliveries are still handed out and settled on the channel
+	if err := consumer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if !ch.canceled {
+		t.Error("Close() did not cancel the subscription")
+	}
+
+	if err := consumer.Ack(first); err != nil {
+		t.Fatalf("Ack() error = %v", err)
+	}
+
+	for {
+		msg, err := consumer.Receive(t.Context())
+		if errors.Is(err, ErrClosed) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Receive() after Close() error = %v", err)
+		}
+
+		if err := consumer.Nack(msg); err != nil {
+			t.Fatalf("Nack() error = %v", err)
+		}
+	}
+
+	if !reflect.DeepEqual(ch.acked, []uint64{1}) || !reflect.DeepEqual(ch.nacked, []uint64{2, 3}) {
+		t.Errorf("settled acked %v nacked %v, want acked [1] nacked [2 3]", ch.acked, ch.nacked)
+	}
+}
+
+func TestAMQPProducerConsumerCloudEvent(t *testing.T) {
+	ch := newStandInChannel()
+	producer := NewAMQPProducer(ch, "orders")
+	consumer := NewAMQPConsumer(ch, "orders-service", 1)
+
+	event, err := cloudevents.New(
+		"3deb76e4-cd89-4aa3-b143-89e9c0ed11db",
+		"/orders-service",
+		"order.created",
+		"3deb76e4-cd89-4aa3-b143-89e9c0ed11ad",
+		time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+		map[string]any{"order": map[string]any{}},
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	event.SetTraceContext(map[string]string{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"})
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	msg := Message{
+		ID:      event.ID,
+		Type:    event.Type,
+		Key:     event.Subject,
+		Payload: payload,
+	}
+
+	if err := producer.Publish(t.Context(), msg); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	got, err := consumer.Receive(t.Context())
+	if err != nil {
+		t.Fatalf("Receive() error = %v", err)
+	}
+	got.deliveryTag = 0
+	if !reflect.DeepEqual(got, msg) {
+		t.Errorf("Receive() = %s, want %s", got.Payload, msg.Payload)
+	}
+}
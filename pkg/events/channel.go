@@ -0,0 +1,85 @@
+package events
+
+import (
+	"context"
+	"sync"
+)
+
+var (
+	_ Producer = (*ChannelBroker)(nil)
+	_ Consumer = (*ChannelBroker)(nil)
+)
+
+// ChannelBroker is an in-process transport backed by a buffered go channel, messages are lost on process exit.
+type ChannelBroker struct {
+	messages  chan Message
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func NewChannelBroker(size int) *ChannelBroker {
+	return &ChannelBroker{
+		messages: make(chan Message, size),
+		closed:   make(chan struct{}),
+	}
+}
+
+func (b *ChannelBroker) Publish(ctx context.Context, msg Message) error {
+	select {
+	case <-b.closed:
+		return ErrClosed
+	default:
+	}
+
+	select {
+	case b.messages <- msg:
+		return nil
+	case <-b.closed:
+		return ErrClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (b *ChannelBroker) Receive(ctx context.Context) (Message, error) {
+	select {
+	case msg := <-b.messages:
+		return msg, nil
+	case <-b.closed:
+		// hand out what is still buffered before reporting the broker as closed
+		select {
+		case msg := <-b.messages:
+			return msg, nil
+		default:
+			return Message{}, ErrClosed
+		}
+	case <-ctx.Done():
+		return Message{}, ctx.Err()
+	}
+}
+
+// Ack does nothing, messages leave the channel when they are received.
+func (b *ChannelBroker) Ack(Message) error {
+	return nil
+}
+
+// Nack does nothing, a message cannot be put back once the broker is closed.
+func (b *ChannelBroker) Nack(Message) error {
+	return nil
+}
+
+func (b *ChannelBroker) Len() int {
+	return len(b.messages)
+}
+
+func (b *ChannelBroker) Cap() int {
+	return cap(b.messages)
+}
+
+func (b *ChannelBroker) Close() error {
+	b.closeOnce.Do(func() {
+		close(b.closed)
+	})
+
+	return nil
+}
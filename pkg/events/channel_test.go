@@ -0,0 +1,75 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestChannelBroker(t *testing.T) {
+	tests := []struct {
+		name     string
+		publish  []Message
+		close    bool
+		want     []Message
+		wantErrs []error
+	}{
+		{
+			name:     "should receive published messages in order",
+			publish:  []Message{{ID: "1", Type: "order.created"}, {ID: "2", Type: "order.created"}},
+			want:     []Message{{ID: "1", Type: "order.created"}, {ID: "2", Type: "order.created"}},
+			wantErrs: []error{nil, nil},
+		},
+		{
+			name:     "should drain buffered messages after close",
+			publish:  []Message{{ID: "1", Type: "order.created"}},
+			close:    true,
+			want:     []Message{{ID: "1", Type: "order.created"}, {}},
+			wantErrs: []error{nil, ErrClosed},
+		},
+		{
+			name:     "should time out when empty",
+			want:     []Message{{}},
+			wantErrs: []error{context.DeadlineExceeded},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broker := NewChannelBroker(10)
+
+			for _, msg := range tt.publish {
+				if err := broker.Publish(t.Context(), msg); err != nil {
+					t.Fatalf("Publish() error = %v", err)
+				}
+			}
+
+			if tt.close {
+				_ = broker.Close()
+			}
+
+			for i := range tt.want {
+				ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+				got, err := broker.Receive(ctx)
+				cancel()
+
+				if !errors.Is(err, tt.wantErrs[i]) {
+					t.Errorf("Receive() error = %v, wantErr %v", err, tt.wantErrs[i])
+				}
+				if !reflect.DeepEqual(got, tt.want[i]) {
+					t.Errorf("Receive() = %v, want %v", got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestChannelBrokerPublishAfterClose(t *testing.T) {
+	broker := NewChannelBroker(1)
+	_ = broker.Close()
+
+	if err := broker.Publish(t.Context(), Message{ID: "1"}); !errors.Is(err, ErrClosed) {
+		t.Errorf("Publish() error = %v, wantErr %v", err, ErrClosed)
+	}
+}
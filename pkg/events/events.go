@@ -0,0 +1,36 @@
+package events
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrClosed = errors.New("event transport closed")
+
+type Message struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Key     string `json:"key"`
+	Payload []byte `json:"payload"`
+	// deliveryTag identifies a received message to the consumer acknowledging it, for transports that need one
+	deliveryTag uint64
+}
+
+type Producer interface {
+	Publish(ctx context.Context, msg Message) error
+	Close() error
+}
+
+// Consumer receives messages one by one, Receive blocks until a message arrives, the context is done or the
+// consumer is closed in which case ErrClosed is returned. A Receive ended by the context loses no message.
+//
+// Every received message is settled with Ack once it was handled or persisted elsewhere, or with Nack to hand it back
+// to the transport to be delivered again; transports without acknowledgements ignore both. Close stops receiving new
+// messages, Receive still hands out the ones the consumer holds before it reports ErrClosed and they can still be
+// settled.
+type Consumer interface {
+	Receive(ctx context.Context) (Message, error)
+	Ack(msg Message) error
+	Nack(msg Message) error
+	Close() error
+}
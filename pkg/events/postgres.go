@@ -0,0 +1,191 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	_ Producer = (*PostgresProducer)(nil)
+	_ Consumer = (*PostgresConsumer)(nil)
+)
+
+// unreceivedBatchSize is how many unreceived message ids the consumer looks up at once.
+const unreceivedBatchSize = 100
+
+// MessageStore holds the messages published over postgres, notifications only carry their id.
+type MessageStore interface {
+	// ReceiveMessage claims the message with id and returns it, it reports false when the message was received
+	// before, so every message is received once even when it is notified and found unreceived.
+	ReceiveMessage(ctx context.Context, id string) (Message, bool, error)
+	// UnreceivedMessageIDs returns the ids of up to limit messages that were not received yet, oldest first.
+	UnreceivedMessageIDs(ctx context.Context, limit int) ([]string, error)
+}
+
+// PostgresProducer publishes messages with NOTIFY. Only the id of a message is notified, payloads are limited to
+// 8000 bytes by default, so consumers load the message itself from where it was stored. Notifications are only
+// received by sessions listening at the time, the consumer makes up for the lost ones with the store.
+type PostgresProducer struct {
+	pool    *pgxpool.Pool
+	channel string
+}
+
+func NewPostgresProducer(pool *pgxpool.Pool, channel string) *PostgresProducer {
+	return &PostgresProducer{
+		pool:    pool,
+		channel: channel,
+	}
+}
+
+func (p *PostgresProducer) Publish(ctx context.Context, msg Message) error {
+	if _, err := p.pool.Exec(ctx, "select pg_notify($1, $2)", p.channel, msg.ID); err != nil {
+		return fmt.Errorf("notify: %w", err)
+	}
+
+	return nil
+}
+
+func (p *PostgresProducer) Close() error {
+	return nil
+}
+
+// PostgresConsumer holds a dedicated pool connection which LISTENs on the channel, the connection is
+// re-established on the next Receive if waiting for a notification fails. Notified messages are claimed in the store,
+// and whenever the consumer starts listening it first claims the messages of the store nobody received, whose
+// notification was lost while no session listened.
+type PostgresConsumer struct {
+	pool    *pgxpool.Pool
+	channel string
+	store   MessageStore
+
+	mu     sync.Mutex
+	conn   *pgxpool.Conn
+	closed bool
+	// unreceived holds ids found unreceived that are not claimed yet, more are looked up while lookup is set
+	unreceived []string
+	lookup     bool
+}
+
+func NewPostgresConsumer(pool *pgxpool.Pool, channel string, store MessageStore) *PostgresConsumer {
+	return &PostgresConsumer{
+		pool:    pool,
+		channel: channel,
+		store:   store,
+	}
+}
+
+func (c *PostgresConsumer) Receive(ctx context.Context) (Message, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for {
+		if c.closed {
+			return Message{}, ErrClosed
+		}
+
+		id, err := c.next(ctx)
+		if err != nil {
+			return Message{}, err
+		}
+
+		msg, received, err := c.store.ReceiveMessage(ctx, id)
+		if err != nil {
+			// the message stays unreceived, look it up again
+			c.lookup = true
+
+			return Message{}, fmt.Errorf("receive message %s: %w", id, err)
+		}
+
+		if received {
+			return msg, nil
+		}
+	}
+}
+
+// next returns the id of the next message to claim, the unreceived ones come before the notified ones.
+func (c *PostgresConsumer) next(ctx context.Context) (string, error) {
+	if c.conn == nil {
+		if err := c.listen(ctx); err != nil {
+			return "", err
+		}
+	}
+
+	if len(c.unreceived) == 0 && c.lookup {
+		ids, err := c.store.UnreceivedMessageIDs(ctx, unreceivedBatchSize)
+		if err != nil {
+			return "", fmt.Errorf("look up unreceived messages: %w", err)
+		}
+
+		c.unreceived = ids
+		c.lookup = len(ids) == unreceivedBatchSize
+	}
+
+	if len(c.unreceived) > 0 {
+		id := c.unreceived[0]
+		c.unreceived = c.unreceived[1:]
+
+		return id, nil
+	}
+
+	notification, err := c.conn.Conn().WaitForNotification(ctx)
+	if err != nil {
+		// a wait ended by ctx keeps the connection listening, so no notification is missed between calls; any other
+		// error closes it and the next call listens on a fresh one
+		if c.conn.Conn().IsClosed() {
+			c.conn.Release()
+			c.conn = nil
+		}
+
+		return "", err
+	}
+
+	return notification.Payload, nil
+}
+
+// listen starts listening on a fresh connection, messages published before are looked up in the store, those
+// published while the lookup runs are notified as well and only claimed once.
+func (c *PostgresConsumer) listen(ctx context.Context) error {
+	conn, err := c.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire connection: %w", err)
+	}
+
+	if _, err := conn.Exec(ctx, "listen "+pgx.Identifier{c.channel}.Sanitize()); err != nil {
+		conn.Release()
+		return fmt.Errorf("listen: %w", err)
+	}
+
+	c.conn = conn
+	c.lookup = true
+
+	return nil
+}
+
+// Ack does nothing, messages are claimed in the store when they are received.
+func (c *PostgresConsumer) Ack(Message) error {
+	return nil
+}
+
+// Nack does nothing, a claimed message is only received again once it is moved back to pending in the store.
+func (c *PostgresConsumer) Nack(Message) error {
+	return nil
+}
+
+func (c *PostgresConsumer) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closed = true
+	if c.conn != nil {
+		// the connection still listens, closing it keeps it from going back to the pool
+		_ = c.conn.Conn().Close(context.Background())
+		c.conn.Release()
+		c.conn = nil
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Check reports a dependency as unavailable by returning an error.
+type Check func(ctx context.Context) error
+
+type Result struct {
+	Name     string
+	Err      error
+	Duration time.Duration
+}
+
+type Report struct {
+	ShuttingDown bool
+	Results      []Result
+}
+
+// Ready tells whether every check passed and the service is not shutting down.
+func (r Report) Ready() bool {
+	if r.ShuttingDown {
+		return false
+	}
+
+	for _, result := range r.Results {
+		if result.Err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Checker runs the readiness checks, once Shutdown was called it reports not ready regardless of the checks so load
+// balancers stop routing traffic before the listener closes.
+type Checker struct {
+	checks       []namedCheck
+	timeout      time.Duration
+	shuttingDown atomic.Bool
+}
+
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{timeout: timeout}
+}
+
+// Add registers a check, checks have to be added before the checker is used.
+func (c *Checker) Add(name string, check Check) {
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+}
+
+func (c *Checker) Shutdown() {
+	c.shuttingDown.Store(true)
+}
+
+// Check runs every check concurrently, a check not finishing within the timeout fails with the context error.
+// Results are in the order the checks were added.
+func (c *Checker) Check(ctx context.Context) Report {
+	report := Report{
+		ShuttingDown: c.shuttingDown.Load(),
+		Results:      make([]Result, len(c.checks)),
+	}
+
+	wg := sync.WaitGroup{}
+	for i, check := range c.checks {
+		wg.Go(func() {
+			report.Results[i] = c.run(ctx, check)
+		})
+	}
+	wg.Wait()
+
+	return report
+}
+
+func (c *Checker) run(ctx context.Context, check namedCheck) Result {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	err := check.check(ctx)
+
+	// checks ignoring the context still count as failed once the timeout passed
+	if err == nil {
+		err = ctx.Err()
+	}
+
+	return Result{Name: check.name, Err: err, Duration: time.Since(start)}
+}
@@ -0,0 +1,88 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestChecker(t *testing.T) {
+	errDown := errors.New("connection refused")
+
+	type args struct {
+		checks   map[string]Check
+		shutdown bool
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantReady  bool
+		wantFailed []string
+	}{
+		{
+			name: "should be ready when every check passes",
+			args: args{checks: map[string]Check{
+				"database": func(context.Context) error { return nil },
+			}},
+			wantReady: true,
+		},
+		{
+			name: "should not be ready when a check fails",
+			args: args{checks: map[string]Check{
+				"database": func(context.Context) error { return errDown },
+				"worker":   func(context.Context) error { return nil },
+			}},
+			wantFailed: []string{"database"},
+		},
+		{
+			name: "should fail checks exceeding the timeout",
+			args: args{checks: map[string]Check{
+				"database": func(ctx context.Context) error {
+					<-ctx.Done()
+					return nil
+				},
+			}},
+			wantFailed: []string{"database"},
+		},
+		{
+			name: "should not be ready while shutting down",
+			args: args{
+				checks:   map[string]Check{"database": func(context.Context) error { return nil }},
+				shutdown: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewChecker(10 * time.Millisecond)
+			for name, check := range tt.args.checks {
+				checker.Add(name, check)
+			}
+
+			if tt.args.shutdown {
+				checker.Shutdown()
+			}
+
+			report := checker.Check(t.Context())
+			if report.Ready() != tt.wantReady {
+				t.Errorf("Ready() = %v, want %v: %+v", report.Ready(), tt.wantReady, report)
+			}
+
+			if len(report.Results) != len(tt.args.checks) {
+				t.Fatalf("Check() returned %d results, want %d", len(report.Results), len(tt.args.checks))
+			}
+
+			failed := []string{}
+			for _, result := range report.Results {
+				if result.Err != nil {
+					failed = append(failed, result.Name)
+				}
+			}
+
+			if len(failed) != len(tt.wantFailed) || (len(failed) > 0 && failed[0] != tt.wantFailed[0]) {
+				t.Errorf("failed checks = %v, want %v", failed, tt.wantFailed)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// InitMeter sets the global meter provider to one exported to prometheus, the returned handler serves the metrics in
+// the prometheus text format together with the go runtime and process metrics.
+func InitMeter(ctx context.Context, serviceName string) (http.Handler, func(context.Context) error, error) {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+
+	exp, err := otelprometheus.New(otelprometheus.WithRegisterer(registry))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			semconv.ServiceName(serviceName),
+		),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mp := metric.NewMeterProvider(
+		metric.WithReader(exp),
+		metric.WithResource(res),
+	)
+
+	otel.SetMeterProvider(mp)
+
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{}), mp.Shutdown, nil
+}
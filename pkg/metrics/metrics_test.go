@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func TestInitMeter(t *testing.T) {
+	handler, shutdown, err := InitMeter(t.Context(), "orders-service")
+	if err != nil {
+		t.Fatalf("InitMeter() error = %v", err)
+	}
+	defer func() {
+		_ = shutdown(t.Context())
+	}()
+
+	counter, err := otel.Meter("test").Int64Counter("orders.created")
+	if err != nil {
+		t.Fatalf("Int64Counter() error = %v", err)
+	}
+
+	counter.Add(t.Context(), 2, metric.WithAttributes(attribute.String("order.currency", "EUR")))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body, err := io.ReadAll(w.Body)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+
+	for _, want := range []string{`orders_created_total{order_currency="EUR"`, "go_goroutines"} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("metrics do not contain %q:\n%s", want, body)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// RecordPoolStats observes the connections of pool and how long acquiring them waited, on every collection.
+func RecordPoolStats(pool *pgxpool.Pool) error {
+	meter := otel.Meter("orders-ms-pgxpool")
+
+	acquired, err := meter.Int64ObservableGauge("db.pool.connections.acquired",
+		metric.WithDescription("Connections currently in use."))
+	if err != nil {
+		return err
+	}
+
+	idle, err := meter.Int64ObservableGauge("db.pool.connections.idle",
+		metric.WithDescription("Connections currently idle."))
+	if err != nil {
+		return err
+	}
+
+	total, err := meter.Int64ObservableGauge("db.pool.connections.total",
+		metric.WithDescription("Connections open or being opened."))
+	if err != nil {
+		return err
+	}
+
+	maxConns, err := meter.Int64ObservableGauge("db.pool.connections.max",
+		metric.WithDescription("Maximum size of the pool."))
+	if err != nil {
+		return err
+	}
+
+	waits, err := meter.Int64ObservableCounter("db.pool.acquire.waits",
+		metric.WithDescription("Acquires that had to wait for a connection."))
+	if err != nil {
+		return err
+	}
+
+	waitDuration, err := meter.Float64ObservableCounter("db.pool.acquire.wait.duration", metric.WithUnit("s"),
+		metric.WithDescription("Time spent waiting for a connection by acquires that had to wait."))
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		stat := pool.Stat()
+
+		o.ObserveInt64(acquired, int64(stat.AcquiredConns()))
+		o.ObserveInt64(idle, int64(stat.IdleConns()))
+		o.ObserveInt64(total, int64(stat.TotalConns()))
+		o.ObserveInt64(maxConns, int64(stat.MaxConns()))
+		o.ObserveInt64(waits, stat.EmptyAcquireCount())
+		o.ObserveFloat64(waitDuration, stat.EmptyAcquireWaitTime().Seconds())
+
+		return nil
+	}, acquired, idle, total, maxConns, waits, waitDuration)
+
+	return err
+}
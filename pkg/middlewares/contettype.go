@@ -2,6 +2,8 @@ package middlewares
 
 import "net/http"
 
+// ContentTypeSetterMW defaults responses to json, handlers writing other content like event streams set their own
+// Content-Type before writing the header.
 func ContentTypeSetterMW(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -1,27 +1,30 @@
 package middlewares
 
 import (
-	"encoding/json"
+	"fmt"
 	"log/slog"
 	"net/http"
 
-	"github.com/leetm4n/orders-service/api"
+	"github.com/leetm4n/orders-service/pkg/problem"
 )
 
+// ErrorHandlerMW writes the error a handler failed with through problem.Fail, and panics, as problem details.
 func ErrorHandlerMW(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		defer func() {
-			if r := recover(); r != nil {
-				slog.Error("recovered from panic: %v", r)
+		ctx, failure := problem.Capture(r.Context())
+		r = r.WithContext(ctx)
 
-				w.WriteHeader(http.StatusInternalServerError)
-				_ = json.NewEncoder(w).Encode(api.ErrorResponse{
-					Error: "Internal Server Error",
-					Code:  http.StatusInternalServerError,
-				})
+		defer func() {
+			if rec := recover(); rec != nil {
+				slog.Error("recovered from panic", "panic", rec)
+				problem.Write(w, r, fmt.Errorf("panic: %v", rec))
 			}
 		}()
 
 		next.ServeHTTP(w, r)
+
+		if err := failure(); err != nil {
+			problem.Write(w, r, err)
+		}
 	})
 }
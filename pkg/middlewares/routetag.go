@@ -0,0 +1,22 @@
+package middlewares
+
+import (
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// RouteTagMW labels the span and the http metrics of the request with the route it matched, it has to run after
+// routing, within the handler of the matched pattern.
+func RouteTagMW(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// patterns may start with the method, e.g. "GET /orders/{orderId}"
+		route := r.Pattern
+		if i := strings.Index(route, "/"); i > 0 {
+			route = route[i:]
+		}
+
+		otelhttp.WithRouteTag(route, next).ServeHTTP(w, r)
+	})
+}
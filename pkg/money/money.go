@@ -0,0 +1,166 @@
+package money
+
+import (
+	"errors"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+var ErrInvalidAmount = errors.New("invalid amount")
+
+var amountPattern = regexp.MustCompile(`^-?\d+(\.\d+)?$`)
+
+// Amount is an exact decimal, its value is the unscaled integer times 10^exp. The zero value is zero, amounts are
+// never modified once created.
+type Amount struct {
+	unscaled *big.Int
+	exp      int32
+}
+
+// NewAmount returns unscaled * 10^exp, a nil unscaled is zero.
+func NewAmount(unscaled *big.Int, exp int32) Amount {
+	if unscaled == nil {
+		return Amount{}
+	}
+
+	return Amount{unscaled: new(big.Int).Set(unscaled), exp: exp}
+}
+
+// Unscaled returns a copy of the integer the amount is represented with.
+func (a Amount) Unscaled() *big.Int {
+	if a.unscaled == nil {
+		return new(big.Int)
+	}
+
+	return new(big.Int).Set(a.unscaled)
+}
+
+// Exp returns the power of ten the unscaled integer is multiplied with.
+func (a Amount) Exp() int32 {
+	if a.unscaled == nil {
+		return 0
+	}
+
+	return a.exp
+}
+
+// ParseAmount parses a plain decimal string like "12.34" into an exact amount.
+func ParseAmount(s string) (Amount, error) {
+	if !amountPattern.MatchString(s) {
+		return Amount{}, ErrInvalidAmount
+	}
+
+	intPart, fracPart, _ := strings.Cut(s, ".")
+
+	v, ok := new(big.Int).SetString(intPart+fracPart, 10)
+	if !ok {
+		return Amount{}, ErrInvalidAmount
+	}
+
+	return Amount{unscaled: v, exp: -int32(len(fracPart))}, nil
+}
+
+// FormatAmount formats the amount as a plain decimal string with exactly scale fractional digits,
+// extra digits are rounded half away from zero.
+func FormatAmount(a Amount, scale int32) string {
+	v := rescale(a, scale)
+
+	sign := ""
+	if v.Sign() < 0 {
+		sign = "-"
+		v.Neg(v)
+	}
+
+	digits := v.String()
+	if scale <= 0 {
+		return sign + digits
+	}
+
+	if len(digits) <= int(scale) {
+		digits = strings.Repeat("0", int(scale)-len(digits)+1) + digits
+	}
+
+	return sign + digits[:len(digits)-int(scale)] + "." + digits[len(digits)-int(scale):]
+}
+
+// Round rounds a half away from zero to scale fractional digits.
+func Round(a Amount, scale int32) Amount {
+	return Amount{unscaled: rescale(a, scale), exp: -scale}
+}
+
+func Add(a, b Amount) Amount {
+	exp := min(a.Exp(), b.Exp())
+
+	return Amount{unscaled: new(big.Int).Add(unscaled(a, exp), unscaled(b, exp)), exp: exp}
+}
+
+func Mul(a, b Amount) Amount {
+	return Amount{unscaled: new(big.Int).Mul(a.Unscaled(), b.Unscaled()), exp: a.Exp() + b.Exp()}
+}
+
+func MulInt(a Amount, m int64) Amount {
+	return Mul(a, Amount{unscaled: big.NewInt(m)})
+}
+
+// Percent calculates percent of a rounded to scale fractional digits.
+func Percent(a, percent Amount, scale int32) Amount {
+	product := Mul(a, percent)
+	product.exp -= 2
+
+	return Round(product, scale)
+}
+
+// Cmp returns -1, 0 or 1 when a is less than, equal to or greater than b.
+func Cmp(a, b Amount) int {
+	exp := min(a.Exp(), b.Exp())
+
+	return unscaled(a, exp).Cmp(unscaled(b, exp))
+}
+
+// Sign returns -1, 0 or 1 for negative, zero and positive a.
+func Sign(a Amount) int {
+	if a.unscaled == nil {
+		return 0
+	}
+
+	return a.unscaled.Sign()
+}
+
+// Scale returns the number of fractional digits a is represented with.
+func Scale(a Amount) int32 {
+	return max(-a.Exp(), 0)
+}
+
+// unscaled returns the integer value of a at the given exponent, exp must not be larger than the exponent of a.
+func unscaled(a Amount, exp int32) *big.Int {
+	return new(big.Int).Mul(a.Unscaled(), pow10(a.Exp()-exp))
+}
+
+// rescale returns the unscaled value of a with an exponent of -scale.
+func rescale(a Amount, scale int32) *big.Int {
+	v := a.Unscaled()
+	shift := a.Exp() + scale
+
+	if shift >= 0 {
+		return v.Mul(v, pow10(shift))
+	}
+
+	divisor := pow10(-shift)
+	quotient, remainder := new(big.Int).QuoRem(v, divisor, new(big.Int))
+
+	// round half away from zero
+	if new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2)).Cmp(divisor) >= 0 {
+		if v.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+
+	return quotient
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
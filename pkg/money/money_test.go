@@ -0,0 +1,152 @@
+package money
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    string
+		wantErr error
+	}{
+		{name: "should parse integer", s: "12", want: "12"},
+		{name: "should parse decimal", s: "12.34", want: "12.34"},
+		{name: "should keep fractional precision", s: "0.0001", want: "0.0001"},
+		{name: "should parse negative", s: "-1.5", want: "-1.5"},
+		{name: "should reject exponent", s: "1e3", wantErr: ErrInvalidAmount},
+		{name: "should reject empty fraction", s: "1.", wantErr: ErrInvalidAmount},
+		{name: "should reject empty", s: "", wantErr: ErrInvalidAmount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAmount(tt.s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseAmount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if formatted := FormatAmount(got, -got.Exp()); formatted != tt.want {
+				t.Errorf("ParseAmount() = %v, want %v", formatted, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	type args struct {
+		n     Amount
+		scale int32
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "should pad to scale",
+			args: args{n: NewAmount(big.NewInt(5), 0), scale: 2},
+			want: "5.00",
+		},
+		{
+			name: "should drop trailing zeros of stored scale",
+			args: args{n: NewAmount(big.NewInt(123400), -4), scale: 2},
+			want: "12.34",
+		},
+		{
+			name: "should round half away from zero",
+			args: args{n: NewAmount(big.NewInt(12345), -3), scale: 2},
+			want: "12.35",
+		},
+		{
+			name: "should round negative half away from zero",
+			args: args{n: NewAmount(big.NewInt(-12345), -3), scale: 2},
+			want: "-12.35",
+		},
+		{
+			name: "should format values below one",
+			args: args{n: NewAmount(big.NewInt(7), -2), scale: 2},
+			want: "0.07",
+		},
+		{
+			name: "should format without fraction",
+			args: args{n: NewAmount(big.NewInt(1250), -2), scale: 0},
+			want: "13",
+		},
+		{
+			name: "should format zero value",
+			args: args{n: Amount{}, scale: 2},
+			want: "0.00",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatAmount(tt.args.n, tt.args.scale); got != tt.want {
+				t.Errorf("FormatAmount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	price := NewAmount(big.NewInt(1999), -2)
+	rate := NewAmount(big.NewInt(275), -1)
+
+	tests := []struct {
+		name string
+		got  Amount
+		want string
+	}{
+		{
+			name: "should multiply by quantity",
+			got:  MulInt(price, 3),
+			want: "59.97",
+		},
+		{
+			name: "should add amounts of different scale",
+			got:  Add(price, NewAmount(big.NewInt(1), 0)),
+			want: "20.99",
+		},
+		{
+			name: "should add to zero value",
+			got:  Add(Amount{}, price),
+			want: "19.99",
+		},
+		{
+			name: "should calculate rounded percent",
+			got:  Percent(price, rate, 2),
+			want: "5.50",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatAmount(tt.got, 2); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCmp(t *testing.T) {
+	tests := []struct {
+		name string
+		a    Amount
+		b    Amount
+		want int
+	}{
+		{name: "should compare amounts of different scale", a: NewAmount(big.NewInt(150), -2), b: NewAmount(big.NewInt(15), -1), want: 0},
+		{name: "should find smaller amount", a: NewAmount(big.NewInt(1499), -3), b: NewAmount(big.NewInt(15), -1), want: -1},
+		{name: "should compare with zero value", a: NewAmount(big.NewInt(1), 15), b: Amount{}, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Cmp(tt.a, tt.b); got != tt.want {
+				t.Errorf("Cmp() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+// Package problem describes failed requests as RFC 7807 problem details.
+package problem
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const ContentType = "application/problem+json"
+
+// typePrefix turns a code into the type URI of the problem.
+const typePrefix = "urn:problem-type:orders-service:"
+
+// Code identifies the kind of a problem, unlike the detail it does not change, so clients can rely on it.
+type Code string
+
+const (
+	CodeMalformedRequest Code = "malformed_request"
+	CodeValidationFailed Code = "validation_failed"
+	CodeNotFound         Code = "not_found"
+	CodeMethodNotAllowed Code = "method_not_allowed"
+	CodeInternal         Code = "internal_error"
+)
+
+// FieldError points at an invalid part of the request, a JSON pointer into the body or the name of a parameter.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error is a failure of a request with the status and code the client gets. Err is the cause, it is only logged.
+type Error struct {
+	Status int
+	Code   Code
+	Detail string
+	Fields []FieldError
+	Err    error
+}
+
+func New(status int, code Code, detail string) *Error {
+	return &Error{Status: status, Code: code, Detail: detail}
+}
+
+// Internal hides err from the client behind a 500.
+func Internal(err error) *Error {
+	return &Error{Status: http.StatusInternalServerError, Code: CodeInternal, Err: err}
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+
+	return e.Detail
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Details is the body of a problem response.
+type Details struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance"`
+	Code     Code         `json:"code"`
+	TraceID  string       `json:"traceId,omitempty"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+// Write writes err as problem details, errors other than *Error are internal errors. Internal errors are logged
+// and their message is not sent to the client.
+func Write(w http.ResponseWriter, r *http.Request, err error) {
+	var e *Error
+	if !errors.As(err, &e) {
+		e = Internal(err)
+	}
+
+	if e.Status >= http.StatusInternalServerError {
+		slog.Error("failed to handle request", "error", err, "uri", r.RequestURI, "method", r.Method)
+	}
+
+	details := Details{
+		Type:     typePrefix + string(e.Code),
+		Title:    http.StatusText(e.Status),
+		Status:   e.Status,
+		Detail:   e.Detail,
+		Instance: r.URL.Path,
+		Code:     e.Code,
+		Errors:   e.Fields,
+	}
+
+	if sc := trace.SpanContextFromContext(r.Context()); sc.HasTraceID() {
+		details.TraceID = sc.TraceID().String()
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(e.Status)
+	_ = json.NewEncoder(w).Encode(details)
+}
+
+type recorderKey struct{}
+
+type recorder struct {
+	err error
+}
+
+// Capture returns a context in which Fail records the error instead of writing it, the returned function reports
+// the recorded error.
+func Capture(ctx context.Context) (context.Context, func() error) {
+	rec := &recorder{}
+
+	return context.WithValue(ctx, recorderKey{}, rec), func() error {
+		return rec.err
+	}
+}
+
+// Fail ends the request with err, the handler must not write anything afterwards. Within Capture the error is
+// written by whoever captured it, middlewares.ErrorHandlerMW, otherwise right away.
+func Fail(w http.ResponseWriter, r *http.Request, err error) {
+	if rec, ok := r.Context().Value(recorderKey{}).(*recorder); ok {
+		rec.err = err
+		return
+	}
+
+	Write(w, r, err)
+}
@@ -0,0 +1,104 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestWrite(t *testing.T) {
+	traceID := trace.TraceID{0x0a, 0xf7, 0x65, 0x19}
+
+	type args struct {
+		err error
+	}
+	tests := []struct {
+		name string
+		args args
+		want Details
+	}{
+		{
+			name: "should write typed errors",
+			args: args{err: fmt.Errorf("get order: %w", New(http.StatusNotFound, "order_not_found", "order not found"))},
+			want: Details{
+				Type:     "urn:problem-type:orders-service:order_not_found",
+				Title:    "Not Found",
+				Status:   http.StatusNotFound,
+				Detail:   "order not found",
+				Instance: "/orders/1",
+				Code:     "order_not_found",
+				TraceID:  traceID.String(),
+			},
+		},
+		{
+			name: "should hide other errors behind an internal error",
+			args: args{err: errors.New("connection refused")},
+			want: Details{
+				Type:     "urn:problem-type:orders-service:internal_error",
+				Title:    "Internal Server Error",
+				Status:   http.StatusInternalServerError,
+				Instance: "/orders/1",
+				Code:     CodeInternal,
+				TraceID:  traceID.String(),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := trace.ContextWithSpanContext(t.Context(), trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID: traceID,
+				SpanID:  trace.SpanID{1},
+			}))
+			r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/orders/1?fields=all", nil)
+			w := httptest.NewRecorder()
+
+			Write(w, r, tt.args.err)
+
+			if w.Code != tt.want.Status {
+				t.Errorf("Write() status = %d, want %d", w.Code, tt.want.Status)
+			}
+
+			if contentType := w.Header().Get("Content-Type"); contentType != ContentType {
+				t.Errorf("Write() Content-Type = %q, want %q", contentType, ContentType)
+			}
+
+			got := Details{}
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("decode error = %v", err)
+			}
+
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("Write() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFail(t *testing.T) {
+	errFailed := New(http.StatusConflict, "conflict", "conflict")
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	ctx, failure := Capture(r.Context())
+	w := httptest.NewRecorder()
+
+	Fail(w, r.WithContext(ctx), errFailed)
+
+	if w.Body.Len() != 0 {
+		t.Errorf("Fail() wrote %q within Capture", w.Body)
+	}
+
+	if err := failure(); !errors.Is(err, errFailed) {
+		t.Errorf("Capture() error = %v, want %v", err, errFailed)
+	}
+
+	Fail(w, r, errFailed)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("Fail() status = %d without Capture, want %d", w.Code, http.StatusConflict)
+	}
+}
@@ -2,39 +2,70 @@ package tracing
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	"go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"google.golang.org/grpc/credentials"
 )
 
-func InitTracer(ctx context.Context, serviceName, exporterEndpoint string) (func(context.Context) error, error) {
-	// Exporter (OTLP gRPC)
-	exp, err := otlptracegrpc.New(ctx,
-		otlptracegrpc.WithEndpoint(exporterEndpoint),
-		otlptracegrpc.WithInsecure(),
-	)
+const (
+	ExporterOTLPGRPC = "otlp-grpc"
+	ExporterOTLPHTTP = "otlp-http"
+	ExporterStdout   = "stdout"
+	ExporterNone     = "none"
+)
+
+// TracerOptions configures where spans are exported to and which are sampled. An empty Endpoint leaves the default
+// of the OTLP exporter (localhost:4317 for gRPC, localhost:4318 for http) or OTEL_EXPORTER_OTLP_ENDPOINT. Insecure
+// disables TLS for OTLP, otherwise the server certificate is verified against CAFile or the system roots. Root spans
+// are sampled with SampleRatio, other spans follow the decision of their parent.
+type TracerOptions struct {
+	ServiceName    string
+	ServiceVersion string
+	Environment    string
+	Exporter       string
+	Endpoint       string
+	Insecure       bool
+	CAFile         string
+	Headers        map[string]string
+	SampleRatio    float64
+}
+
+// InitTracer sets the global tracer provider and propagator. With ExporterNone spans are still created, so trace ids
+// are propagated and logged, they are just not exported. Resource attributes of OTEL_RESOURCE_ATTRIBUTES are added
+// to the ones in opts.
+func InitTracer(ctx context.Context, opts TracerOptions) (func(context.Context) error, error) {
+	res, err := newResource(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	res, err := resource.New(ctx,
-		resource.WithAttributes(
-			semconv.ServiceName(serviceName),
-		),
-	)
+	tpOpts := []trace.TracerProviderOption{
+		trace.WithResource(res),
+		trace.WithSampler(trace.ParentBased(trace.TraceIDRatioBased(opts.SampleRatio))),
+	}
+
+	exp, err := newExporter(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
 
+	if exp != nil {
+		tpOpts = append(tpOpts, trace.WithBatcher(exp))
+	}
+
 	// Tracer provider
-	tp := trace.NewTracerProvider(
-		trace.WithBatcher(exp),
-		trace.WithResource(res),
-	)
+	tp := trace.NewTracerProvider(tpOpts...)
 
 	otel.SetTracerProvider(tp)
 	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
@@ -44,3 +75,90 @@ func InitTracer(ctx context.Context, serviceName, exporterEndpoint string) (func
 		return tp.Shutdown(ctx)
 	}, nil
 }
+
+func newResource(ctx context.Context, opts TracerOptions) (*resource.Resource, error) {
+	attrs := []resource.Option{
+		resource.WithFromEnv(),
+		resource.WithAttributes(semconv.ServiceName(opts.ServiceName)),
+	}
+
+	if opts.ServiceVersion != "" {
+		attrs = append(attrs, resource.WithAttributes(semconv.ServiceVersion(opts.ServiceVersion)))
+	}
+
+	if opts.Environment != "" {
+		attrs = append(attrs, resource.WithAttributes(semconv.DeploymentEnvironment(opts.Environment)))
+	}
+
+	return resource.New(ctx, attrs...)
+}
+
+// newExporter returns a nil exporter for ExporterNone.
+func newExporter(ctx context.Context, opts TracerOptions) (trace.SpanExporter, error) {
+	switch opts.Exporter {
+	case ExporterOTLPGRPC:
+		grpcOpts := []otlptracegrpc.Option{otlptracegrpc.WithHeaders(opts.Headers)}
+		if opts.Endpoint != "" {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithEndpoint(opts.Endpoint))
+		}
+
+		if opts.Insecure {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithInsecure())
+		} else {
+			tlsConfig, err := newTLSConfig(opts.CAFile)
+			if err != nil {
+				return nil, err
+			}
+
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsConfig)))
+		}
+
+		return otlptracegrpc.New(ctx, grpcOpts...)
+	case ExporterOTLPHTTP:
+		httpOpts := []otlptracehttp.Option{otlptracehttp.WithHeaders(opts.Headers)}
+		if opts.Endpoint != "" {
+			httpOpts = append(httpOpts, otlptracehttp.WithEndpoint(opts.Endpoint))
+		}
+
+		if opts.Insecure {
+			httpOpts = append(httpOpts, otlptracehttp.WithInsecure())
+		} else {
+			tlsConfig, err := newTLSConfig(opts.CAFile)
+			if err != nil {
+				return nil, err
+			}
+
+			httpOpts = append(httpOpts, otlptracehttp.WithTLSClientConfig(tlsConfig))
+		}
+
+		return otlptracehttp.New(ctx, httpOpts...)
+	case ExporterStdout:
+		return stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case ExporterNone:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", opts.Exporter)
+	}
+}
+
+// newTLSConfig trusts the certificates of caFile, the system roots without it.
+func newTLSConfig(caFile string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile == "" {
+		return tlsConfig, nil
+	}
+
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("read tracing exporter ca file: %w", err)
+	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates in tracing exporter ca file %q", caFile)
+	}
+
+	tlsConfig.RootCAs = roots
+
+	return tlsConfig, nil
+}
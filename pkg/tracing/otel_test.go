@@ -0,0 +1,84 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestInitTracer(t *testing.T) {
+	sampledParent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	type args struct {
+		opts   TracerOptions
+		parent trace.SpanContext
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantErr     bool
+		wantSampled bool
+	}{
+		{
+			name:        "should sample every root span with ratio 1",
+			args:        args{opts: TracerOptions{ServiceName: "orders-service", Exporter: ExporterNone, SampleRatio: 1}},
+			wantSampled: true,
+		},
+		{
+			name: "should not sample root spans with ratio 0",
+			args: args{opts: TracerOptions{ServiceName: "orders-service", Exporter: ExporterNone, SampleRatio: 0}},
+		},
+		{
+			name: "should follow the sampled parent regardless of the ratio",
+			args: args{
+				opts:   TracerOptions{ServiceName: "orders-service", Exporter: ExporterStdout, SampleRatio: 0},
+				parent: sampledParent,
+			},
+			wantSampled: true,
+		},
+		{
+			name:    "should reject unknown exporters",
+			args:    args{opts: TracerOptions{ServiceName: "orders-service", Exporter: "zipkin"}},
+			wantErr: true,
+		},
+		{
+			name: "should reject a missing ca file",
+			args: args{opts: TracerOptions{
+				ServiceName: "orders-service",
+				Exporter:    ExporterOTLPHTTP,
+				CAFile:      "testdata/missing.pem",
+			}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shutdown, err := InitTracer(t.Context(), tt.args.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("InitTracer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			defer func() {
+				_ = shutdown(context.Background())
+			}()
+
+			ctx := trace.ContextWithSpanContext(t.Context(), tt.args.parent)
+			_, span := otel.Tracer("test").Start(ctx, "test")
+			span.End()
+
+			if got := span.SpanContext().IsSampled(); got != tt.wantSampled {
+				t.Errorf("IsSampled() = %v, want %v", got, tt.wantSampled)
+			}
+		})
+	}
+}
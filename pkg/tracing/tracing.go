@@ -7,27 +7,25 @@ import (
 	"go.opentelemetry.io/otel/propagation"
 )
 
-type TraceEnvelope struct {
-	TraceParent string `json:"traceparent"`
-	TraceState  string `json:"tracestate,omitempty"`
-}
+// TraceEnvelope carries the fields of the registered propagators keyed by their header name, traceparent and
+// tracestate of the W3C trace context and the W3C baggage with the propagators set by InitTracer.
+type TraceEnvelope map[string]string
 
 func SerializeTraceCtx(ctx context.Context) TraceEnvelope {
 	carrier := propagation.MapCarrier{}
 
 	otel.GetTextMapPropagator().Inject(ctx, carrier)
 
-	return TraceEnvelope{
-		TraceParent: carrier["traceparent"],
-	}
+	return TraceEnvelope(carrier)
 }
 
-func DeserializeTraceCtx(env TraceEnvelope) context.Context {
-	carrier := propagation.MapCarrier{
-		"traceparent": env.TraceParent,
-	}
+// Fields returns the header names the registered propagators read and write.
+func Fields() []string {
+	return otel.GetTextMapPropagator().Fields()
+}
 
-	ctx := context.Background()
-	ctx = otel.GetTextMapPropagator().Extract(ctx, carrier)
-	return ctx
+// DeserializeTraceCtx returns parent with the span context and baggage of env, the span context becomes the remote
+// parent of spans started from it.
+func DeserializeTraceCtx(parent context.Context, env TraceEnvelope) context.Context {
+	return otel.GetTextMapPropagator().Extract(parent, propagation.MapCarrier(env))
 }
@@ -6,10 +6,14 @@ import (
 	"testing"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/baggage"
 	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestSerializeTraceCtx(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
 	type args struct {
 		ctx context.Context
 	}
@@ -29,15 +33,30 @@ func TestSerializeTraceCtx(t *testing.T) {
 			name: "should serialize trace context with traceparent",
 			args: args{
 				ctx: func() context.Context {
-					otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
-
 					carrier := propagation.MapCarrier{}
 					carrier.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
 					return otel.GetTextMapPropagator().Extract(context.Background(), carrier)
 				}(),
 			},
 			want: TraceEnvelope{
-				TraceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+				"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			},
+		},
+		{
+			name: "should serialize tracestate and baggage",
+			args: args{
+				ctx: func() context.Context {
+					carrier := propagation.MapCarrier{}
+					carrier.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+					carrier.Set("tracestate", "vendor=value")
+					carrier.Set("baggage", "tenant=acme")
+					return otel.GetTextMapPropagator().Extract(context.Background(), carrier)
+				}(),
+			},
+			want: TraceEnvelope{
+				"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+				"tracestate":  "vendor=value",
+				"baggage":     "tenant=acme",
 			},
 		},
 	}
@@ -50,42 +69,72 @@ func TestSerializeTraceCtx(t *testing.T) {
 	}
 }
 
+type parentKey struct{}
+
 func TestDeserializeTraceCtx(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
 	type args struct {
 		env TraceEnvelope
 	}
 	tests := []struct {
-		name string
-		args args
-		want context.Context
+		name         string
+		args         args
+		wantTraceID  string
+		wantState    string
+		wantBaggage  string
+		wantSpanless bool
 	}{
 		{
-			name: "should deserialize empty trace envelope",
-			args: args{
-				env: TraceEnvelope{},
-			},
-			want: context.Background(),
+			name:         "should deserialize empty trace envelope",
+			args:         args{env: TraceEnvelope{}},
+			wantSpanless: true,
 		},
 		{
-			name: "should deserialize trace envelope with traceparent",
-			args: args{
-				env: TraceEnvelope{
-					TraceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
-				},
-			},
-			want: func() context.Context {
-				otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
-
-				carrier := propagation.MapCarrier{}
-				carrier.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
-				return otel.GetTextMapPropagator().Extract(context.Background(), carrier)
-			}(),
+			name:        "should deserialize trace envelope with traceparent",
+			args:        args{env: TraceEnvelope{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}},
+			wantTraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+		},
+		{
+			name: "should deserialize tracestate and baggage",
+			args: args{env: TraceEnvelope{
+				"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+				"tracestate":  "vendor=value",
+				"baggage":     "tenant=acme",
+			}},
+			wantTraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+			wantState:   "vendor=value",
+			wantBaggage: "acme",
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := DeserializeTraceCtx(tt.args.env); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("DeserializeTraceCtx() = %v, want %v", got, tt.want)
+			parent := context.WithValue(context.Background(), parentKey{}, "parent")
+
+			got := DeserializeTraceCtx(parent, tt.args.env)
+			if got.Value(parentKey{}) != "parent" {
+				t.Error("DeserializeTraceCtx() did not derive from the parent context")
+			}
+
+			spanCtx := trace.SpanContextFromContext(got)
+			if spanCtx.IsValid() == tt.wantSpanless {
+				t.Fatalf("DeserializeTraceCtx() span context valid = %v, want %v", spanCtx.IsValid(), !tt.wantSpanless)
+			}
+
+			if tt.wantSpanless {
+				return
+			}
+
+			if spanCtx.TraceID().String() != tt.wantTraceID || !spanCtx.IsRemote() {
+				t.Errorf("DeserializeTraceCtx() span context = %+v, want remote trace %s", spanCtx, tt.wantTraceID)
+			}
+
+			if state := spanCtx.TraceState().String(); state != tt.wantState {
+				t.Errorf("DeserializeTraceCtx() tracestate = %q, want %q", state, tt.wantState)
+			}
+
+			if tenant := baggage.FromContext(got).Member("tenant").Value(); tenant != tt.wantBaggage {
+				t.Errorf("DeserializeTraceCtx() baggage tenant = %q, want %q", tenant, tt.wantBaggage)
 			}
 		})
 	}
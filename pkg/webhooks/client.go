@@ -0,0 +1,103 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	IDHeader    = "Webhook-Id"
+	EventHeader = "Webhook-Event"
+)
+
+var ErrUnexpectedStatus = errors.New("unexpected webhook response status")
+
+type Delivery struct {
+	ID        string
+	URL       string
+	Secret    string
+	EventType string
+	// ContentType of the body, application/json when empty
+	ContentType string
+	Body        []byte
+}
+
+// Client posts signed deliveries to subscriber endpoints allowed by its policy, any 2xx response counts as
+// delivered.
+type Client struct {
+	httpClient *http.Client
+	policy     URLPolicy
+}
+
+type ClientOptions struct {
+	Timeout time.Duration
+	Policy  URLPolicy
+}
+
+func NewClient(opts ClientOptions) *Client {
+	dialer := &net.Dialer{
+		Timeout: 30 * time.Second,
+		Control: opts.Policy.control,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	// a proxy would be dialed instead of the endpoint, which keeps its address from being checked
+	transport.Proxy = nil
+
+	return &Client{
+		httpClient: &http.Client{
+			Timeout:   opts.Timeout,
+			Transport: transport,
+			// a redirect could point the signed payload anywhere, endpoints have to be registered with their final url
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		policy: opts.Policy,
+	}
+}
+
+// Deliver sends delivery and returns the response status code, which is 0 when no response was received.
+func (c *Client) Deliver(ctx context.Context, delivery Delivery) (int, error) {
+	// subscriptions stored before the policy applied are checked as well
+	if err := c.policy.CheckURL(delivery.URL); err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Body))
+	if err != nil {
+		return 0, fmt.Errorf("new request: %w", err)
+	}
+
+	contentType := delivery.ContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set(IDHeader, delivery.ID)
+	req.Header.Set(EventHeader, delivery.EventType)
+	req.Header.Set(SignatureHeader, Sign(delivery.Secret, time.Now(), delivery.Body))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// drain a bit of the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("%w: %d", ErrUnexpectedStatus, resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
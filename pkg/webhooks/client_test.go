@@ -0,0 +1,139 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClientDeliver(t *testing.T) {
+	const secret = "s3cr3t"
+
+	type args struct {
+		status int
+		policy URLPolicy
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantStatus int
+		wantErr    error
+	}{
+		{
+			name:       "should deliver signed payload",
+			args:       args{status: http.StatusNoContent, policy: URLPolicy{AllowHTTP: true, AllowPrivateAddresses: true}},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "should fail on error status",
+			args:       args{status: http.StatusInternalServerError, policy: URLPolicy{AllowHTTP: true, AllowPrivateAddresses: true}},
+			wantStatus: http.StatusInternalServerError,
+			wantErr:    ErrUnexpectedStatus,
+		},
+		{
+			name:       "should not follow redirects",
+			args:       args{status: http.StatusFound, policy: URLPolicy{AllowHTTP: true, AllowPrivateAddresses: true}},
+			wantStatus: http.StatusFound,
+			wantErr:    ErrUnexpectedStatus,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			received := make(chan *http.Request, 1)
+			receivedBody := make(chan []byte, 1)
+
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				received <- r
+				receivedBody <- body
+
+				if tt.args.status == http.StatusFound {
+					w.Header().Set("Location", "/elsewhere")
+				}
+				w.WriteHeader(tt.args.status)
+			}))
+			defer receiver.Close()
+
+			delivery := Delivery{
+				ID:        "3deb76e4-cd89-4aa3-b143-89e9c0ed11db",
+				URL:       receiver.URL,
+				Secret:    secret,
+				EventType: "order.created",
+				Body:      []byte(`{"type":"order.created"}`),
+			}
+
+			status, err := NewClient(ClientOptions{Timeout: time.Second, Policy: tt.args.policy}).Deliver(context.Background(), delivery)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Deliver() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if status != tt.wantStatus {
+				t.Errorf("Deliver() status = %d, want %d", status, tt.wantStatus)
+			}
+
+			r := <-received
+			body := <-receivedBody
+
+			if got := r.Header.Get(IDHeader); got != delivery.ID {
+				t.Errorf("Deliver() %s = %q, want %q", IDHeader, got, delivery.ID)
+			}
+
+			if got := r.Header.Get(EventHeader); got != delivery.EventType {
+				t.Errorf("Deliver() %s = %q, want %q", EventHeader, got, delivery.EventType)
+			}
+
+			if err := Verify(secret, r.Header.Get(SignatureHeader), body, time.Minute, time.Now()); err != nil {
+				t.Errorf("Verify() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestClientDeliverRejectsForbiddenAddresses(t *testing.T) {
+	received := make(chan struct{}, 1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		received <- struct{}{}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	type args struct {
+		url    string
+		policy URLPolicy
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name:    "should not post over http",
+			args:    args{url: receiver.URL, policy: URLPolicy{AllowPrivateAddresses: true}},
+			wantErr: ErrInvalidURL,
+		},
+		{
+			name:    "should not post to a loopback address",
+			args:    args{url: receiver.URL, policy: URLPolicy{AllowHTTP: true}},
+			wantErr: ErrForbiddenAddress,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClient(ClientOptions{Timeout: time.Second, Policy: tt.args.policy}).Deliver(
+				context.Background(), Delivery{URL: tt.args.url, Body: []byte(`{}`)})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Deliver() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			select {
+			case <-received:
+				t.Error("Deliver() posted to the forbidden endpoint")
+			default:
+			}
+		})
+	}
+}
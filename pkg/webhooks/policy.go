@@ -0,0 +1,75 @@
+package webhooks
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+)
+
+var (
+	ErrInvalidURL       = errors.New("webhook url must be an absolute https url")
+	ErrForbiddenAddress = errors.New("webhook address is not publicly routable")
+)
+
+// sharedAddressSpace is the carrier-grade NAT range, cloud providers serve metadata from it as well.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// URLPolicy restricts where deliveries are posted to, by default only to https endpoints on public addresses so
+// subscribers cannot point the dispatcher at the loopback, the internal network or the cloud metadata service.
+// AllowHTTP and AllowPrivateAddresses lift the restrictions for tests and local development.
+type URLPolicy struct {
+	AllowHTTP             bool
+	AllowPrivateAddresses bool
+}
+
+// CheckURL rejects urls the policy does not allow. Host names are only checked once they are resolved, when the
+// client dials them, literal addresses and localhost are rejected right away.
+func (p URLPolicy) CheckURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && (u.Scheme != "http" || !p.AllowHTTP)) {
+		return ErrInvalidURL
+	}
+
+	if p.AllowPrivateAddresses {
+		return nil
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrForbiddenAddress
+	}
+
+	if addr, err := netip.ParseAddr(host); err == nil && !isPublic(addr) {
+		return ErrForbiddenAddress
+	}
+
+	return nil
+}
+
+// control is the net.Dialer Control of the client, it sees the resolved address of every connection, so host names
+// resolving to private addresses are rejected as well.
+func (p URLPolicy) control(_, address string, _ syscall.RawConn) error {
+	if p.AllowPrivateAddresses {
+		return nil
+	}
+
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("parse dialed address %q: %w", address, err)
+	}
+
+	if !isPublic(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, addrPort.Addr())
+	}
+
+	return nil
+}
+
+func isPublic(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !sharedAddressSpace.Contains(addr)
+}
@@ -0,0 +1,111 @@
+package webhooks
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestURLPolicyCheckURL(t *testing.T) {
+	type args struct {
+		url    string
+		policy URLPolicy
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "should allow https url",
+			args: args{url: "https://partner.example.com/hooks"},
+		},
+		{
+			name:    "should reject http url",
+			args:    args{url: "http://partner.example.com/hooks"},
+			wantErr: ErrInvalidURL,
+		},
+		{
+			name: "should allow http url when configured",
+			args: args{url: "http://partner.example.com/hooks", policy: URLPolicy{AllowHTTP: true}},
+		},
+		{
+			name:    "should reject relative url",
+			args:    args{url: "/hooks"},
+			wantErr: ErrInvalidURL,
+		},
+		{
+			name:    "should reject localhost",
+			args:    args{url: "https://LocalHost./hooks"},
+			wantErr: ErrForbiddenAddress,
+		},
+		{
+			name:    "should reject metadata service",
+			args:    args{url: "https://169.254.169.254/latest/meta-data"},
+			wantErr: ErrForbiddenAddress,
+		},
+		{
+			name:    "should reject private address",
+			args:    args{url: "https://10.0.0.1/hooks"},
+			wantErr: ErrForbiddenAddress,
+		},
+		{
+			name:    "should reject ipv4 mapped loopback address",
+			args:    args{url: "https://[::ffff:127.0.0.1]/hooks"},
+			wantErr: ErrForbiddenAddress,
+		},
+		{
+			name: "should allow private address when configured",
+			args: args{url: "https://10.0.0.1/hooks", policy: URLPolicy{AllowPrivateAddresses: true}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.args.policy.CheckURL(tt.args.url); !errors.Is(err, tt.wantErr) {
+				t.Errorf("CheckURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestURLPolicyControl(t *testing.T) {
+	type args struct {
+		address string
+		policy  URLPolicy
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "should dial public address",
+			args: args{address: "93.184.215.14:443"},
+		},
+		{
+			name:    "should not dial loopback address",
+			args:    args{address: "127.0.0.1:443"},
+			wantErr: ErrForbiddenAddress,
+		},
+		{
+			name:    "should not dial ipv6 link-local address",
+			args:    args{address: "[fe80::1]:443"},
+			wantErr: ErrForbiddenAddress,
+		},
+		{
+			name:    "should not dial shared address space",
+			args:    args{address: "100.100.100.200:80"},
+			wantErr: ErrForbiddenAddress,
+		},
+		{
+			name: "should dial loopback address when configured",
+			args: args{address: "127.0.0.1:443", policy: URLPolicy{AllowPrivateAddresses: true}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.args.policy.control("tcp4", tt.args.address, nil); !errors.Is(err, tt.wantErr) {
+				t.Errorf("control() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
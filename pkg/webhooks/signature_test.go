@@ -0,0 +1,61 @@
+package webhooks
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	signedAt := time.Unix(1764000000, 0)
+	body := []byte(`{"type":"order.created"}`)
+
+	type args struct {
+		secret string
+		header string
+		body   []byte
+		now    time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "should accept valid signature",
+			args: args{secret: "s3cr3t", header: Sign("s3cr3t", signedAt, body), body: body, now: signedAt},
+		},
+		{
+			name:    "should reject other secret",
+			args:    args{secret: "other", header: Sign("s3cr3t", signedAt, body), body: body, now: signedAt},
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "should reject modified body",
+			args:    args{secret: "s3cr3t", header: Sign("s3cr3t", signedAt, body), body: []byte(`{}`), now: signedAt},
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "should reject malformed header",
+			args:    args{secret: "s3cr3t", header: "v1=abc", body: body, now: signedAt},
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name: "should reject old signature",
+			args: args{
+				secret: "s3cr3t",
+				header: Sign("s3cr3t", signedAt, body),
+				body:   body,
+				now:    signedAt.Add(10 * time.Minute),
+			},
+			wantErr: ErrSignatureExpired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Verify(tt.args.secret, tt.args.header, tt.args.body, 5*time.Minute, tt.args.now); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,79 +1,77 @@
 package test
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
-	"net/url"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
-	"github.com/amacneil/dbmate/v2/pkg/dbmate"
 	_ "github.com/amacneil/dbmate/v2/pkg/driver/postgres"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/leetm4n/orders-service/api"
+	"github.com/leetm4n/orders-service/api/ordersv1"
 	"github.com/leetm4n/orders-service/config"
-	"github.com/leetm4n/orders-service/db"
 	"github.com/leetm4n/orders-service/internal/application"
+	"github.com/leetm4n/orders-service/pkg/tracing"
+	"github.com/leetm4n/orders-service/pkg/webhooks"
 	openapiTypes "github.com/oapi-codegen/runtime/types"
-	"github.com/testcontainers/testcontainers-go"
-	"github.com/testcontainers/testcontainers-go/modules/postgres"
-	"github.com/testcontainers/testcontainers-go/wait"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
 func TestAppWithPostgres(t *testing.T) {
 	ctx := t.Context()
 
-	pgContainer, err := postgres.Run(ctx,
-		"postgres:18",
-		postgres.WithDatabase("testdb"),
-		postgres.WithUsername("testuser"),
-		postgres.WithPassword("testpass"),
-		testcontainers.WithWaitStrategy(wait.ForListeningPort("5432/tcp")),
-	)
-	if err != nil {
-		t.Fatal(err)
-	}
-	defer pgContainer.Terminate(ctx)
-
-	host, _ := pgContainer.Host(ctx)
-	port, _ := pgContainer.MappedPort(ctx, "5432/tcp")
-
-	databaseURL := fmt.Sprintf(
-		"postgres://testuser:testpass@%s:%s/testdb?sslmode=disable",
-		host, port.Port(),
-	)
-
-	url, err := url.Parse(databaseURL)
-	if err != nil {
-		t.Fatalf("failed to parse database URL: %v", err)
-	}
-
-	migrator := dbmate.New(url)
-	migrator.MigrationsDir = []string{"./migrations"}
-	migrator.FS = db.Migrations
-
-	if err := migrator.Migrate(); err != nil {
-		t.Fatalf("failed to migrate database: %v", err)
-	}
+	databaseURL := startPostgres(t)
 
 	cancellableContext, cancel := context.WithCancel(ctx)
 	defer cancel()
 
 	go application.Run(cancellableContext, config.Config{
-		DatabaseURL: databaseURL,
-		Port:        8085,
-		Host:        "",
+		Storage:                      config.StoragePostgres,
+		DatabaseURL:                  databaseURL,
+		Port:                         8085,
+		GRPCPort:                     9095,
+		Host:                         "",
+		TracingExporter:              tracing.ExporterNone,
+		TracingSampleRatio:           1,
+		OutboxPollIntervalMs:         100,
+		OutboxBatchSize:              100,
+		EventBroker:                  config.EventBrokerChannel,
+		EventBufferSize:              100,
+		EventSource:                  "/orders-service",
+		StreamPollIntervalMs:         100,
+		StreamHeartbeatSec:           1,
+		TaxRatePercent:               "27",
+		IdempotencyKeyTTLHours:       24,
+		WorkerConcurrency:            4,
+		WorkerMaxAttempts:            3,
+		WorkerRetryBaseDelayMs:       10,
+		WorkerRetryMaxDelayMs:        100,
+		WorkerDrainTimeoutSec:        1,
+		WebhookPollIntervalMs:        100,
+		WebhookBatchSize:             20,
+		WebhookTimeoutMs:             1000,
+		WebhookMaxAttempts:           3,
+		WebhookRetryBaseDelayMs:      10,
+		WebhookRetryMaxDelayMs:       100,
+		WebhookFailuresToDisable:     5,
+		WebhookAllowHTTP:             true,
+		WebhookAllowPrivateAddresses: true,
 	})
 
 	// Give the app time to start
 	time.Sleep(1 * time.Second)
 
-	// Test healthz first
-	resp, err := http.Get("http://localhost:8085/healthz")
+	// Test readyz first
+	resp, err := http.Get("http://localhost:8085/readyz")
 	if err != nil {
 		t.Fatalf("http request failed: %v", err)
 	}
@@ -83,24 +81,70 @@ func TestAppWithPostgres(t *testing.T) {
 		t.Fatalf("expected 200 OK, got %d", resp.StatusCode)
 	}
 
+	// Subscribe to order created webhooks
+	webhookSecret := "integration-test-secret"
+	webhookDeliveries := make(chan []byte, 10)
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := webhooks.Verify(webhookSecret, r.Header.Get(webhooks.SignatureHeader), body, time.Minute, time.Now()); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		webhookDeliveries <- body
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	b, err := json.Marshal(api.CreateWebhookSubscriptionRequest{
+		Url:        receiver.URL,
+		EventTypes: &[]api.WebhookEventType{api.OrderCreated},
+		Secret:     &webhookSecret,
+	})
+	if err != nil {
+		t.Fatalf("error marshalling request, got %v", err)
+	}
+	resp, err = http.Post("http://localhost:8085/webhooks", "application/json", bytes.NewReader(b))
+	if err != nil {
+		t.Fatalf("http request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected 201 Created, got %d", resp.StatusCode)
+	}
+
 	// Create Order
-	idempotencyKey := openapiTypes.UUID{}
+	idempotencyKey := "3deb76e4-cd89-4aa3-b143-89e9c0ed11db"
 	sku := openapiTypes.UUID{}
 
-	_ = idempotencyKey.UnmarshalText([]byte("3deb76e4-cd89-4aa3-b143-89e9c0ed11db"))
 	_ = sku.UnmarshalText([]byte("3deb76e4-cd89-4aa3-b143-89e9c0ed11ad"))
 
-	b, err := json.Marshal(api.CreateOrderRequest{
-		Quantity:        4,
-		ShippingAddress: "address",
-		IdempotencyKey:  &idempotencyKey,
-		Sku:             sku,
+	shippingAddress := api.ShippingAddress{
+		Name:       "John Doe",
+		Lines:      []string{"Andrassy ut 1."},
+		City:       "Budapest",
+		PostalCode: "1061",
+		Country:    "HU",
+	}
+
+	b, err = json.Marshal(api.CreateOrderRequest{
+		Items: []api.OrderItem{
+			{
+				Sku:       sku,
+				Quantity:  4,
+				UnitPrice: "19.99",
+			},
+		},
+		Currency:        "EUR",
+		ShippingAddress: shippingAddress,
 	})
 	if err != nil {
 		t.Fatalf("error marshalling request, got %v", err)
 
 	}
-	resp, err = http.Post("http://localhost:8085/orders", "application/json", bytes.NewReader(b))
+	resp, err = createOrder(b, idempotencyKey)
 	if err != nil {
 		t.Fatalf("http request failed: %v", err)
 	}
@@ -116,8 +160,17 @@ func TestAppWithPostgres(t *testing.T) {
 		t.Fatalf("err reading body: %v", err)
 	}
 
+	createdOrder := api.Order{}
+	if err := json.Unmarshal(respBody, &createdOrder); err != nil {
+		t.Fatalf("err reading body: %v", err)
+	}
+
+	if createdOrder.Subtotal != "79.96" || createdOrder.Tax != "21.59" || createdOrder.Total != "101.55" {
+		t.Fatalf("unexpected order totals, got %s + %s = %s", createdOrder.Subtotal, createdOrder.Tax, createdOrder.Total)
+	}
+
 	// Create order again with same idempotency key
-	resp, err = http.Post("http://localhost:8085/orders", "application/json", bytes.NewReader(b))
+	resp, err = createOrder(b, idempotencyKey)
 	if err != nil {
 		t.Fatalf("http request failed: %v", err)
 	}
@@ -127,6 +180,35 @@ func TestAppWithPostgres(t *testing.T) {
 		t.Fatalf("expected 200 OK, got %d", resp.StatusCode)
 	}
 
+	if resp.Header.Get("Idempotent-Replayed") != "true" {
+		t.Fatalf("expected replayed response, got Idempotent-Replayed %q", resp.Header.Get("Idempotent-Replayed"))
+	}
+
+	// Create order with same idempotency key and different body is rejected
+	differentBody, err := json.Marshal(api.CreateOrderRequest{
+		Items: []api.OrderItem{
+			{
+				Sku:       sku,
+				Quantity:  5,
+				UnitPrice: "19.99",
+			},
+		},
+		Currency:        "EUR",
+		ShippingAddress: shippingAddress,
+	})
+	if err != nil {
+		t.Fatalf("error marshalling request, got %v", err)
+	}
+	resp, err = createOrder(differentBody, idempotencyKey)
+	if err != nil {
+		t.Fatalf("http request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422 Unprocessable Entity, got %d", resp.StatusCode)
+	}
+
 	order := map[string]interface{}{}
 	if err := json.Unmarshal(respBody, &order); err != nil {
 		t.Fatalf("err reading body: %v", err)
@@ -149,4 +231,317 @@ func TestAppWithPostgres(t *testing.T) {
 	if resp.StatusCode != http.StatusOK {
 		t.Fatalf("expected 200 OK, got %d", resp.StatusCode)
 	}
+
+	etag := resp.Header.Get("ETag")
+
+	// Get Order by ID with matching If-None-Match
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:8085/orders/%s", id), nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("If-None-Match", etag)
+
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("http request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotModified {
+		t.Fatalf("expected 304 Not Modified, got %d", resp.StatusCode)
+	}
+
+	// Get Order by ID over grpc
+	conn, err := grpc.NewClient("localhost:9095", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to create grpc client: %v", err)
+	}
+	defer conn.Close()
+
+	grpcOrder, err := ordersv1.NewOrderServiceClient(conn).GetOrder(ctx, &ordersv1.GetOrderRequest{OrderId: id})
+	if err != nil {
+		t.Fatalf("grpc request failed: %v", err)
+	}
+
+	if grpcOrder.GetTotal() != createdOrder.Total || grpcOrder.GetStatus() != ordersv1.OrderStatus_ORDER_STATUS_PENDING {
+		t.Fatalf("unexpected grpc order, got total %s and status %s", grpcOrder.GetTotal(), grpcOrder.GetStatus())
+	}
+
+	// Create the same order over grpc with the idempotency key used over http, it replays the order
+	replayedOrder, err := ordersv1.NewOrderServiceClient(conn).CreateOrder(ctx, &ordersv1.CreateOrderRequest{
+		Items:    []*ordersv1.OrderItem{{Sku: sku.String(), Quantity: 4, UnitPrice: "19.99"}},
+		Currency: "EUR",
+		ShippingAddress: &ordersv1.ShippingAddress{
+			Name:       shippingAddress.Name,
+			Lines:      shippingAddress.Lines,
+			City:       shippingAddress.City,
+			PostalCode: shippingAddress.PostalCode,
+			Country:    shippingAddress.Country,
+		},
+		IdempotencyKey: &idempotencyKey,
+	})
+	if err != nil {
+		t.Fatalf("grpc request failed: %v", err)
+	}
+
+	if replayedOrder.GetId() != id {
+		t.Fatalf("expected grpc to replay order %s, got %s", id, replayedOrder.GetId())
+	}
+
+	// List orders
+	resp, err = http.Get("http://localhost:8085/orders?limit=1&status=pending")
+	if err != nil {
+		t.Fatalf("http request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", resp.StatusCode)
+	}
+
+	listResponse := api.ListOrdersResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(&listResponse); err != nil {
+		t.Fatalf("err reading body: %v", err)
+	}
+
+	if len(listResponse.Items) != 1 || listResponse.Items[0].Id.String() != id {
+		t.Fatalf("expected created order to be listed, got %v", listResponse.Items)
+	}
+
+	// Ship order
+	b, err = json.Marshal(api.TransitionOrderRequest{
+		Status: api.Shipped,
+	})
+	if err != nil {
+		t.Fatalf("error marshalling request, got %v", err)
+	}
+	resp, err = postJSON(fmt.Sprintf("http://localhost:8085/orders/%s/transitions", id), b, etag)
+	if err != nil {
+		t.Fatalf("http request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", resp.StatusCode)
+	}
+
+	staleETag := etag
+	etag = resp.Header.Get("ETag")
+
+	// Change based on stale version is rejected
+	resp, err = postJSON(fmt.Sprintf("http://localhost:8085/orders/%s/transitions", id), b, staleETag)
+	if err != nil {
+		t.Fatalf("http request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusPreconditionFailed {
+		t.Fatalf("expected 412 Precondition Failed, got %d", resp.StatusCode)
+	}
+
+	// Cancel shipped order is rejected
+	b, err = json.Marshal(api.CancelOrderRequest{
+		Reason: api.CustomerRequest,
+	})
+	if err != nil {
+		t.Fatalf("error marshalling request, got %v", err)
+	}
+	resp, err = postJSON(fmt.Sprintf("http://localhost:8085/orders/%s/cancel", id), b, etag)
+	if err != nil {
+		t.Fatalf("http request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusConflict {
+		t.Fatalf("expected 409 Conflict, got %d", resp.StatusCode)
+	}
+
+	conflict := api.Problem{}
+	if err := json.NewDecoder(resp.Body).Decode(&conflict); err != nil {
+		t.Fatalf("error decoding problem, got %v", err)
+	}
+
+	if conflict.Code != "illegal_status_transition" || conflict.TraceId == nil {
+		t.Fatalf("expected illegal_status_transition problem with trace id, got %+v", conflict)
+	}
+
+	// Get order history
+	resp, err = http.Get(fmt.Sprintf("http://localhost:8085/orders/%s/history", id))
+	if err != nil {
+		t.Fatalf("http request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	historyResponse := api.OrderHistoryResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(&historyResponse); err != nil {
+		t.Fatalf("err reading body: %v", err)
+	}
+
+	if len(historyResponse.Items) != 2 ||
+		historyResponse.Items[0].Action != api.OrderHistoryEntryActionCreated ||
+		historyResponse.Items[1].Action != api.OrderHistoryEntryActionStatusChanged {
+		t.Fatalf("expected created and status changed history entries, got %v", historyResponse.Items)
+	}
+
+	// Stream order events resuming after the created entry
+	streamCtx, stopStream := context.WithTimeout(ctx, 5*time.Second)
+	defer stopStream()
+
+	req, err = http.NewRequestWithContext(streamCtx, http.MethodGet,
+		fmt.Sprintf("http://localhost:8085/orders/%s/events", id), nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("Last-Event-ID", fmt.Sprint(historyResponse.Items[0].Id))
+
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("http request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("expected text/event-stream, got %s", resp.Header.Get("Content-Type"))
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	statusEvent := api.OrderStatusEvent{}
+	for scanner.Scan() {
+		if data, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+			if err := json.Unmarshal([]byte(data), &statusEvent); err != nil {
+				t.Fatalf("err reading event: %v", err)
+			}
+			break
+		}
+	}
+	stopStream()
+
+	if statusEvent.Id != historyResponse.Items[1].Id || statusEvent.Status != api.OrderStatusShipped {
+		t.Fatalf("expected shipped status event, got %v", statusEvent)
+	}
+
+	// Cancel pending order
+	b, err = json.Marshal(api.CreateOrderRequest{
+		Items:           []api.OrderItem{{Sku: sku, Quantity: 1, UnitPrice: "5"}},
+		Currency:        "EUR",
+		ShippingAddress: shippingAddress,
+	})
+	if err != nil {
+		t.Fatalf("error marshalling request, got %v", err)
+	}
+	resp, err = http.Post("http://localhost:8085/orders", "application/json", bytes.NewReader(b))
+	if err != nil {
+		t.Fatalf("http request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	pendingOrder := api.Order{}
+	if err := json.NewDecoder(resp.Body).Decode(&pendingOrder); err != nil {
+		t.Fatalf("err reading body: %v", err)
+	}
+
+	pendingOrderETag := resp.Header.Get("ETag")
+
+	// Cancel without If-Match is rejected
+	resp, err = postJSON(fmt.Sprintf("http://localhost:8085/orders/%s/cancel", pendingOrder.Id), []byte(`{"reason":"other"}`), "")
+	if err != nil {
+		t.Fatalf("http request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusPreconditionRequired {
+		t.Fatalf("expected 428 Precondition Required, got %d", resp.StatusCode)
+	}
+
+	b, err = json.Marshal(api.CancelOrderRequest{
+		Reason: api.OutOfStock,
+	})
+	if err != nil {
+		t.Fatalf("error marshalling request, got %v", err)
+	}
+	resp, err = postJSON(fmt.Sprintf("http://localhost:8085/orders/%s/cancel", pendingOrder.Id), b, pendingOrderETag)
+	if err != nil {
+		t.Fatalf("http request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", resp.StatusCode)
+	}
+
+	// List dead-lettered events, every event was processed
+	resp, err = http.Get("http://localhost:8085/admin/dead-letter-events")
+	if err != nil {
+		t.Fatalf("http request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	deadLetterEvents := api.ListDeadLetterEventsResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(&deadLetterEvents); err != nil {
+		t.Fatalf("err reading body: %v", err)
+	}
+
+	if len(deadLetterEvents.Items) != 0 {
+		t.Fatalf("expected no dead-lettered events, got %d", len(deadLetterEvents.Items))
+	}
+
+	// Replay of unknown dead-lettered event
+	resp, err = http.Post("http://localhost:8085/admin/dead-letter-events/1/replay", "application/json", nil)
+	if err != nil {
+		t.Fatalf("http request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected 404 Not Found, got %d", resp.StatusCode)
+	}
+
+	// Order created webhook is delivered
+	select {
+	case body := <-webhookDeliveries:
+		delivery := struct {
+			SpecVersion string `json:"specversion"`
+			Type        string `json:"type"`
+			Data        struct {
+				Order struct {
+					ID string `json:"id"`
+				} `json:"order"`
+			} `json:"data"`
+		}{}
+		if err := json.Unmarshal(body, &delivery); err != nil {
+			t.Fatalf("err reading webhook body: %v", err)
+		}
+
+		if delivery.SpecVersion != "1.0" || delivery.Type != "order.created" {
+			t.Fatalf("expected order.created cloudevent webhook, got %s %s", delivery.SpecVersion, delivery.Type)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("expected order created webhook to be delivered")
+	}
+}
+
+func postJSON(url string, body []byte, ifMatch string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+
+	return http.DefaultClient.Do(req)
+}
+
+func createOrder(body []byte, idempotencyKey string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodPost, "http://localhost:8085/orders", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", idempotencyKey)
+
+	return http.DefaultClient.Do(req)
 }
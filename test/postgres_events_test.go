@@ -0,0 +1,174 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/amacneil/dbmate/v2/pkg/dbmate"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/leetm4n/orders-service/db"
+	"github.com/leetm4n/orders-service/internal/model"
+	"github.com/leetm4n/orders-service/internal/repo"
+	"github.com/leetm4n/orders-service/internal/service"
+	"github.com/leetm4n/orders-service/internal/worker"
+	"github.com/leetm4n/orders-service/pkg/cloudevents"
+	"github.com/leetm4n/orders-service/pkg/events"
+	"github.com/leetm4n/orders-service/pkg/money"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// startPostgres runs a migrated postgres for the test and returns its url.
+func startPostgres(t *testing.T) string {
+	t.Helper()
+
+	ctx := t.Context()
+
+	pgContainer, err := postgres.Run(ctx,
+		"postgres:18",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(wait.ForListeningPort("5432/tcp")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = pgContainer.Terminate(context.Background())
+	})
+
+	host, _ := pgContainer.Host(ctx)
+	port, _ := pgContainer.MappedPort(ctx, "5432/tcp")
+
+	databaseURL := fmt.Sprintf(
+		"postgres://testuser:testpass@%s:%s/testdb?sslmode=disable",
+		host, port.Port(),
+	)
+
+	url, err := url.Parse(databaseURL)
+	if err != nil {
+		t.Fatalf("failed to parse database URL: %v", err)
+	}
+
+	migrator := dbmate.New(url)
+	migrator.MigrationsDir = []string{"./migrations"}
+	migrator.FS = db.Migrations
+
+	if err := migrator.Migrate(); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+
+	return databaseURL
+}
+
+// TestPostgresEventBrokerLargeOrder relays the event of an order with the most items the api accepts, which does not
+// fit in a notification, over the postgres transport while no consumer listens.
+func TestPostgresEventBrokerLargeOrder(t *testing.T) {
+	ctx := t.Context()
+
+	pool, err := pgxpool.New(ctx, startPostgres(t))
+	if err != nil {
+		t.Fatalf("failed to create pool: %v", err)
+	}
+	defer pool.Close()
+
+	orders := repo.NewPostgresOrderRepository(pool)
+
+	taxRatePercent, err := money.ParseAmount("27")
+	if err != nil {
+		t.Fatalf("ParseAmount() error = %v", err)
+	}
+
+	orderService := service.NewOrderService(service.OrderServiceOptions{
+		Repository:        orders,
+		TaxRatePercent:    taxRatePercent,
+		IdempotencyKeyTTL: time.Hour,
+		EventSource:       "/orders-service",
+		WatchPollInterval: time.Second,
+	})
+
+	items := make([]service.OrderItemInput, 0, 100)
+	for range 100 {
+		items = append(items, service.OrderItemInput{Sku: uuid.New(), Quantity: 1000, UnitPrice: "123456.78"})
+	}
+
+	order, _, err := orderService.CreateOrder(ctx, service.CreateOrderInput{
+		Items:    items,
+		Currency: "EUR",
+		ShippingAddress: service.AddressInput{
+			Name:       "John Doe",
+			Lines:      []string{"Andrassy ut 1."},
+			City:       "Budapest",
+			PostalCode: "1061",
+			Country:    "HU",
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+
+	producer := events.NewPostgresProducer(pool, "orders_events_test")
+
+	// nobody listens yet, so the notification of the event is lost
+	pending, err := orders.LeasePendingOutboxEvents(ctx, 10, time.Minute)
+	if err != nil || len(pending) != 1 {
+		t.Fatalf("LeasePendingOutboxEvents() = %d, %v, want 1 event", len(pending), err)
+	}
+
+	if err := producer.Publish(ctx, events.Message{ID: pending[0].ID.String()}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	if err := orders.MarkOutboxEventDispatched(ctx, pending[0].ID); err != nil {
+		t.Fatalf("MarkOutboxEventDispatched() error = %v", err)
+	}
+
+	consumer := events.NewPostgresConsumer(pool, "orders_events_test", worker.OutboxMessageStore(orders))
+	defer consumer.Close()
+
+	receiveCtx, cancelReceive := context.WithTimeout(ctx, 5*time.Second)
+	defer cancelReceive()
+
+	// the consumer finds the event nobody received once it starts listening
+	msg, err := consumer.Receive(receiveCtx)
+	if err != nil {
+		t.Fatalf("Receive() error = %v", err)
+	}
+
+	if msg.Type != model.OrderCreatedEventType || msg.Key != order.ID.String() {
+		t.Errorf("Receive() = %s of %s, want %s of %s", msg.Type, msg.Key, model.OrderCreatedEventType, order.ID)
+	}
+
+	// a received event is not received again, even when it is notified once more
+	if err := producer.Publish(ctx, events.Message{ID: msg.ID}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	againCtx, cancelAgain := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancelAgain()
+
+	if again, err := consumer.Receive(againCtx); err == nil {
+		t.Fatalf("Receive() returned %s a second time", again.ID)
+	}
+
+	event := cloudevents.Event{}
+	if err := json.Unmarshal(msg.Payload, &event); err != nil {
+		t.Fatalf("failed to unmarshal event: %v", err)
+	}
+
+	created := model.OrderCreatedEvent{}
+	if err := event.DecodeData(&created); err != nil {
+		t.Fatalf("failed to decode event data: %v", err)
+	}
+
+	if len(created.Order.Items) != len(items) {
+		t.Errorf("event has %d items, want %d", len(created.Order.Items), len(items))
+	}
+}